@@ -0,0 +1,289 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+const (
+	minChartWidth  = 320
+	minChartHeight = 200
+)
+
+// ChartOptions contains the options for the Chart.
+type ChartOptions struct {
+	// Title is displayed at the top of the chart. It's only rendered in SVG.
+	Title string
+
+	// Width represents the width of the chart in pixels.
+	Width int
+
+	// Height represents the height of the chart in pixels.
+	Height int
+}
+
+func (o *ChartOptions) validate() error {
+	if o.Width < minChartWidth {
+		return fmt.Errorf("width cannot be less than %d", minChartWidth)
+	}
+
+	if o.Height < minChartHeight {
+		return fmt.Errorf("height cannot be less than %d", minChartHeight)
+	}
+
+	return nil
+}
+
+// Chart represents a line chart made up of one or more time series.
+// The zero value is not useful. Use NewChart to create a new instance.
+type Chart struct {
+	options ChartOptions
+	series  []Series
+}
+
+// NewChart returns a new instance of Chart.
+func NewChart(options ChartOptions) (*Chart, error) {
+	if err := options.validate(); err != nil {
+		return nil, fmt.Errorf("error validating chart configuration: %w", err)
+	}
+
+	return &Chart{
+		options: options,
+	}, nil
+}
+
+// Series returns the time series added to the chart.
+func (c *Chart) Series() []Series {
+	return c.series
+}
+
+// AddSeries adds a time series to the chart.
+func (c *Chart) AddSeries(series Series) {
+	c.series = append(c.series, series)
+}
+
+// AddDataIterator scrapes the DataIterator according to the settings of the Scraper and adds the resulting time series
+// to the chart.
+// The Scraper must be finite. The time series stops at the first scrape where the DataIterator is exhausted, which is
+// marked as stale. Stale samples are marked as stale as well, but the time series carries on. Skipped scrapes are marked
+// as missing.
+// Counter resets are only marked when the metric type is promadapter.MetricTypeCounter.
+func (c *Chart) AddDataIterator(scraper *metrics.Scraper, name string, metricType promadapter.MetricType, dataIterator metrics.DataIterator) error {
+	if scraper.IsInfinite() {
+		return fmt.Errorf("error collecting time series: scraper must be finite")
+	}
+
+	series := Series{
+		Name: name,
+	}
+
+	iter := scraper.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		scrapeResult := dataIterator.Evaluate(scrapeInfo)
+		if scrapeResult.Exhausted {
+			series.Points = append(series.Points, Point{Time: scrapeInfo.IterationTime, Stale: true})
+			break
+		}
+
+		series.Points = append(series.Points, Point{
+			Time:    scrapeInfo.IterationTime,
+			Value:   scrapeResult.Value,
+			Missing: scrapeResult.Missing || scrapeInfo.Skipped,
+			Stale:   scrapeResult.Stale && !scrapeInfo.Skipped,
+		})
+	}
+
+	if metricType == promadapter.MetricTypeCounter {
+		markCounterResets(series.Points)
+	}
+
+	c.AddSeries(series)
+
+	return nil
+}
+
+// AddMetric scrapes the metric according to the settings of the Scraper and adds every time series of the metric to
+// the chart.
+// The Scraper must be finite. Note that scraping the metric advances the iterators of its time series, hence a fresh
+// metric should be used.
+// Time series are named after the metric family and their labels. A time series that doesn't return a sample on a
+// given scrape is marked as missing, until its stale marker has been sent.
+// Counter resets are only marked when the metric is a counter. Histogram and summary metrics are not supported.
+func (c *Chart) AddMetric(scraper *metrics.Scraper, metric promadapter.MetricObservable) error {
+	if scraper.IsInfinite() {
+		return fmt.Errorf("error collecting time series: scraper must be finite")
+	}
+
+	switch metric.Desc().MetricType {
+	case promadapter.MetricTypeHistogram, promadapter.MetricTypeNativeHistogram:
+		return fmt.Errorf("error collecting time series: histogram metrics are not supported")
+	case promadapter.MetricTypeSummary:
+		return fmt.Errorf("error collecting time series: summary metrics are not supported")
+	}
+
+	var seriesList []*metricSeries
+	seriesIndex := make(map[string]int)
+
+	// scrapeTimes contains the time of every scrape seen so far, so time series showing up late can be backfilled with
+	// missing points.
+	var scrapeTimes []time.Time
+
+	iter := scraper.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		seen := make(map[int]struct{})
+
+		for _, metricResult := range metric.Evaluate(scrapeInfo) {
+			name := seriesName(metric.Desc().MetricFamily, metricResult.LabelsSet)
+
+			index, ok := seriesIndex[name]
+			if !ok {
+				index = len(seriesList)
+				seriesIndex[name] = index
+
+				series := &metricSeries{Series: Series{Name: name}}
+				for _, scrapeTime := range scrapeTimes {
+					series.Points = append(series.Points, Point{Time: scrapeTime, Missing: true})
+				}
+				seriesList = append(seriesList, series)
+			}
+
+			series := seriesList[index]
+			seen[index] = struct{}{}
+
+			if metricResult.StaleMarker {
+				series.Points = append(series.Points, Point{Time: scrapeInfo.IterationTime, Stale: true})
+				series.ended = true
+				continue
+			}
+
+			series.Points = append(series.Points, Point{Time: scrapeInfo.IterationTime, Value: metricResult.Value})
+			// the time series carries on after a stale sample
+			series.ended = false
+		}
+
+		for index, series := range seriesList {
+			if _, ok := seen[index]; ok || series.ended {
+				continue
+			}
+			series.Points = append(series.Points, Point{Time: scrapeInfo.IterationTime, Missing: true})
+		}
+
+		scrapeTimes = append(scrapeTimes, scrapeInfo.IterationTime)
+	}
+
+	for _, series := range seriesList {
+		if metric.Desc().MetricType == promadapter.MetricTypeCounter {
+			markCounterResets(series.Points)
+		}
+		c.AddSeries(series.Series)
+	}
+
+	return nil
+}
+
+// WriteSVG renders the chart as an SVG image.
+func (c *Chart) WriteSVG(w io.Writer) error {
+	canvas := newSVGCanvas(c.options.Width, c.options.Height)
+	c.draw(canvas)
+
+	if _, err := io.WriteString(w, canvas.String()); err != nil {
+		return fmt.Errorf("error writing svg: %w", err)
+	}
+
+	return nil
+}
+
+// WritePNG renders the chart as a PNG image.
+// Since no fonts are available in the standard library, text (title, axis labels and legend) is left out of the PNG.
+func (c *Chart) WritePNG(w io.Writer) error {
+	canvas := newPNGCanvas(c.options.Width, c.options.Height)
+	c.draw(canvas)
+
+	if err := canvas.encode(w); err != nil {
+		return fmt.Errorf("error writing png: %w", err)
+	}
+
+	return nil
+}
+
+// Series represents a single time series in the chart.
+type Series struct {
+	// Name is displayed in the legend.
+	Name string
+
+	// Points contains the points of the time series in chronological order.
+	Points []Point
+}
+
+// Point represents the outcome of a single scrape.
+type Point struct {
+	// Time represents the time of the scrape.
+	Time time.Time
+
+	// Value represents the value of the sample. It's meaningless if the point is missing or stale.
+	Value float64
+
+	// Missing indicates the scrape failed to retrieve a sample.
+	Missing bool
+
+	// Stale indicates a stale marker was sent, i.e., the time series was exhausted or the sample was stale.
+	Stale bool
+
+	// CounterReset indicates the value dropped when compared to the previous sample of a counter.
+	CounterReset bool
+}
+
+// hasValue reports whether the point has a value to be plotted.
+func (p Point) hasValue() bool {
+	return !p.Missing && !p.Stale && isFinite(p.Value)
+}
+
+// metricSeries keeps track of a time series while a metric is being scraped.
+type metricSeries struct {
+	Series
+
+	// ended indicates the stale marker has been sent.
+	ended bool
+}
+
+// markCounterResets flags the points whose value is lower than the value of the previous sample.
+func markCounterResets(points []Point) {
+	var previous *Point
+
+	for i := range points {
+		if !points[i].hasValue() {
+			continue
+		}
+
+		if previous != nil && points[i].Value < previous.Value {
+			points[i].CounterReset = true
+		}
+		previous = &points[i]
+	}
+}
+
+// seriesName returns the name of a time series in the usual prometheus format: metric_family{label="value"}.
+func seriesName(metricFamily string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return metricFamily
+	}
+
+	labelsNames := make([]string, 0, len(labels))
+	for labelName := range labels {
+		labelsNames = append(labelsNames, labelName)
+	}
+	sort.Strings(labelsNames)
+
+	pairs := make([]string, 0, len(labelsNames))
+	for _, labelName := range labelsNames {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", labelName, labels[labelName]))
+	}
+
+	return fmt.Sprintf("%s{%s}", metricFamily, strings.Join(pairs, ","))
+}
@@ -0,0 +1,188 @@
+package chart_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/chart"
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+func helperScraper(t *testing.T, iterationCountLimit int) *metrics.Scraper {
+	t.Helper()
+
+	scraper, err := metrics.NewScraper(
+		metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		},
+		metrics.WithScraperIterationCountLimit(iterationCountLimit),
+	)
+	require.NoError(t, err)
+
+	return scraper
+}
+
+func helperChart(t *testing.T) *chart.Chart {
+	t.Helper()
+
+	c, err := chart.NewChart(chart.ChartOptions{
+		Title:  "Requests",
+		Width:  800,
+		Height: 400,
+	})
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNewChart(t *testing.T) {
+	t.Run("should fail given a chart that is too small", func(t *testing.T) {
+		_, err := chart.NewChart(chart.ChartOptions{Width: 100, Height: 400})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating chart configuration: width cannot be less than 320"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
+
+func TestChartAddDataIterator(t *testing.T) {
+	t.Run("should mark missing scrapes, counter resets and the exhaustion of the data iterator", func(t *testing.T) {
+		c := helperChart(t)
+
+		dataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 1}, {Missing: true}, {Value: 5}, {Value: 2}, {Value: 4},
+		})
+
+		err := c.AddDataIterator(helperScraper(t, 10), "requests_total", promadapter.MetricTypeCounter, dataGenerator.Iterator())
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(c.Series()))
+		points := c.Series()[0].Points
+
+		require.Equal(t, 6, len(points))
+		assert.True(t, points[1].Missing)
+		assert.False(t, points[2].CounterReset)
+		assert.True(t, points[3].CounterReset)
+		assert.False(t, points[4].CounterReset)
+		assert.True(t, points[5].Stale)
+	})
+
+	t.Run("should not mark counter resets on gauges", func(t *testing.T) {
+		c := helperChart(t)
+
+		dataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 5}, {Value: 2}})
+
+		err := c.AddDataIterator(helperScraper(t, 2), "temperature", promadapter.MetricTypeGauge, dataGenerator.Iterator())
+		require.NoError(t, err)
+
+		points := c.Series()[0].Points
+		require.Equal(t, 2, len(points))
+		assert.False(t, points[1].CounterReset)
+	})
+
+	t.Run("should fail given an infinite scraper", func(t *testing.T) {
+		c := helperChart(t)
+
+		scraper, err := metrics.NewScraper(metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		})
+		require.NoError(t, err)
+
+		err = c.AddDataIterator(scraper, "temperature", promadapter.MetricTypeGauge, discrete.NewVoidSegmentDataGenerator(1).Iterator())
+		require.Error(t, err)
+		assert.Equal(t, "error collecting time series: scraper must be finite", err.Error())
+	})
+}
+
+func TestChartAddMetric(t *testing.T) {
+	t.Run("should add every time series of the metric", func(t *testing.T) {
+		c := helperChart(t)
+
+		metric := promadapter.NewMetric("requests_total", "Number of requests", promadapter.MetricTypeCounter, []string{"code"})
+
+		err := metric.AddTimeSeries(discrete.NewMetricTimeSeries(
+			map[string]string{"code": "200"},
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Value: 2}}),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		err = metric.AddTimeSeries(discrete.NewMetricTimeSeries(
+			map[string]string{"code": "500"},
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Missing: true}, {Value: 3}, {Value: 1}, {Value: 2}}),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		err = c.AddMetric(helperScraper(t, 6), metric)
+		require.NoError(t, err)
+
+		series := c.Series()
+		require.Equal(t, 2, len(series))
+
+		assert.Equal(t, `requests_total{code="200"}`, series[0].Name)
+		require.Equal(t, 3, len(series[0].Points))
+		assert.True(t, series[0].Points[2].Stale)
+
+		assert.Equal(t, `requests_total{code="500"}`, series[1].Name)
+		require.Equal(t, 5, len(series[1].Points))
+		assert.True(t, series[1].Points[0].Missing)
+		assert.True(t, series[1].Points[2].CounterReset)
+		assert.True(t, series[1].Points[4].Stale)
+	})
+}
+
+func TestChartWrite(t *testing.T) {
+	dataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+		{Value: 1}, {Missing: true}, {Value: 5}, {Value: 2},
+	})
+
+	c := helperChart(t)
+	err := c.AddDataIterator(helperScraper(t, 10), "requests_total", promadapter.MetricTypeCounter, dataGenerator.Iterator())
+	require.NoError(t, err)
+
+	t.Run("should render the markers in the SVG", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := c.WriteSVG(&buf)
+		require.NoError(t, err)
+
+		result := buf.String()
+		assert.Contains(t, result, `<svg xmlns="http://www.w3.org/2000/svg" width="800" height="400"`)
+		assert.Contains(t, result, ">Requests</text>")
+		assert.Contains(t, result, `class="missing"`)
+		assert.Contains(t, result, `class="stale"`)
+		assert.Contains(t, result, `class="counter-reset"`)
+	})
+
+	t.Run("should render a valid PNG", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := c.WritePNG(&buf)
+		require.NoError(t, err)
+
+		img, err := png.Decode(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, 800, img.Bounds().Dx())
+		assert.Equal(t, 400, img.Bounds().Dy())
+
+		// The missing scrape is marked with a red cross on the time axis.
+		foundMissingMarker := false
+		for x := 0; x < img.Bounds().Dx() && !foundMissingMarker; x++ {
+			for y := 0; y < img.Bounds().Dy(); y++ {
+				r, g, b, _ := img.At(x, y).RGBA()
+				if r>>8 == 214 && g>>8 == 39 && b>>8 == 40 {
+					foundMissingMarker = true
+					break
+				}
+			}
+		}
+		assert.True(t, foundMissingMarker)
+	})
+}
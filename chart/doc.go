@@ -0,0 +1,7 @@
+// Package chart renders static previews of time series, so scenarios can be inspected before being pushed into
+// prometheus.
+// Time series are collected by driving a metrics.Scraper over a metrics.DataIterator or over a whole
+// promadapter.MetricObservable, and rendered as a line chart in either SVG or PNG.
+// Missing scrapes, stale markers and counter resets are marked on the chart.
+// Only the standard library is used, which makes it suitable to run in CI.
+package chart
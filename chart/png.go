@@ -0,0 +1,89 @@
+package chart
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// Check at compile time whether pngCanvas implements canvas interface.
+var _ canvas = (*pngCanvas)(nil)
+
+// pngCanvas rasterizes the chart into an image, which is then encoded as PNG.
+// Text is not supported, since there are no fonts available in the standard library.
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func newPNGCanvas(width, height int) *pngCanvas {
+	return &pngCanvas{
+		img: image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+}
+
+func (pc *pngCanvas) rect(x, y, width, height float64, fill color.RGBA) {
+	bounds := image.Rect(int(x), int(y), int(math.Ceil(x+width)), int(math.Ceil(y+height)))
+	draw.Draw(pc.img, bounds, &image.Uniform{C: fill}, image.Point{}, draw.Src)
+}
+
+// line draws the line by stamping a square brush, as wide as the line, along the way.
+func (pc *pngCanvas) line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64, dashed bool, _ string) {
+	const (
+		step       = 0.5
+		dashLength = 4.0
+		gapLength  = 3.0
+	)
+
+	length := math.Hypot(x2-x1, y2-y1)
+	half := width / 2
+
+	for travelled := 0.0; travelled <= length; travelled += step {
+		if dashed && math.Mod(travelled, dashLength+gapLength) >= dashLength {
+			continue
+		}
+
+		ratio := 0.0
+		if length > 0 {
+			ratio = travelled / length
+		}
+		x := x1 + ratio*(x2-x1)
+		y := y1 + ratio*(y2-y1)
+
+		for px := int(math.Round(x - half)); px <= int(math.Round(x+half-0.5)); px++ {
+			for py := int(math.Round(y - half)); py <= int(math.Round(y+half-0.5)); py++ {
+				pc.set(px, py, stroke)
+			}
+		}
+	}
+}
+
+func (pc *pngCanvas) circle(x, y, radius float64, stroke color.RGBA, filled bool, _ string) {
+	for px := int(math.Floor(x - radius - 1)); px <= int(math.Ceil(x+radius+1)); px++ {
+		for py := int(math.Floor(y - radius - 1)); py <= int(math.Ceil(y+radius+1)); py++ {
+			distance := math.Hypot(float64(px)+0.5-x, float64(py)+0.5-y)
+
+			if filled && distance <= radius {
+				pc.set(px, py, stroke)
+			} else if !filled && math.Abs(distance-radius) <= 0.75 {
+				pc.set(px, py, stroke)
+			}
+		}
+	}
+}
+
+func (pc *pngCanvas) text(_, _ float64, _ string, _ textAnchor, _ color.RGBA) {}
+
+func (pc *pngCanvas) set(x, y int, c color.RGBA) {
+	if !(image.Point{X: x, Y: y}).In(pc.img.Bounds()) {
+		return
+	}
+
+	pc.img.SetRGBA(x, y, c)
+}
+
+func (pc *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, pc.img)
+}
@@ -0,0 +1,263 @@
+package chart
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	marginLeft   = 70.0
+	marginRight  = 20.0
+	marginTop    = 40.0
+	marginBottom = 70.0
+
+	tickCount = 5
+)
+
+var (
+	colorBackground   = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	colorAxis         = color.RGBA{R: 60, G: 60, B: 60, A: 255}
+	colorGrid         = color.RGBA{R: 225, G: 225, B: 225, A: 255}
+	colorText         = color.RGBA{R: 30, G: 30, B: 30, A: 255}
+	colorMissing      = color.RGBA{R: 214, G: 39, B: 40, A: 255}
+	colorStale        = color.RGBA{R: 90, G: 90, B: 90, A: 255}
+	colorCounterReset = color.RGBA{R: 255, G: 127, B: 14, A: 255}
+
+	// seriesPalette contains the colors used by the time series, which are reused when there are more time series than
+	// colors.
+	seriesPalette = []color.RGBA{
+		{R: 31, G: 119, B: 180, A: 255},
+		{R: 44, G: 160, B: 44, A: 255},
+		{R: 148, G: 103, B: 189, A: 255},
+		{R: 140, G: 86, B: 75, A: 255},
+		{R: 227, G: 119, B: 194, A: 255},
+		{R: 23, G: 190, B: 207, A: 255},
+	}
+)
+
+type textAnchor string
+
+const (
+	textAnchorStart  textAnchor = "start"
+	textAnchorMiddle textAnchor = "middle"
+	textAnchorEnd    textAnchor = "end"
+)
+
+// canvas abstracts the drawing primitives of the supported image formats.
+// The class is used to identify the elements in formats that support it (i.e., SVG).
+type canvas interface {
+	rect(x, y, width, height float64, fill color.RGBA)
+	line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64, dashed bool, class string)
+	circle(x, y, radius float64, stroke color.RGBA, filled bool, class string)
+	text(x, y float64, content string, anchor textAnchor, fill color.RGBA)
+}
+
+// plotArea maps times and values to coordinates in the canvas.
+type plotArea struct {
+	left, right, top, bottom float64
+
+	timeMin, timeMax   time.Time
+	valueMin, valueMax float64
+}
+
+func newPlotArea(width, height int, series []Series) plotArea {
+	area := plotArea{
+		left:   marginLeft,
+		right:  float64(width) - marginRight,
+		top:    marginTop,
+		bottom: float64(height) - marginBottom,
+	}
+
+	first := true
+	hasValue := false
+
+	for _, s := range series {
+		for _, point := range s.Points {
+			if first || point.Time.Before(area.timeMin) {
+				area.timeMin = point.Time
+			}
+			if first || point.Time.After(area.timeMax) {
+				area.timeMax = point.Time
+			}
+			first = false
+
+			if !point.hasValue() {
+				continue
+			}
+
+			if !hasValue || point.Value < area.valueMin {
+				area.valueMin = point.Value
+			}
+			if !hasValue || point.Value > area.valueMax {
+				area.valueMax = point.Value
+			}
+			hasValue = true
+		}
+	}
+
+	if !area.timeMax.After(area.timeMin) {
+		area.timeMax = area.timeMin.Add(time.Second)
+	}
+
+	switch {
+	case !hasValue:
+		area.valueMin, area.valueMax = 0, 1
+	case area.valueMin == area.valueMax:
+		area.valueMin, area.valueMax = area.valueMin-1, area.valueMax+1
+	default:
+		padding := (area.valueMax - area.valueMin) * 0.05
+		area.valueMin, area.valueMax = area.valueMin-padding, area.valueMax+padding
+	}
+
+	return area
+}
+
+func (pa plotArea) x(t time.Time) float64 {
+	ratio := float64(t.Sub(pa.timeMin)) / float64(pa.timeMax.Sub(pa.timeMin))
+	return pa.left + ratio*(pa.right-pa.left)
+}
+
+func (pa plotArea) y(value float64) float64 {
+	ratio := (value - pa.valueMin) / (pa.valueMax - pa.valueMin)
+	return pa.bottom - ratio*(pa.bottom-pa.top)
+}
+
+// draw draws the whole chart onto the canvas.
+func (c *Chart) draw(cv canvas) {
+	width, height := float64(c.options.Width), float64(c.options.Height)
+	area := newPlotArea(c.options.Width, c.options.Height, c.series)
+
+	cv.rect(0, 0, width, height, colorBackground)
+
+	if c.options.Title != "" {
+		cv.text(width/2, marginTop/2+5, c.options.Title, textAnchorMiddle, colorText)
+	}
+
+	drawAxes(cv, area)
+
+	var hasMissing, hasStale, hasCounterReset bool
+
+	for i, series := range c.series {
+		seriesColor := seriesPalette[i%len(seriesPalette)]
+		drawSeries(cv, area, series, seriesColor)
+
+		for _, point := range series.Points {
+			hasMissing = hasMissing || point.Missing
+			hasStale = hasStale || point.Stale
+			hasCounterReset = hasCounterReset || point.CounterReset
+		}
+	}
+
+	drawLegend(cv, area, height, c.series, hasMissing, hasStale, hasCounterReset)
+}
+
+func drawAxes(cv canvas, area plotArea) {
+	for i := 0; i <= tickCount; i++ {
+		ratio := float64(i) / tickCount
+
+		// horizontal grid lines and value labels
+		value := area.valueMin + ratio*(area.valueMax-area.valueMin)
+		y := area.y(value)
+		cv.line(area.left, y, area.right, y, colorGrid, 1, false, "grid")
+		cv.text(area.left-8, y+4, strconv.FormatFloat(value, 'g', 4, 64), textAnchorEnd, colorText)
+
+		// vertical grid lines and time labels
+		t := area.timeMin.Add(time.Duration(ratio * float64(area.timeMax.Sub(area.timeMin))))
+		x := area.x(t)
+		cv.line(x, area.top, x, area.bottom, colorGrid, 1, false, "grid")
+		cv.text(x, area.bottom+18, formatTime(t, area.timeMax.Sub(area.timeMin)), textAnchorMiddle, colorText)
+	}
+
+	cv.line(area.left, area.bottom, area.right, area.bottom, colorAxis, 1, false, "axis")
+	cv.line(area.left, area.top, area.left, area.bottom, colorAxis, 1, false, "axis")
+}
+
+func drawSeries(cv canvas, area plotArea, series Series, seriesColor color.RGBA) {
+	// Points are only drawn individually when there is enough room between them.
+	drawPoints := len(series.Points) <= int((area.right-area.left)/6)
+
+	var previous *Point
+
+	for i := range series.Points {
+		point := series.Points[i]
+
+		switch {
+		case point.Missing:
+			x := area.x(point.Time)
+			cv.line(x-4, area.bottom-4, x+4, area.bottom+4, colorMissing, 2, false, "missing")
+			cv.line(x-4, area.bottom+4, x+4, area.bottom-4, colorMissing, 2, false, "missing")
+			previous = nil
+			continue
+		case point.Stale:
+			x := area.x(point.Time)
+			cv.line(x, area.top, x, area.bottom, colorStale, 1.5, true, "stale")
+			previous = nil
+			continue
+		case !point.hasValue():
+			// infinities and NaN can't be plotted
+			previous = nil
+			continue
+		}
+
+		x, y := area.x(point.Time), area.y(point.Value)
+
+		if previous != nil {
+			cv.line(area.x(previous.Time), area.y(previous.Value), x, y, seriesColor, 2, false, "series")
+		}
+
+		if drawPoints || previous == nil {
+			cv.circle(x, y, 2.5, seriesColor, true, "point")
+		}
+
+		if point.CounterReset {
+			cv.circle(x, y, 6, colorCounterReset, false, "counter-reset")
+		}
+
+		previous = &series.Points[i]
+	}
+}
+
+func drawLegend(cv canvas, area plotArea, height float64, series []Series, hasMissing, hasStale, hasCounterReset bool) {
+	x := area.left
+	y := height - 20
+
+	for i, s := range series {
+		seriesColor := seriesPalette[i%len(seriesPalette)]
+		cv.line(x, y-4, x+20, y-4, seriesColor, 2, false, "legend")
+		cv.text(x+25, y, s.Name, textAnchorStart, colorText)
+		x += 35 + float64(len(s.Name))*7
+	}
+
+	if hasMissing {
+		cv.line(x, y-8, x+8, y, colorMissing, 2, false, "legend")
+		cv.line(x, y, x+8, y-8, colorMissing, 2, false, "legend")
+		cv.text(x+13, y, "missing", textAnchorStart, colorText)
+		x += 75
+	}
+
+	if hasStale {
+		cv.line(x+4, y-12, x+4, y+2, colorStale, 1.5, true, "legend")
+		cv.text(x+13, y, "stale", textAnchorStart, colorText)
+		x += 60
+	}
+
+	if hasCounterReset {
+		cv.circle(x+5, y-4, 6, colorCounterReset, false, "legend")
+		cv.text(x+16, y, "counter reset", textAnchorStart, colorText)
+	}
+}
+
+// formatTime formats the time with a layout suited to the time range being displayed.
+func formatTime(t time.Time, timeRange time.Duration) string {
+	if timeRange > 24*time.Hour {
+		return t.Format("01-02 15:04")
+	}
+
+	return t.Format("15:04:05")
+}
+
+func isFinite(value float64) bool {
+	return !math.IsInf(value, 0) && !math.IsNaN(value)
+}
@@ -0,0 +1,68 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"strings"
+)
+
+// Check at compile time whether svgCanvas implements canvas interface.
+var _ canvas = (*svgCanvas)(nil)
+
+// svgCanvas draws the chart as an SVG document.
+type svgCanvas struct {
+	width  int
+	height int
+
+	builder strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	return &svgCanvas{
+		width:  width,
+		height: height,
+	}
+}
+
+func (sc *svgCanvas) rect(x, y, width, height float64, fill color.RGBA) {
+	sc.builder.WriteString(fmt.Sprintf(`<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s"/>`+"\n",
+		x, y, width, height, svgColor(fill)))
+}
+
+func (sc *svgCanvas) line(x1, y1, x2, y2 float64, stroke color.RGBA, width float64, dashed bool, class string) {
+	dash := ""
+	if dashed {
+		dash = ` stroke-dasharray="4 3"`
+	}
+
+	sc.builder.WriteString(fmt.Sprintf(`<line class="%s" x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%.2f"%s/>`+"\n",
+		class, x1, y1, x2, y2, svgColor(stroke), width, dash))
+}
+
+func (sc *svgCanvas) circle(x, y, radius float64, stroke color.RGBA, filled bool, class string) {
+	fill := "none"
+	if filled {
+		fill = svgColor(stroke)
+	}
+
+	sc.builder.WriteString(fmt.Sprintf(`<circle class="%s" cx="%.2f" cy="%.2f" r="%.2f" stroke="%s" stroke-width="1.50" fill="%s"/>`+"\n",
+		class, x, y, radius, svgColor(stroke), fill))
+}
+
+func (sc *svgCanvas) text(x, y float64, content string, anchor textAnchor, fill color.RGBA) {
+	sc.builder.WriteString(fmt.Sprintf(`<text x="%.2f" y="%.2f" text-anchor="%s" fill="%s">%s</text>`+"\n",
+		x, y, anchor, svgColor(fill), html.EscapeString(content)))
+}
+
+// String returns the SVG document.
+func (sc *svgCanvas) String() string {
+	header := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		sc.width, sc.height, sc.width, sc.height)
+
+	return header + sc.builder.String() + "</svg>\n"
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,426 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/chart"
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+const (
+	// defaultPreviewScrapeCount is the number of scrapes used when the number of iterations of the series definition
+	// cannot be determined.
+	defaultPreviewScrapeCount = 100
+
+	// plotLabelPrecision is the number of significant digits shown in the labels of the y-axis.
+	plotLabelPrecision = 4
+)
+
+func newPreviewCmd(parentCmd *cobra.Command) *cobra.Command {
+	selfCmd := &cobra.Command{
+		Use:   "preview [series definition file]",
+		Short: "Preview a series definition in the terminal",
+		Long: `Preview a series definition in the terminal.
+
+The series definition is described as a tree of data generators along with their options, then it is run through a
+scraper and the values are drawn as a chart and a table.
+Missing scrapes, stale points (stale samples or the series has been exhausted) and counter resets are highlighted.
+
+The series definition is either a file holding a data spec in JSON (.json) or YAML (.yaml or .yml), or a series
+written in the promtool notation provided with the --notation flag.`,
+		Example: `  promgen preview scenario.yaml --interval 30s --count 50
+  promgen preview --notation "0+10x20 _x3 200x5" --metric-type counter --svg preview.svg`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			outputErr := os.Stderr
+
+			if len(args) > 1 {
+				msg := pterm.Error.Sprintfln("Accepts at most 1 arg, received %d", len(args))
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			notation, _ := cmd.Flags().GetString("notation")
+			if (len(args) == 0) == (notation == "") {
+				msg := pterm.Error.Sprintfln("Either a series definition file or the --notation flag must be provided")
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			outputInfo := cmd.OutOrStdout()
+			outputErr := os.Stderr
+
+			notation, _ := cmd.Flags().GetString("notation")
+			startTimeRaw, _ := cmd.Flags().GetString("start")
+			scrapeInterval, _ := cmd.Flags().GetDuration("interval")
+			scrapeCount, _ := cmd.Flags().GetInt("count")
+			metricTypeRaw, _ := cmd.Flags().GetString("metric-type")
+			plotHeight, _ := cmd.Flags().GetInt("height")
+			plotWidth, _ := cmd.Flags().GetInt("width")
+			noTable, _ := cmd.Flags().GetBool("no-table")
+			noColor, _ := cmd.Flags().GetBool("no-color")
+			svgPath, _ := cmd.Flags().GetString("svg")
+			pngPath, _ := cmd.Flags().GetString("png")
+
+			if noColor {
+				pterm.DisableColor()
+			}
+
+			var metricType promadapter.MetricType
+			switch metricTypeRaw {
+			case "gauge":
+				metricType = promadapter.MetricTypeGauge
+			case "counter":
+				metricType = promadapter.MetricTypeCounter
+			default:
+				msg := pterm.Error.Sprintfln("Unknown metric type %q, expected gauge or counter", metricTypeRaw)
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			if scrapeInterval <= 0 {
+				msg := pterm.Error.Sprintfln("Scrape interval must be greater than zero")
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			if plotHeight < 3 {
+				msg := pterm.Error.Sprintfln("Chart height cannot be less than 3")
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			startTime := time.Now().UTC().Truncate(scrapeInterval)
+			if startTimeRaw != "" {
+				var err error
+				startTime, err = time.Parse(time.RFC3339, startTimeRaw)
+				if err != nil {
+					msg := pterm.Error.Sprintfln("Invalid start time %q, expected RFC3339 format", startTimeRaw)
+					_, _ = fmt.Fprint(outputErr, msg)
+					return ErrValidation
+				}
+			}
+
+			var seriesPath string
+			if len(args) == 1 {
+				seriesPath = args[0]
+			}
+
+			dataGenerator, err := loadSeriesDefinition(seriesPath, notation)
+			if err != nil {
+				msg := pterm.Error.Sprintfln("%s", err)
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			// By default, scrape the whole series plus one extra scrape to show the series going stale.
+			if scrapeCount <= 0 {
+				scrapeCount = defaultPreviewScrapeCount
+				if count, ok := discrete.IterationCount(dataGenerator.Describe()); ok {
+					scrapeCount = count + 1
+				}
+			}
+
+			scraper, err := metrics.NewScraper(
+				metrics.ScraperConfig{
+					StartTime:      startTime,
+					ScrapeInterval: scrapeInterval,
+				},
+				metrics.WithScraperIterationCountLimit(scrapeCount),
+			)
+			if err != nil {
+				msg := pterm.Error.Sprintfln("%s", err)
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrValidation
+			}
+
+			previewChart, err := chart.NewChart(chart.ChartOptions{
+				Title:  seriesTitle(seriesPath, notation),
+				Width:  1000,
+				Height: 400,
+			})
+			if err != nil {
+				msg := pterm.Error.Sprintfln("%s", err)
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrProgram
+			}
+
+			err = previewChart.AddDataIterator(scraper, seriesTitle(seriesPath, notation), metricType, dataGenerator.Iterator())
+			if err != nil {
+				msg := pterm.Error.Sprintfln("%s", err)
+				_, _ = fmt.Fprint(outputErr, msg)
+				return ErrProgram
+			}
+
+			points := previewChart.Series()[0].Points
+
+			if plotWidth <= 0 {
+				plotWidth = pterm.GetTerminalWidth() - 16
+			}
+
+			_, _ = fmt.Fprintf(outputInfo, "%s\n\n", discrete.DescribeWithOptions(dataGenerator.Describe()))
+			_, _ = fmt.Fprint(outputInfo, renderPlot(points, plotWidth, plotHeight))
+
+			if !noTable {
+				table, err := pterm.DefaultTable.WithHasHeader().WithData(tableData(points)).Srender()
+				if err != nil {
+					msg := pterm.Error.Sprintfln("%s", err)
+					_, _ = fmt.Fprint(outputErr, msg)
+					return ErrProgram
+				}
+				_, _ = fmt.Fprintf(outputInfo, "\n%s\n", table)
+			}
+
+			if svgPath != "" {
+				if err := writeChartFile(svgPath, previewChart.WriteSVG); err != nil {
+					msg := pterm.Error.Sprintfln("%s", err)
+					_, _ = fmt.Fprint(outputErr, msg)
+					return ErrProgram
+				}
+			}
+
+			if pngPath != "" {
+				if err := writeChartFile(pngPath, previewChart.WritePNG); err != nil {
+					msg := pterm.Error.Sprintfln("%s", err)
+					_, _ = fmt.Fprint(outputErr, msg)
+					return ErrProgram
+				}
+			}
+
+			return nil
+		},
+	}
+
+	selfCmd.Flags().StringP("notation", "n", "", "series written in the promtool notation (ex: \"0+10x100 _x5 stale\")")
+	selfCmd.Flags().String("start", "", "time of the first scrape in RFC3339 format (defaults to now)")
+	selfCmd.Flags().DurationP("interval", "i", 15*time.Second, "scrape interval")
+	selfCmd.Flags().Int("count", 0, "number of scrapes (defaults to the length of the series plus one)")
+	selfCmd.Flags().String("metric-type", "gauge", "metric type (gauge or counter), counter resets are only highlighted for counters")
+	selfCmd.Flags().Int("height", 15, "height of the chart in lines")
+	selfCmd.Flags().Int("width", 0, "maximum width of the chart in columns (defaults to the terminal width)")
+	selfCmd.Flags().Bool("no-table", false, "do not print the table of values")
+	selfCmd.Flags().Bool("no-color", false, "disable colors")
+	selfCmd.Flags().String("svg", "", "also write the chart as an SVG image to the path provided")
+	selfCmd.Flags().String("png", "", "also write the chart as a PNG image to the path provided")
+
+	parentCmd.AddCommand(selfCmd)
+	return selfCmd
+}
+
+// loadSeriesDefinition builds the data generator either from the file provided or from the notation provided.
+func loadSeriesDefinition(path string, notation string) (discrete.DataGenerator, error) {
+	if notation != "" {
+		return discrete.ParseSeriesNotation(notation)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading series definition: %w", err)
+	}
+
+	registry := discrete.NewRegistry()
+	continuous.RegisterBuilders(registry)
+
+	var dataSpec discrete.DataSpec
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		dataSpec, err = registry.UnmarshalDataSpecJSON(data)
+	case ".yaml", ".yml":
+		dataSpec, err = registry.UnmarshalDataSpecYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported series definition file extension %q, expected .json, .yaml or .yml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.Build(dataSpec)
+}
+
+func seriesTitle(path string, notation string) string {
+	if notation != "" {
+		return notation
+	}
+
+	return filepath.Base(path)
+}
+
+func writeChartFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating chart file: %w", err)
+	}
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	// Closing flushes the file, so its error must be reported, otherwise a truncated chart would go unnoticed.
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error closing chart file: %w", err)
+	}
+
+	return nil
+}
+
+// plotColumn represents a single column of the terminal chart.
+// When there are more points than columns, each column aggregates several points and shows the last value.
+type plotColumn struct {
+	value        float64
+	hasValue     bool
+	missing      bool
+	stale        bool
+	counterReset bool
+}
+
+// renderPlot draws the points as a scatter chart made of characters.
+// The y-axis is labelled with the min, mid and max values. Missing scrapes are marked below the x-axis and stale
+// points are drawn as a vertical line.
+func renderPlot(points []chart.Point, width int, height int) string {
+	if len(points) == 0 {
+		return "No scrapes to plot.\n"
+	}
+
+	columnCount := len(points)
+	if width > 0 && columnCount > width {
+		columnCount = width
+	}
+
+	columns := make([]plotColumn, columnCount)
+	for i, point := range points {
+		column := &columns[i*columnCount/len(points)]
+
+		switch {
+		case point.Missing:
+			column.missing = true
+		case point.Stale:
+			column.stale = true
+		case !math.IsInf(point.Value, 0) && !math.IsNaN(point.Value):
+			column.value = point.Value
+			column.hasValue = true
+			column.counterReset = column.counterReset || point.CounterReset
+		}
+	}
+
+	valueMin, valueMax := math.Inf(1), math.Inf(-1)
+	for _, column := range columns {
+		if column.hasValue {
+			valueMin = math.Min(valueMin, column.value)
+			valueMax = math.Max(valueMax, column.value)
+		}
+	}
+	if math.IsInf(valueMin, 1) {
+		valueMin, valueMax = 0, 1
+	}
+	if valueMin == valueMax {
+		valueMin, valueMax = valueMin-1, valueMax+1
+	}
+
+	labels := map[int]string{
+		0:                strconv.FormatFloat(valueMax, 'g', plotLabelPrecision, 64),
+		(height - 1) / 2: strconv.FormatFloat((valueMin+valueMax)/2, 'g', plotLabelPrecision, 64),
+		height - 1:       strconv.FormatFloat(valueMin, 'g', plotLabelPrecision, 64),
+	}
+	labelWidth := 0
+	for _, label := range labels {
+		if len(label) > labelWidth {
+			labelWidth = len(label)
+		}
+	}
+
+	var builder strings.Builder
+
+	for row := 0; row < height; row++ {
+		label, ok := labels[row]
+		if ok {
+			builder.WriteString(fmt.Sprintf("%*s ┤", labelWidth, label))
+		} else {
+			builder.WriteString(fmt.Sprintf("%*s │", labelWidth, ""))
+		}
+
+		for _, column := range columns {
+			columnRow := -1
+			if column.hasValue {
+				columnRow = int(math.Round((valueMax - column.value) / (valueMax - valueMin) * float64(height-1)))
+			}
+
+			switch {
+			case columnRow == row && column.counterReset:
+				builder.WriteString(pterm.FgMagenta.Sprint("◆"))
+			case columnRow == row:
+				builder.WriteString(pterm.FgCyan.Sprint("●"))
+			case column.stale:
+				builder.WriteString(pterm.FgYellow.Sprint("┊"))
+			default:
+				builder.WriteString(" ")
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(fmt.Sprintf("%*s └%s\n", labelWidth, "", strings.Repeat("─", columnCount)))
+
+	builder.WriteString(fmt.Sprintf("%*s  ", labelWidth, ""))
+	for _, column := range columns {
+		if column.missing {
+			builder.WriteString(pterm.FgRed.Sprint("×"))
+		} else {
+			builder.WriteString(" ")
+		}
+	}
+	builder.WriteString("\n")
+
+	firstTime := points[0].Time.Format(time.RFC3339)
+	lastTime := points[len(points)-1].Time.Format(time.RFC3339)
+	padding := columnCount - len(firstTime) - len(lastTime)
+	if padding < 1 {
+		padding = 1
+	}
+	builder.WriteString(fmt.Sprintf("%*s  %s%s%s\n", labelWidth, "", firstTime, strings.Repeat(" ", padding), lastTime))
+
+	builder.WriteString(fmt.Sprintf("\n%s value  %s missing  %s stale  %s counter reset\n",
+		pterm.FgCyan.Sprint("●"), pterm.FgRed.Sprint("×"), pterm.FgYellow.Sprint("┊"), pterm.FgMagenta.Sprint("◆")))
+
+	return builder.String()
+}
+
+// tableData returns the rows of the table of values, including the header.
+func tableData(points []chart.Point) pterm.TableData {
+	data := pterm.TableData{{"#", "Time", "Value", "Status"}}
+
+	for i, point := range points {
+		value := strconv.FormatFloat(point.Value, 'g', -1, 64)
+		status := "ok"
+
+		switch {
+		case point.Missing:
+			value = "-"
+			status = pterm.FgRed.Sprint("missing")
+		case point.Stale:
+			value = "-"
+			status = pterm.FgYellow.Sprint("stale")
+		case point.CounterReset:
+			status = pterm.FgMagenta.Sprint("counter reset")
+		}
+
+		data = append(data, []string{strconv.Itoa(i), point.Time.Format(time.RFC3339), value, status})
+	}
+
+	return data
+}
@@ -0,0 +1,10 @@
+package cli
+
+// RenderPlot exports the private function renderPlot().
+var RenderPlot = renderPlot
+
+// TableData exports the private function tableData().
+var TableData = tableData
+
+// SeriesTitle exports the private function seriesTitle().
+var SeriesTitle = seriesTitle
@@ -0,0 +1,203 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/chart"
+	"github.com/gustavooferreira/prometheus-metrics-generator/cmd/promgen/cli"
+)
+
+func TestRenderPlot(t *testing.T) {
+	pterm.DisableColor()
+
+	startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		points         []chart.Point
+		width          int
+		height         int
+		expectedOutput string
+	}{
+		"should report there is nothing to plot given no points": {
+			points:         nil,
+			width:          0,
+			height:         3,
+			expectedOutput: "No scrapes to plot.\n",
+		},
+		"should mark missing, stale and counter reset points": {
+			points: []chart.Point{
+				{Time: startTime, Value: 1},
+				{Time: startTime.Add(15 * time.Second), Missing: true},
+				{Time: startTime.Add(30 * time.Second), Value: 3},
+				{Time: startTime.Add(45 * time.Second), Value: 0, CounterReset: true},
+				{Time: startTime.Add(60 * time.Second), Stale: true},
+			},
+			width:  0,
+			height: 3,
+			expectedOutput: "  3 ┤  ● ┊\n" +
+				"1.5 ┤●   ┊\n" +
+				"  0 ┤   ◆┊\n" +
+				"    └─────\n" +
+				"      ×   \n" +
+				"     2023-01-01T10:30:00Z 2023-01-01T10:31:00Z\n" +
+				"\n" +
+				"● value  × missing  ┊ stale  ◆ counter reset\n",
+		},
+		"should aggregate the points given more points than columns": {
+			points: []chart.Point{
+				{Time: startTime, Value: 10},
+				{Time: startTime.Add(15 * time.Second), Value: 20},
+				{Time: startTime.Add(30 * time.Second), Value: 30},
+				{Time: startTime.Add(45 * time.Second), Value: 40},
+			},
+			width:  2,
+			height: 3,
+			expectedOutput: "40 ┤ ●\n" +
+				"30 ┤  \n" +
+				"20 ┤● \n" +
+				"   └──\n" +
+				"      \n" +
+				"    2023-01-01T10:30:00Z 2023-01-01T10:30:45Z\n" +
+				"\n" +
+				"● value  × missing  ┊ stale  ◆ counter reset\n",
+		},
+		"should centre a flat series": {
+			points: []chart.Point{
+				{Time: startTime, Value: 5},
+				{Time: startTime.Add(15 * time.Second), Value: 5},
+			},
+			width:  0,
+			height: 3,
+			expectedOutput: "6 ┤  \n" +
+				"5 ┤●●\n" +
+				"4 ┤  \n" +
+				"  └──\n" +
+				"     \n" +
+				"   2023-01-01T10:30:00Z 2023-01-01T10:30:15Z\n" +
+				"\n" +
+				"● value  × missing  ┊ stale  ◆ counter reset\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			output := cli.RenderPlot(testCase.points, testCase.width, testCase.height)
+			assert.Equal(t, testCase.expectedOutput, output)
+		})
+	}
+}
+
+func TestTableData(t *testing.T) {
+	pterm.DisableColor()
+
+	startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		point       chart.Point
+		expectedRow []string
+	}{
+		"should show the value of a sample": {
+			point:       chart.Point{Time: startTime, Value: 1.5},
+			expectedRow: []string{"0", "2023-01-01T10:30:00Z", "1.5", "ok"},
+		},
+		"should hide the value of a missing sample": {
+			point:       chart.Point{Time: startTime, Missing: true},
+			expectedRow: []string{"0", "2023-01-01T10:30:00Z", "-", "missing"},
+		},
+		"should hide the value of a stale sample": {
+			point:       chart.Point{Time: startTime, Stale: true},
+			expectedRow: []string{"0", "2023-01-01T10:30:00Z", "-", "stale"},
+		},
+		"should flag counter resets": {
+			point:       chart.Point{Time: startTime, Value: 0, CounterReset: true},
+			expectedRow: []string{"0", "2023-01-01T10:30:00Z", "0", "counter reset"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := cli.TableData([]chart.Point{testCase.point})
+
+			require.Equal(t, 2, len(data))
+			assert.Equal(t, []string{"#", "Time", "Value", "Status"}, data[0])
+			assert.Equal(t, testCase.expectedRow, data[1])
+		})
+	}
+}
+
+func TestSeriesTitle(t *testing.T) {
+	testCases := map[string]struct {
+		path          string
+		notation      string
+		expectedTitle string
+	}{
+		"should use the notation given a notation": {
+			notation:      "0+10x20 _x3",
+			expectedTitle: "0+10x20 _x3",
+		},
+		"should use the file name given a series definition file": {
+			path:          "/some/dir/scenario.yaml",
+			expectedTitle: "scenario.yaml",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedTitle, cli.SeriesTitle(testCase.path, testCase.notation))
+		})
+	}
+}
+
+func TestPreviewCmd(t *testing.T) {
+	t.Run("should preview a series written in the promtool notation", func(t *testing.T) {
+		var output bytes.Buffer
+
+		rootCmd := cli.NewRootCmd()
+		rootCmd.SetOut(&output)
+		rootCmd.SetArgs([]string{
+			"preview",
+			"--notation", "1 2 _ 4",
+			"--start", "2023-01-01T10:30:00Z",
+			"--interval", "30s",
+			"--width", "40",
+			"--no-color",
+		})
+
+		err := rootCmd.Execute()
+		require.NoError(t, err)
+
+		// The whole series is scraped, plus one extra scrape showing the series going stale.
+		assert.Contains(t, output.String(), "2023-01-01T10:30:00Z")
+		assert.Contains(t, output.String(), "2023-01-01T10:32:00Z")
+		assert.NotContains(t, output.String(), "2023-01-01T10:32:30Z")
+		assert.Contains(t, output.String(), "missing")
+		assert.Contains(t, output.String(), "stale")
+	})
+	t.Run("should describe the series definition along with the options of every data generator", func(t *testing.T) {
+		seriesPath := filepath.Join(t.TempDir(), "scenario.json")
+		err := os.WriteFile(seriesPath, []byte(`{
+			"type": "data_generator_type-sine_segment",
+			"options": {"amplitude": 10, "offset": 20, "period": 8, "phase": 2, "iteration_count_limit": 16}
+		}`), 0o600)
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+
+		rootCmd := cli.NewRootCmd()
+		rootCmd.SetOut(&output)
+		rootCmd.SetArgs([]string{"preview", seriesPath, "--no-table", "--no-color"})
+
+		err = rootCmd.Execute()
+		require.NoError(t, err)
+
+		assert.Contains(t, output.String(), "Sine Segment {Amplitude:10 Offset:20 Period:8 Phase:2 IterationCountLimit:16}\n")
+	})
+}
@@ -25,6 +25,7 @@ This tool currently supports getting access tokens using the passwordless oauth
 
 	// Init and register sub commands
 	_ = newVersionCmd(rootCmd)
+	_ = newPreviewCmd(rootCmd)
 
 	return rootCmd
 }
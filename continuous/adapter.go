@@ -0,0 +1,96 @@
+package continuous
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// DiscreteAdapterOptions contains the options for the DiscreteAdapter.
+type DiscreteAdapterOptions struct {
+	// StartTime anchors the continuous function, i.e., it's the time at which the function starts.
+	// If zero, the function is anchored at the time of the first scrape evaluated by each iterator.
+	StartTime time.Time `json:"start_time" yaml:"start_time"`
+}
+
+// Check at compile time whether DiscreteAdapter implements DataGenerator interface.
+var _ DataGenerator = (*DiscreteAdapter)(nil)
+
+// DiscreteAdapter adapts a continuous DataIterator into a metrics.DataGenerator, whose iterators comply with the
+// metrics.DataIterator interface.
+// This allows continuous functions to be scraped by the metrics.Scraper, to be used as discrete.MetricTimeSeries and
+// therefore to be exposed by the promadapter.Collector or sent through the remote writer. The samples depend on the
+// time of the scrapes rather than on the iteration index.
+//
+// The function is anchored at the StartTime. If the StartTime is not set, every iterator anchors the function at the
+// time of the first scrape it evaluates, which means the function restarts on every loop when used with the
+// metrics.EndStrategyTypeLoop end strategy. Note that looping over a function anchored at a StartTime that has already
+// gone by never produces any samples, as the function is always exhausted.
+//
+// A DiscreteAdapter without a StartTime is the continuous DataGenerator. It can be mixed with discrete data generators,
+// e.g., in a discrete.JoinDataGenerator, where the function starts at the first scrape after the previous data
+// generator is exhausted and lasts for the duration of the function.
+// The zero value is not useful. Use NewDiscreteAdapter function.
+type DiscreteAdapter struct {
+	dataIterator DataIterator
+	options      DiscreteAdapterOptions
+}
+
+// NewDiscreteAdapter returns a new instance of DiscreteAdapter.
+func NewDiscreteAdapter(dataIterator DataIterator, options DiscreteAdapterOptions) (*DiscreteAdapter, error) {
+	if dataIterator == nil {
+		return &DiscreteAdapter{}, fmt.Errorf("error validating discrete adapter configuration: data iterator cannot be nil")
+	}
+
+	return &DiscreteAdapter{
+		dataIterator: dataIterator,
+		options:      options,
+	}, nil
+}
+
+// Iterator returns a metrics.DataIterator evaluating the continuous function.
+func (da *DiscreteAdapter) Iterator() metrics.DataIterator {
+	return &DiscreteAdapterDataIterator{
+		discreteAdapter:   *da,
+		functionStartTime: da.options.StartTime,
+	}
+}
+
+// Describe describes the DataGenerator.
+// Without a StartTime, it's the description of the continuous function it evaluates. Otherwise, the description holds
+// the StartTime, with the continuous function as its only child, so the function is anchored at the same time when
+// built back.
+func (da *DiscreteAdapter) Describe() metrics.DataSpec {
+	if da.options.StartTime.IsZero() {
+		return da.dataIterator.Describe()
+	}
+
+	return metrics.NewContainerDataSpec(DataGeneratorTypeDiscreteAdapter, "Discrete Adapter", da.options, []metrics.DataSpec{da.dataIterator.Describe()})
+}
+
+// Duration reports the duration of the continuous function.
+func (da *DiscreteAdapter) Duration() time.Duration {
+	return da.dataIterator.Duration()
+}
+
+// Check at compile time whether DiscreteAdapterDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*DiscreteAdapterDataIterator)(nil)
+
+type DiscreteAdapterDataIterator struct {
+	// read-only access
+	discreteAdapter DiscreteAdapter
+
+	// functionStartTime is the time the continuous function is anchored at.
+	functionStartTime time.Time
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// It turns the metrics.ScrapeInfo into a ScrapeInfo and evaluates the continuous function.
+func (di *DiscreteAdapterDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	if di.functionStartTime.IsZero() {
+		di.functionStartTime = scrapeInfo.IterationTime
+	}
+
+	return di.discreteAdapter.dataIterator.Evaluate(newScrapeInfo(scrapeInfo, di.functionStartTime))
+}
@@ -0,0 +1,122 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+func TestDiscreteAdapter(t *testing.T) {
+	scraperStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	scraper, err := metrics.NewScraper(
+		metrics.ScraperConfig{
+			StartTime:      scraperStartTime,
+			ScrapeInterval: 15 * time.Second,
+		},
+		metrics.WithScraperIterationCountLimit(10),
+	)
+	require.NoError(t, err)
+
+	// The linear segment goes from 0 to 60 in one minute, i.e., its value is the number of seconds elapsed.
+	dataIterator, err := continuous.NewLinearSegmentDataIterator(continuous.LinearSegmentDataIteratorOptions{
+		AmplitudeStart: 0,
+		AmplitudeEnd:   60,
+		DurationLength: time.Minute,
+	})
+	require.NoError(t, err)
+
+	t.Run("should fail given a nil data iterator", func(t *testing.T) {
+		_, err := continuous.NewDiscreteAdapter(nil, continuous.DiscreteAdapterOptions{})
+		require.Error(t, err)
+		assert.Equal(t, "error validating discrete adapter configuration: data iterator cannot be nil", err.Error())
+	})
+
+	t.Run("should evaluate the function given the time of the scrapes", func(t *testing.T) {
+		// The function starts 20 seconds after the first scrape.
+		adapter, err := continuous.NewDiscreteAdapter(dataIterator, continuous.DiscreteAdapterOptions{
+			StartTime: scraperStartTime.Add(20 * time.Second),
+		})
+		require.NoError(t, err)
+
+		var results []metrics.ScrapeResult
+		err = scraper.ScrapeDataIterator(adapter.Iterator(), func(_ metrics.ScrapeInfo, scrapeResult metrics.ScrapeResult) error {
+			results = append(results, scrapeResult)
+			return nil
+		})
+		require.NoError(t, err)
+
+		expected := []metrics.ScrapeResult{
+			{Missing: true}, {Missing: true}, {Value: 10}, {Value: 25}, {Value: 40}, {Value: 55},
+		}
+		require.Equal(t, len(expected), len(results))
+		for i := range expected {
+			assert.Equal(t, expected[i].Missing, results[i].Missing)
+			assert.InDelta(t, expected[i].Value, results[i].Value, 0.001)
+		}
+	})
+
+	t.Run("should anchor the function at the first scrape and restart it on every loop", func(t *testing.T) {
+		adapter, err := continuous.NewDiscreteAdapter(dataIterator, continuous.DiscreteAdapterOptions{})
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeGauge, nil)
+		err = metric.AddTimeSeries(discrete.NewMetricTimeSeries(nil, adapter, metrics.NewEndStrategyLoop()))
+		require.NoError(t, err)
+
+		var values []float64
+		iter := scraper.Iterator()
+		for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+			metricResults := metric.Evaluate(scrapeInfo)
+			require.Equal(t, 1, len(metricResults))
+			values = append(values, metricResults[0].Value)
+		}
+
+		assert.InDeltaSlice(t, []float64{0, 15, 30, 45, 60, 0, 15, 30, 45, 60}, values, 0.001)
+	})
+
+	t.Run("should mix discrete and continuous data generators in a join", func(t *testing.T) {
+		adapter, err := continuous.NewDiscreteAdapter(dataIterator, continuous.DiscreteAdapterOptions{})
+		require.NoError(t, err)
+
+		before, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      100,
+			AmplitudeEnd:        80,
+			IterationCountLimit: 3,
+		})
+		require.NoError(t, err)
+
+		after, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      5,
+			AmplitudeEnd:        5,
+			IterationCountLimit: 2,
+		})
+		require.NoError(t, err)
+
+		join := discrete.NewJoinDataGenerator([]discrete.DataGenerator{before, adapter, after})
+
+		var values []float64
+		err = scraper.ScrapeDataIterator(join.Iterator(), func(_ metrics.ScrapeInfo, scrapeResult metrics.ScrapeResult) error {
+			values = append(values, scrapeResult.Value)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// The continuous function starts at the first scrape after the discrete segment is exhausted.
+		assert.InDeltaSlice(t, []float64{100, 90, 80, 0, 15, 30, 45, 60, 5, 5}, values, 0.001)
+
+		joinDataSpec, ok := join.Describe().(discrete.JoinDataSpec)
+		require.True(t, ok)
+		require.Equal(t, 3, len(joinDataSpec.Children))
+		assert.Equal(t, discrete.DataGeneratorTypeLinearSegment, joinDataSpec.Children[0].(discrete.DataNodeDataSpec).DataGeneratorType())
+		assert.Equal(t, continuous.DataGeneratorTypeLinearSegment, joinDataSpec.Children[1].(discrete.DataNodeDataSpec).DataGeneratorType())
+		assert.Equal(t, discrete.DataGeneratorTypeLinearSegment, joinDataSpec.Children[2].(discrete.DataNodeDataSpec).DataGeneratorType())
+	})
+}
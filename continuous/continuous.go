@@ -6,19 +6,43 @@ import (
 	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
 )
 
-// startTime represents the time at which the function started being evaluated.
-// A given iterator might not return any sample if too long has passed since the startTime.
+// DataGenerator generates data according to a continuous function.
+// It complies with the metrics.DataGenerator interface, just like discrete data generators do. This means continuous
+// data generators can be mixed with discrete ones in the same tree, e.g., a discrete.JoinDataGenerator can go through a
+// number of discrete iterations followed by a continuous function lasting a given duration.
+// The continuous DataGenerator is a DiscreteAdapter without a StartTime, hence each iterator anchors the function at
+// the time of the first scrape it evaluates. A DiscreteAdapter anchored at a StartTime complies with this interface as
+// well, but it cannot be used as part of a continuous Join or Loop, as the anchor is its own.
 type DataGenerator interface {
-	Iterator(startTime time.Time) DataIterator
-	// Describe() DataSpec
+	metrics.DataGenerator
+	Duration() time.Duration
 }
 
 // DataIterator defines the interface a continuous data iterator needs to comply with.
+// Continuous data iterators are stateless, i.e., the sample only depends on the scrape, hence they can be evaluated
+// any number of times.
 type DataIterator interface {
 	Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult
 	Duration() time.Duration
+	Describe() metrics.DataSpec
 }
 
+// The DataGeneratorType of the continuous data iterators, used in their metrics.DataSpec.
+// The continuous Join and Loop are described by a metrics.ContainerDataSpec, since, unlike their discrete
+// counterparts, they're made up of continuous functions and a loop can repeat its function forever. So is a
+// DiscreteAdapter anchored at a StartTime, whose only child is the continuous function it evaluates.
+const (
+	DataGeneratorTypeLinearSegment        metrics.DataGeneratorType = "data_generator_type-continuous_linear_segment"
+	DataGeneratorTypeSineSegment          metrics.DataGeneratorType = "data_generator_type-continuous_sine_segment"
+	DataGeneratorTypeExponentialSegment   metrics.DataGeneratorType = "data_generator_type-continuous_exponential_segment"
+	DataGeneratorTypeLogisticSegment      metrics.DataGeneratorType = "data_generator_type-continuous_logistic_segment"
+	DataGeneratorTypeStepSegment          metrics.DataGeneratorType = "data_generator_type-continuous_step_segment"
+	DataGeneratorTypeInterpolationSegment metrics.DataGeneratorType = "data_generator_type-continuous_interpolation_segment"
+	DataGeneratorTypeJoin                 metrics.DataGeneratorType = "data_generator_type-continuous_join"
+	DataGeneratorTypeLoop                 metrics.DataGeneratorType = "data_generator_type-continuous_loop"
+	DataGeneratorTypeDiscreteAdapter      metrics.DataGeneratorType = "data_generator_type-continuous_discrete_adapter"
+)
+
 // ScrapeInfo contains information about the scrape for a continuous data iterator.
 type ScrapeInfo struct {
 	// FirstIterationTime represents the time at which the very first iteration (scrape) happened.
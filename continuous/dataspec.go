@@ -0,0 +1,238 @@
+package continuous
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// RegisterBuilders registers the continuous data iterators in the metrics.BuilderRegistry provided, e.g., a
+// discrete.Registry, so trees of data generators made up of both discrete and continuous data generators can be built
+// back from their DataSpec, and therefore unmarshalled.
+// The continuous data iterators are built as a DiscreteAdapter without a StartTime, i.e., the continuous DataGenerator,
+// unless they're wrapped in the DataSpec of a DiscreteAdapter anchored at a StartTime.
+// The children of the continuous Join and Loop must be continuous DataGenerators as well.
+func RegisterBuilders(registry metrics.BuilderRegistry) {
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeLinearSegment, adaptConstructor(NewLinearSegmentDataIterator))
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeSineSegment, adaptConstructor(NewSineSegmentDataIterator))
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeExponentialSegment, adaptConstructor(NewExponentialSegmentDataIterator))
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeLogisticSegment, adaptConstructor(NewLogisticSegmentDataIterator))
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeStepSegment, adaptConstructor(NewStepSegmentDataIterator))
+	metrics.RegisterDataNodeBuilder(registry, DataGeneratorTypeInterpolationSegment, adaptConstructor(NewInterpolationSegmentDataIterator))
+
+	metrics.RegisterContainerBuilder(registry, DataGeneratorTypeJoin, func(_ struct{}, children []metrics.DataGenerator) (metrics.DataGenerator, error) {
+		dataIterators, err := dataIteratorsFromDataGenerators(children)
+		if err != nil {
+			return nil, err
+		}
+
+		joinDataIterator, err := NewJoinDataIterator(dataIterators)
+		if err != nil {
+			return nil, err
+		}
+		return newDataGenerator(joinDataIterator)
+	})
+	metrics.RegisterContainerBuilder(registry, DataGeneratorTypeLoop, func(options LoopDataIteratorOptions, children []metrics.DataGenerator) (metrics.DataGenerator, error) {
+		if len(children) != 1 {
+			return nil, fmt.Errorf("loop must have exactly one data generator to loop over, got %d", len(children))
+		}
+
+		dataIterators, err := dataIteratorsFromDataGenerators(children)
+		if err != nil {
+			return nil, err
+		}
+
+		loopDataIterator, err := NewLoopDataIterator(dataIterators[0], options)
+		if err != nil {
+			return nil, err
+		}
+		return newDataGenerator(loopDataIterator)
+	})
+	metrics.RegisterContainerBuilder(registry, DataGeneratorTypeDiscreteAdapter, func(options DiscreteAdapterOptions, children []metrics.DataGenerator) (metrics.DataGenerator, error) {
+		if len(children) != 1 {
+			return nil, fmt.Errorf("discrete adapter must have exactly one continuous function, got %d", len(children))
+		}
+
+		dataIterators, err := dataIteratorsFromDataGenerators(children)
+		if err != nil {
+			return nil, err
+		}
+
+		discreteAdapter, err := NewDiscreteAdapter(dataIterators[0], options)
+		if err != nil {
+			return nil, err
+		}
+		return discreteAdapter, nil
+	})
+}
+
+// adaptConstructor adapts the constructors returning concrete data iterators to the signature expected by
+// metrics.RegisterDataNodeBuilder.
+func adaptConstructor[T any, D DataIterator](constructor func(options T) (D, error)) func(options T) (metrics.DataGenerator, error) {
+	return func(options T) (metrics.DataGenerator, error) {
+		dataIterator, err := constructor(options)
+		if err != nil {
+			return nil, err
+		}
+		return newDataGenerator(dataIterator)
+	}
+}
+
+// newDataGenerator returns the continuous DataGenerator evaluating the DataIterator provided, i.e., a DiscreteAdapter
+// without a StartTime.
+func newDataGenerator(dataIterator DataIterator) (metrics.DataGenerator, error) {
+	discreteAdapter, err := NewDiscreteAdapter(dataIterator, DiscreteAdapterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return discreteAdapter, nil
+}
+
+// dataIteratorsFromDataGenerators unwraps the continuous DataIterators from the DataGenerators built by the
+// metrics.BuilderRegistry.
+func dataIteratorsFromDataGenerators(dataGenerators []metrics.DataGenerator) ([]DataIterator, error) {
+	dataIterators := make([]DataIterator, 0, len(dataGenerators))
+
+	for i, dataGenerator := range dataGenerators {
+		discreteAdapter, ok := dataGenerator.(*DiscreteAdapter)
+		if !ok || !discreteAdapter.options.StartTime.IsZero() {
+			return nil, fmt.Errorf("data generator %d must be a continuous data generator, got %T", i, dataGenerator)
+		}
+		dataIterators = append(dataIterators, discreteAdapter.dataIterator)
+	}
+
+	return dataIterators, nil
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (o LinearSegmentDataIteratorOptions) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(o)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (o *LinearSegmentDataIteratorOptions) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, o)
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (o SineSegmentDataIteratorOptions) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(o)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (o *SineSegmentDataIteratorOptions) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, o)
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (o ExponentialSegmentDataIteratorOptions) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(o)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (o *ExponentialSegmentDataIteratorOptions) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, o)
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (o LogisticSegmentDataIteratorOptions) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(o)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (o *LogisticSegmentDataIteratorOptions) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, o)
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (o StepSegmentDataIteratorOptions) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(o)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (o *StepSegmentDataIteratorOptions) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, o)
+}
+
+// MarshalJSON encodes the durations as strings (e.g., "1m30s") rather than in nanoseconds, just like YAML does.
+func (cp ControlPoint) MarshalJSON() ([]byte, error) {
+	return marshalJSONDurations(cp)
+}
+
+// UnmarshalJSON decodes the durations encoded as strings by MarshalJSON.
+func (cp *ControlPoint) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONDurations(data, cp)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// jsonDurationsType returns a struct type with the same fields as the struct type provided, except the time.Duration
+// fields, which are turned into strings.
+// The struct type returned has no methods, hence it's encoded by the json package as a plain struct.
+func jsonDurationsType(structType reflect.Type) reflect.Type {
+	fields := make([]reflect.StructField, structType.NumField())
+	for i := range fields {
+		fields[i] = structType.Field(i)
+		if fields[i].Type == durationType {
+			fields[i].Type = reflect.TypeOf("")
+		}
+	}
+
+	return reflect.StructOf(fields)
+}
+
+// marshalJSONDurations returns the JSON encoding of the struct provided, with the time.Duration fields encoded as
+// strings.
+func marshalJSONDurations(v any) ([]byte, error) {
+	value := reflect.ValueOf(v)
+	plain := reflect.New(jsonDurationsType(value.Type())).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).Type() == durationType {
+			plain.Field(i).SetString(time.Duration(value.Field(i).Int()).String())
+			continue
+		}
+		plain.Field(i).Set(value.Field(i))
+	}
+
+	return json.Marshal(plain.Interface())
+}
+
+// unmarshalJSONDurations decodes the JSON encoding of a struct, with the time.Duration fields encoded as strings, into
+// the struct pointed to by v. Unknown fields are rejected.
+func unmarshalJSONDurations(data []byte, v any) error {
+	value := reflect.ValueOf(v).Elem()
+	plain := reflect.New(jsonDurationsType(value.Type()))
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(plain.Interface()); err != nil {
+		return err
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).Type() != durationType {
+			value.Field(i).Set(plain.Elem().Field(i))
+			continue
+		}
+
+		// Durations left out are treated as the zero value.
+		durationString := plain.Elem().Field(i).String()
+		if durationString == "" {
+			value.Field(i).SetInt(0)
+			continue
+		}
+
+		duration, err := time.ParseDuration(durationString)
+		if err != nil {
+			return fmt.Errorf("error decoding field %q: %w", value.Type().Field(i).Name, err)
+		}
+		value.Field(i).SetInt(int64(duration))
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestDataSpec(t *testing.T) {
+	// helperMixedDataGenerator returns a discrete join of a discrete linear segment, a continuous join of a linear
+	// segment and a step segment repeated twice, and a sine segment repeated forever.
+	helperMixedDataGenerator := func(t *testing.T) discrete.DataGenerator {
+		t.Helper()
+
+		discreteLinearSegment, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      100,
+			AmplitudeEnd:        80,
+			IterationCountLimit: 3,
+		})
+		require.NoError(t, err)
+
+		linearSegment := helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+			AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second, IntervalRightBoundOpen: true,
+		})
+
+		stepSegment, err := continuous.NewStepSegmentDataIterator(continuous.StepSegmentDataIteratorOptions{
+			AmplitudeStart: 10, AmplitudeEnd: 20, StepTime: 15 * time.Second, DurationLength: 30 * time.Second,
+			IntervalRightBoundOpen: true,
+		})
+		require.NoError(t, err)
+
+		stepLoop, err := continuous.NewLoopDataIterator(stepSegment, continuous.LoopDataIteratorOptions{Count: 2})
+		require.NoError(t, err)
+
+		join, err := continuous.NewJoinDataIterator([]continuous.DataIterator{linearSegment, stepLoop})
+		require.NoError(t, err)
+
+		joinAdapter, err := continuous.NewDiscreteAdapter(join, continuous.DiscreteAdapterOptions{})
+		require.NoError(t, err)
+
+		sineSegment, err := continuous.NewSineSegmentDataIterator(continuous.SineSegmentDataIteratorOptions{
+			Amplitude: 5, Offset: 50, Period: time.Minute, DurationLength: time.Minute, IntervalRightBoundOpen: true,
+		})
+		require.NoError(t, err)
+
+		sineLoop, err := continuous.NewLoopDataIterator(sineSegment, continuous.LoopDataIteratorOptions{})
+		require.NoError(t, err)
+
+		sineLoopAdapter, err := continuous.NewDiscreteAdapter(sineLoop, continuous.DiscreteAdapterOptions{})
+		require.NoError(t, err)
+
+		return discrete.NewJoinDataGenerator([]discrete.DataGenerator{discreteLinearSegment, joinAdapter, sineLoopAdapter})
+	}
+
+	// helperScrapeDataGenerator scrapes the data generator 20 times, every 15 seconds.
+	helperScrapeDataGenerator := func(t *testing.T, dataGenerator discrete.DataGenerator) []metrics.ScrapeResult {
+		t.Helper()
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(20),
+		)
+		require.NoError(t, err)
+
+		var results []metrics.ScrapeResult
+		err = scraper.ScrapeDataIterator(dataGenerator.Iterator(), func(_ metrics.ScrapeInfo, scrapeResult metrics.ScrapeResult) error {
+			results = append(results, scrapeResult)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return results
+	}
+
+	registry := discrete.NewRegistry()
+	continuous.RegisterBuilders(registry)
+
+	testCases := map[string]struct {
+		marshal   func(dataSpec discrete.DataSpec) ([]byte, error)
+		unmarshal func(data []byte) (discrete.DataSpec, error)
+	}{
+		"json": {marshal: discrete.MarshalDataSpecJSON, unmarshal: registry.UnmarshalDataSpecJSON},
+		"yaml": {marshal: discrete.MarshalDataSpecYAML, unmarshal: registry.UnmarshalDataSpecYAML},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run("should round trip a tree mixing discrete and continuous data generators in "+name, func(t *testing.T) {
+			dataGenerator := helperMixedDataGenerator(t)
+
+			data, err := testCase.marshal(dataGenerator.Describe())
+			require.NoError(t, err)
+
+			dataSpec, err := testCase.unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, discrete.DescribeWithOptions(dataGenerator.Describe()), discrete.DescribeWithOptions(dataSpec))
+
+			rebuiltDataGenerator, err := registry.Build(dataSpec)
+			require.NoError(t, err)
+
+			expected := helperScrapeDataGenerator(t, dataGenerator)
+			assert.Equal(t, expected, helperScrapeDataGenerator(t, rebuiltDataGenerator))
+		})
+
+		t.Run("should round trip a discrete adapter anchored at a start time in "+name, func(t *testing.T) {
+			linearSegment := helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 0, AmplitudeEnd: 60, DurationLength: 3 * time.Minute,
+			})
+
+			dataGenerator, err := continuous.NewDiscreteAdapter(linearSegment, continuous.DiscreteAdapterOptions{
+				StartTime: time.Date(2023, 1, 1, 10, 29, 0, 0, time.UTC),
+			})
+			require.NoError(t, err)
+
+			data, err := testCase.marshal(dataGenerator.Describe())
+			require.NoError(t, err)
+
+			dataSpec, err := testCase.unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, discrete.DescribeWithOptions(dataGenerator.Describe()), discrete.DescribeWithOptions(dataSpec))
+
+			rebuiltDataGenerator, err := registry.Build(dataSpec)
+			require.NoError(t, err)
+
+			expected := helperScrapeDataGenerator(t, dataGenerator)
+			assert.InDelta(t, 20, expected[0].Value, 0.001)
+			assert.Equal(t, expected, helperScrapeDataGenerator(t, rebuiltDataGenerator))
+		})
+	}
+
+	t.Run("should describe the mixed tree", func(t *testing.T) {
+		expected := "Join\n" +
+			"  Linear Segment\n" +
+			"  Continuous Join\n" +
+			"    Continuous Linear Segment\n" +
+			"    Continuous Loop [2]\n" +
+			"      Continuous Step Segment\n" +
+			"  Continuous Loop [forever]\n" +
+			"    Continuous Sine Segment"
+		assert.Equal(t, expected, discrete.Describe(helperMixedDataGenerator(t).Describe()))
+	})
+
+	t.Run("should encode the durations as strings", func(t *testing.T) {
+		dataSpec := helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+			AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 90 * time.Second,
+		}).Describe()
+
+		data, err := discrete.MarshalDataSpecJSON(dataSpec)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"type": "data_generator_type-continuous_linear_segment",
+			"options": {
+				"amplitude_start": 0,
+				"amplitude_end": 30,
+				"duration_length": "1m30s",
+				"interval_left_bound_open": false,
+				"interval_right_bound_open": false
+			}
+		}`, string(data))
+
+		data, err = discrete.MarshalDataSpecYAML(dataSpec)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "duration_length: 1m30s")
+	})
+
+	t.Run("should fail to build a continuous join given a discrete child", func(t *testing.T) {
+		discreteLinearSegment, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      1,
+			AmplitudeEnd:        1,
+			IterationCountLimit: 3,
+		})
+		require.NoError(t, err)
+
+		dataSpec := discrete.NewContainerDataSpec(continuous.DataGeneratorTypeJoin, "Continuous Join", nil,
+			[]discrete.DataSpec{discreteLinearSegment.Describe()})
+
+		_, err = registry.Build(dataSpec)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data generator 0 must be a continuous data generator")
+	})
+
+	t.Run("should fail to unmarshal continuous data generators given a registry without them", func(t *testing.T) {
+		data, err := discrete.MarshalDataSpecJSON(helperMixedDataGenerator(t).Describe())
+		require.NoError(t, err)
+
+		_, err = discrete.NewRegistry().UnmarshalDataSpecJSON(data)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not registered")
+	})
+}
@@ -0,0 +1,88 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// ExponentialSegmentDataIteratorOptions contains the options for the ExponentialSegmentDataIterator.
+type ExponentialSegmentDataIteratorOptions struct {
+	// AmplitudeStart represents the initial value for the segment.
+	AmplitudeStart float64 `json:"amplitude_start" yaml:"amplitude_start"`
+
+	// AmplitudeEnd represents the end value for the segment.
+	AmplitudeEnd float64 `json:"amplitude_end" yaml:"amplitude_end"`
+
+	// DurationLength sets the max duration between the first and the last value.
+	DurationLength time.Duration `json:"duration_length" yaml:"duration_length"`
+
+	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
+	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
+
+	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
+}
+
+// Check at compile time whether ExponentialSegmentDataIterator implements DataIterator interface.
+var _ DataIterator = (*ExponentialSegmentDataIterator)(nil)
+
+// ExponentialSegmentDataIterator returns a DataIterator representing an exponential growth or decay, going from
+// AmplitudeStart to AmplitudeEnd over the DurationLength.
+// The value grows (or decays) by the same factor over equal periods of time, i.e., the value is given by:
+// AmplitudeStart * (AmplitudeEnd / AmplitudeStart) ^ (elapsed time / DurationLength).
+// Both amplitudes must be non-zero and have the same sign.
+// The zero value is not useful. Use NewExponentialSegmentDataIterator function.
+type ExponentialSegmentDataIterator struct {
+	options ExponentialSegmentDataIteratorOptions
+}
+
+// NewExponentialSegmentDataIterator returns a new instance of ExponentialSegmentDataIterator.
+func NewExponentialSegmentDataIterator(options ExponentialSegmentDataIteratorOptions) (*ExponentialSegmentDataIterator, error) {
+	if options.DurationLength < 0 {
+		return &ExponentialSegmentDataIterator{}, fmt.Errorf("duration length cannot be less than zero")
+	}
+
+	if options.AmplitudeStart == 0 || options.AmplitudeEnd == 0 {
+		return &ExponentialSegmentDataIterator{}, fmt.Errorf("amplitudes cannot be zero")
+	}
+
+	if (options.AmplitudeStart > 0) != (options.AmplitudeEnd > 0) {
+		return &ExponentialSegmentDataIterator{}, fmt.Errorf("amplitudes must have the same sign")
+	}
+
+	return &ExponentialSegmentDataIterator{
+		options: options,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (es *ExponentialSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, es.options.DurationLength, es.options.IntervalLeftBoundOpen, es.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
+	}
+
+	// If the amplitudes are the same, or the segment has no duration, there is no need to do any computation
+	if es.options.AmplitudeStart == es.options.AmplitudeEnd || es.options.DurationLength == 0 {
+		return metrics.ScrapeResult{Value: es.options.AmplitudeStart}
+	}
+
+	progress := float64(elapsedTime) / float64(es.options.DurationLength)
+	value := es.options.AmplitudeStart * math.Pow(es.options.AmplitudeEnd/es.options.AmplitudeStart, progress)
+	return metrics.ScrapeResult{Value: value}
+}
+
+// Duration reports the duration of the continuous function.
+func (es *ExponentialSegmentDataIterator) Duration() time.Duration {
+	return es.options.DurationLength
+}
+
+// Describe describes the continuous function.
+func (es *ExponentialSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeExponentialSegment, "Continuous Exponential Segment", es.options)
+}
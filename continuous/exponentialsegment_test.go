@@ -0,0 +1,75 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func TestExponentialSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given amplitudes with different signs", func(t *testing.T) {
+		_, err := continuous.NewExponentialSegmentDataIterator(continuous.ExponentialSegmentDataIteratorOptions{
+			AmplitudeStart: -1,
+			AmplitudeEnd:   10,
+			DurationLength: time.Minute,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "amplitudes must have the same sign"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given an amplitude of zero", func(t *testing.T) {
+		_, err := continuous.NewExponentialSegmentDataIterator(continuous.ExponentialSegmentDataIteratorOptions{
+			AmplitudeStart: 0,
+			AmplitudeEnd:   10,
+			DurationLength: time.Minute,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "amplitudes cannot be zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should grow by the same factor on every scrape, closed interval", func(t *testing.T) {
+		esDataIterator, err := continuous.NewExponentialSegmentDataIterator(continuous.ExponentialSegmentDataIteratorOptions{
+			AmplitudeStart: 1,
+			AmplitudeEnd:   16,
+			DurationLength: time.Minute,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, esDataIterator, functionStartTime)
+
+		expectedValues := []float64{1, 2, 4, 8, 16}
+		require.Equal(t, len(expectedValues), len(results))
+		for i, expectedValue := range expectedValues {
+			assert.InDelta(t, expectedValue, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should decay by the same factor on every scrape, left open interval", func(t *testing.T) {
+		esDataIterator, err := continuous.NewExponentialSegmentDataIterator(continuous.ExponentialSegmentDataIteratorOptions{
+			AmplitudeStart:        81,
+			AmplitudeEnd:          1,
+			DurationLength:        time.Minute,
+			IntervalLeftBoundOpen: true,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, esDataIterator, functionStartTime)
+
+		require.Equal(t, 5, len(results))
+		assert.True(t, results[0].scrapeResult.Missing)
+		expectedValues := []float64{27, 9, 3, 1}
+		for i, expectedValue := range expectedValues {
+			assert.InDelta(t, expectedValue, results[i+1].scrapeResult.Value, 0.001)
+		}
+	})
+}
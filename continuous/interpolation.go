@@ -0,0 +1,179 @@
+package continuous
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// InterpolationType represents the method used to compute the values between control points.
+type InterpolationType string
+
+const (
+	// InterpolationTypeLinear joins consecutive control points with straight lines.
+	InterpolationTypeLinear InterpolationType = "interpolation_type-linear"
+	// InterpolationTypeStep holds the value of a control point until the next control point.
+	InterpolationTypeStep InterpolationType = "interpolation_type-step"
+	// InterpolationTypeCubicSpline joins the control points with a natural cubic spline, i.e., a smooth curve that goes
+	// through every control point. Note that the curve might overshoot the values of the control points.
+	InterpolationTypeCubicSpline InterpolationType = "interpolation_type-cubic_spline"
+)
+
+// ControlPoint represents a value at a given time offset, since the start of the segment.
+type ControlPoint struct {
+	Offset time.Duration `json:"offset" yaml:"offset"`
+	Value  float64       `json:"value" yaml:"value"`
+}
+
+// InterpolationSegmentDataIteratorOptions contains the options for the InterpolationSegmentDataIterator.
+type InterpolationSegmentDataIteratorOptions struct {
+	// ControlPoints are the points the segment goes through.
+	// There must be at least two control points, the first one at offset zero, and the offsets must be strictly
+	// increasing. The offset of the last control point sets the duration of the segment.
+	ControlPoints []ControlPoint `json:"control_points" yaml:"control_points"`
+
+	// InterpolationType sets how the values between the control points are computed.
+	InterpolationType InterpolationType `json:"interpolation_type" yaml:"interpolation_type"`
+
+	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
+	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
+
+	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
+}
+
+// Check at compile time whether InterpolationSegmentDataIterator implements DataIterator interface.
+var _ DataIterator = (*InterpolationSegmentDataIterator)(nil)
+
+// InterpolationSegmentDataIterator returns a DataIterator that goes through a sparse set of control points,
+// interpolating the values in between.
+// It's useful to model shapes that are easier described by a few points than by a formula, like a traffic ramp
+// during a deployment.
+// The zero value is not useful. Use NewInterpolationSegmentDataIterator function.
+type InterpolationSegmentDataIterator struct {
+	options InterpolationSegmentDataIteratorOptions
+
+	// secondDerivatives contains the second derivatives of the cubic spline at each control point.
+	// It's only set for the cubic spline interpolation.
+	secondDerivatives []float64
+}
+
+// NewInterpolationSegmentDataIterator returns a new instance of InterpolationSegmentDataIterator.
+func NewInterpolationSegmentDataIterator(options InterpolationSegmentDataIteratorOptions) (*InterpolationSegmentDataIterator, error) {
+	if len(options.ControlPoints) < 2 {
+		return &InterpolationSegmentDataIterator{}, fmt.Errorf("there must be at least two control points")
+	}
+
+	if options.ControlPoints[0].Offset != 0 {
+		return &InterpolationSegmentDataIterator{}, fmt.Errorf("first control point must be at offset zero")
+	}
+
+	for i := 1; i < len(options.ControlPoints); i++ {
+		if options.ControlPoints[i].Offset <= options.ControlPoints[i-1].Offset {
+			return &InterpolationSegmentDataIterator{}, fmt.Errorf("control point offsets must be strictly increasing")
+		}
+	}
+
+	// Copy the control points, so later changes to the slice provided don't affect the segment.
+	controlPoints := make([]ControlPoint, len(options.ControlPoints))
+	copy(controlPoints, options.ControlPoints)
+	options.ControlPoints = controlPoints
+
+	is := &InterpolationSegmentDataIterator{
+		options: options,
+	}
+
+	switch options.InterpolationType {
+	case InterpolationTypeLinear, InterpolationTypeStep:
+	case InterpolationTypeCubicSpline:
+		is.secondDerivatives = naturalCubicSplineSecondDerivatives(controlPoints)
+	default:
+		return &InterpolationSegmentDataIterator{}, fmt.Errorf("interpolation type %q is not supported", options.InterpolationType)
+	}
+
+	return is, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (is *InterpolationSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, is.Duration(), is.options.IntervalLeftBoundOpen, is.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
+	}
+
+	points := is.options.ControlPoints
+
+	// Find the interval [points[i], points[i+1]] the elapsed time lands in.
+	i := sort.Search(len(points), func(i int) bool { return points[i].Offset > elapsedTime }) - 1
+	if i == len(points)-1 {
+		return metrics.ScrapeResult{Value: points[i].Value}
+	}
+
+	left, right := points[i], points[i+1]
+	width := float64(right.Offset - left.Offset)
+	t := float64(elapsedTime-left.Offset) / width
+
+	var value float64
+	switch is.options.InterpolationType {
+	case InterpolationTypeStep:
+		value = left.Value
+	case InterpolationTypeLinear:
+		value = left.Value + (right.Value-left.Value)*t
+	case InterpolationTypeCubicSpline:
+		// The offsets are normalized, so the spline is computed with seconds, to keep the numbers manageable.
+		h := width / float64(time.Second)
+		a := 1 - t
+		value = a*left.Value + t*right.Value +
+			((a*a*a-a)*is.secondDerivatives[i]+(t*t*t-t)*is.secondDerivatives[i+1])*h*h/6
+	}
+
+	return metrics.ScrapeResult{Value: value}
+}
+
+// Duration reports the duration of the continuous function.
+func (is *InterpolationSegmentDataIterator) Duration() time.Duration {
+	if len(is.options.ControlPoints) == 0 {
+		return 0
+	}
+
+	return is.options.ControlPoints[len(is.options.ControlPoints)-1].Offset
+}
+
+// Describe describes the continuous function.
+func (is *InterpolationSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeInterpolationSegment, "Continuous Interpolation Segment", is.options)
+}
+
+// naturalCubicSplineSecondDerivatives computes the second derivatives of the natural cubic spline going through the
+// control points, i.e., the spline whose second derivative is zero at both ends.
+// The offsets are converted to seconds. It solves the tridiagonal system with the Thomas algorithm.
+func naturalCubicSplineSecondDerivatives(points []ControlPoint) []float64 {
+	n := len(points)
+	secondDerivatives := make([]float64, n)
+	// u holds the decomposed right-hand side of the system.
+	u := make([]float64, n)
+
+	for i := 1; i < n-1; i++ {
+		hPrevious := (points[i].Offset - points[i-1].Offset).Seconds()
+		hNext := (points[i+1].Offset - points[i].Offset).Seconds()
+
+		sigma := hPrevious / (hPrevious + hNext)
+		p := sigma*secondDerivatives[i-1] + 2
+		secondDerivatives[i] = (sigma - 1) / p
+
+		slopeDifference := (points[i+1].Value-points[i].Value)/hNext - (points[i].Value-points[i-1].Value)/hPrevious
+		u[i] = (6*slopeDifference/(hPrevious+hNext) - sigma*u[i-1]) / p
+	}
+
+	// Natural spline: the second derivative at both ends is zero.
+	secondDerivatives[n-1] = 0
+	for i := n - 2; i >= 0; i-- {
+		secondDerivatives[i] = secondDerivatives[i]*secondDerivatives[i+1] + u[i]
+	}
+
+	return secondDerivatives
+}
@@ -0,0 +1,92 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func TestInterpolationSegmentDataIterator(t *testing.T) {
+	controlPoints := []continuous.ControlPoint{
+		{Offset: 0, Value: 0},
+		{Offset: 30 * time.Second, Value: 60},
+		{Offset: time.Minute, Value: 0},
+	}
+
+	t.Run("should fail given a single control point", func(t *testing.T) {
+		_, err := continuous.NewInterpolationSegmentDataIterator(continuous.InterpolationSegmentDataIteratorOptions{
+			ControlPoints:     controlPoints[:1],
+			InterpolationType: continuous.InterpolationTypeLinear,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "there must be at least two control points"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given control points out of order", func(t *testing.T) {
+		_, err := continuous.NewInterpolationSegmentDataIterator(continuous.InterpolationSegmentDataIteratorOptions{
+			ControlPoints:     []continuous.ControlPoint{{Offset: 0}, {Offset: time.Minute}, {Offset: time.Minute}},
+			InterpolationType: continuous.InterpolationTypeLinear,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "control point offsets must be strictly increasing"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given an unknown interpolation type", func(t *testing.T) {
+		_, err := continuous.NewInterpolationSegmentDataIterator(continuous.InterpolationSegmentDataIteratorOptions{
+			ControlPoints:     controlPoints,
+			InterpolationType: "interpolation_type-unknown",
+		})
+		require.Error(t, err)
+		expectedErrorMessage := `interpolation type "interpolation_type-unknown" is not supported`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should interpolate the control points", func(t *testing.T) {
+		testCases := map[string]struct {
+			interpolationType continuous.InterpolationType
+			expectedValues    []float64
+		}{
+			"linear": {
+				interpolationType: continuous.InterpolationTypeLinear,
+				expectedValues:    []float64{0, 30, 60, 30, 0},
+			},
+			"step": {
+				interpolationType: continuous.InterpolationTypeStep,
+				expectedValues:    []float64{0, 0, 60, 60, 0},
+			},
+			// The natural cubic spline through these points has a second derivative of -0.2 (per second squared) at the
+			// middle control point. Halfway between two control points, it lies 0.375*0.2*30^2/6 = 11.25 above the line.
+			"cubic spline": {
+				interpolationType: continuous.InterpolationTypeCubicSpline,
+				expectedValues:    []float64{0, 41.25, 60, 41.25, 0},
+			},
+		}
+
+		for name, testCase := range testCases {
+			t.Run(name, func(t *testing.T) {
+				isDataIterator, err := continuous.NewInterpolationSegmentDataIterator(continuous.InterpolationSegmentDataIteratorOptions{
+					ControlPoints:     controlPoints,
+					InterpolationType: testCase.interpolationType,
+				})
+				require.NoError(t, err)
+				assert.Equal(t, time.Minute, isDataIterator.Duration())
+
+				functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+				results := helperScraper(t, isDataIterator, functionStartTime)
+
+				values := make([]float64, len(results))
+				for i, result := range results {
+					values[i] = result.scrapeResult.Value
+				}
+				assert.InDeltaSlice(t, testCase.expectedValues, values, 0.001)
+			})
+		}
+	})
+}
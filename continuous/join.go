@@ -0,0 +1,81 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// Check at compile time whether JoinDataIterator implements DataIterator interface.
+var _ DataIterator = (*JoinDataIterator)(nil)
+
+// JoinDataIterator joins several continuous functions together, back to back in time, to form a bigger and more complex
+// function.
+// Each function starts when the previous one ends, according to their Duration.
+// The interval bounds of each function are honoured at the boundaries. At the time two functions meet, the value of
+// the first function is used if its right bound is closed, otherwise the value of the second function is used if its
+// left bound is closed. If both bounds are open, the scrape is missing.
+// The zero value is not useful. Use NewJoinDataIterator function.
+type JoinDataIterator struct {
+	dataIterators []DataIterator
+}
+
+// NewJoinDataIterator returns a new instance of JoinDataIterator.
+func NewJoinDataIterator(dataIterators []DataIterator) (*JoinDataIterator, error) {
+	for _, dataIterator := range dataIterators {
+		if dataIterator == nil {
+			return &JoinDataIterator{}, fmt.Errorf("data iterators cannot be nil")
+		}
+	}
+
+	return &JoinDataIterator{
+		dataIterators: dataIterators,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (j *JoinDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	functionStartTime := scrapeInfo.FunctionStartTime
+
+	for _, dataIterator := range j.dataIterators {
+		scrapeInfo.FunctionStartTime = functionStartTime
+
+		// The function is exhausted when the scrape is past its end or lands on its open right bound, in which case the
+		// next function takes over.
+		result := dataIterator.Evaluate(scrapeInfo)
+		if result.Exhausted {
+			functionStartTime = functionStartTime.Add(dataIterator.Duration())
+			continue
+		}
+
+		return result
+	}
+
+	return metrics.ScrapeResult{Exhausted: true}
+}
+
+// Duration reports the duration of the continuous function, i.e., the sum of the durations of all functions.
+// The duration saturates at the maximum duration possible, e.g., when joining a loop that repeats forever.
+func (j *JoinDataIterator) Duration() time.Duration {
+	var duration time.Duration
+	for _, dataIterator := range j.dataIterators {
+		if dataIterator.Duration() > time.Duration(math.MaxInt64)-duration {
+			return time.Duration(math.MaxInt64)
+		}
+		duration += dataIterator.Duration()
+	}
+
+	return duration
+}
+
+// Describe describes the continuous function, along with all the functions joined.
+func (j *JoinDataIterator) Describe() metrics.DataSpec {
+	dataSpecs := make([]metrics.DataSpec, 0, len(j.dataIterators))
+	for _, dataIterator := range j.dataIterators {
+		dataSpecs = append(dataSpecs, dataIterator.Describe())
+	}
+
+	return metrics.NewContainerDataSpec(DataGeneratorTypeJoin, "Continuous Join", nil, dataSpecs)
+}
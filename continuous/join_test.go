@@ -0,0 +1,90 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func helperLinearSegment(t *testing.T, options continuous.LinearSegmentDataIteratorOptions) continuous.DataIterator {
+	t.Helper()
+
+	dataIterator, err := continuous.NewLinearSegmentDataIterator(options)
+	require.NoError(t, err)
+
+	return dataIterator
+}
+
+func TestJoinDataIterator(t *testing.T) {
+	functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("should fail given a nil data iterator", func(t *testing.T) {
+		_, err := continuous.NewJoinDataIterator([]continuous.DataIterator{nil})
+		require.Error(t, err)
+		assert.Equal(t, "data iterators cannot be nil", err.Error())
+	})
+
+	t.Run("should place the functions back to back given their durations", func(t *testing.T) {
+		dataIterator, err := continuous.NewJoinDataIterator([]continuous.DataIterator{
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second}),
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 100, AmplitudeEnd: 100, DurationLength: time.Minute}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, dataIterator.Duration())
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+
+		// Both bounds are closed at 30s, so the first function wins.
+		var values []float64
+		for _, result := range results {
+			values = append(values, result.scrapeResult.Value)
+		}
+		assert.InDeltaSlice(t, []float64{0, 15, 30, 100, 100, 100, 100}, values, 0.001)
+	})
+
+	t.Run("should honour the open bounds at the boundaries", func(t *testing.T) {
+		dataIterator, err := continuous.NewJoinDataIterator([]continuous.DataIterator{
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second, IntervalRightBoundOpen: true,
+			}),
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 100, AmplitudeEnd: 100, DurationLength: 30 * time.Second,
+			}),
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 200, AmplitudeEnd: 200, DurationLength: 30 * time.Second, IntervalLeftBoundOpen: true,
+			}),
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+		require.Equal(t, 7, len(results))
+
+		// At 30s, the first function's right bound is open, so the second function takes over.
+		assert.InDelta(t, 100, results[2].scrapeResult.Value, 0.001)
+		// At 60s, the second function's right bound is closed.
+		assert.InDelta(t, 100, results[4].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 200, results[5].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 200, results[6].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should return a missing sample when both bounds are open", func(t *testing.T) {
+		dataIterator, err := continuous.NewJoinDataIterator([]continuous.DataIterator{
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second, IntervalRightBoundOpen: true,
+			}),
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 100, AmplitudeEnd: 100, DurationLength: 30 * time.Second, IntervalLeftBoundOpen: true,
+			}),
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+		require.Equal(t, 5, len(results))
+		assert.True(t, results[2].scrapeResult.Missing)
+		assert.InDelta(t, 100, results[3].scrapeResult.Value, 0.001)
+	})
+}
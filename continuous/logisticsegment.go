@@ -0,0 +1,94 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// LogisticSegmentDataIteratorOptions contains the options for the LogisticSegmentDataIterator.
+type LogisticSegmentDataIteratorOptions struct {
+	// AmplitudeStart represents the value the curve starts from, i.e., its lower (or upper) asymptote.
+	AmplitudeStart float64 `json:"amplitude_start" yaml:"amplitude_start"`
+
+	// AmplitudeEnd represents the value the curve settles at, i.e., its upper (or lower) asymptote.
+	AmplitudeEnd float64 `json:"amplitude_end" yaml:"amplitude_end"`
+
+	// Midpoint represents the time, since the start of the segment, at which the curve is halfway between the
+	// amplitudes.
+	Midpoint time.Duration `json:"midpoint" yaml:"midpoint"`
+
+	// TransitionDuration represents the time it takes for the curve to go from 10% to 90% of the way between the
+	// amplitudes. The shorter the transition, the steeper the curve.
+	TransitionDuration time.Duration `json:"transition_duration" yaml:"transition_duration"`
+
+	// DurationLength sets the max duration between the first and the last value.
+	DurationLength time.Duration `json:"duration_length" yaml:"duration_length"`
+
+	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
+	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
+
+	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
+}
+
+// Check at compile time whether LogisticSegmentDataIterator implements DataIterator interface.
+var _ DataIterator = (*LogisticSegmentDataIterator)(nil)
+
+// LogisticSegmentDataIterator returns a DataIterator representing a logistic function (S-curve), going from
+// AmplitudeStart to AmplitudeEnd.
+// It's useful to simulate smooth transitions, such as traffic shifting to a new deployment.
+// Note that the curve only reaches the amplitudes asymptotically, so the values at the bounds of the segment are close
+// to, but not exactly, the amplitudes.
+// The zero value is not useful. Use NewLogisticSegmentDataIterator function.
+type LogisticSegmentDataIterator struct {
+	options LogisticSegmentDataIteratorOptions
+
+	// steepness is the logistic growth rate, per nanosecond.
+	steepness float64
+}
+
+// NewLogisticSegmentDataIterator returns a new instance of LogisticSegmentDataIterator.
+func NewLogisticSegmentDataIterator(options LogisticSegmentDataIteratorOptions) (*LogisticSegmentDataIterator, error) {
+	if options.DurationLength < 0 {
+		return &LogisticSegmentDataIterator{}, fmt.Errorf("duration length cannot be less than zero")
+	}
+
+	if options.TransitionDuration <= 0 {
+		return &LogisticSegmentDataIterator{}, fmt.Errorf("transition duration cannot be less than or equal to zero")
+	}
+
+	// The logistic function goes from 10% to 90% in 2*ln(9)/steepness.
+	steepness := 2 * math.Log(9) / float64(options.TransitionDuration)
+
+	return &LogisticSegmentDataIterator{
+		options:   options,
+		steepness: steepness,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (ls *LogisticSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, ls.options.DurationLength, ls.options.IntervalLeftBoundOpen, ls.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
+	}
+
+	progress := 1 / (1 + math.Exp(-ls.steepness*float64(elapsedTime-ls.options.Midpoint)))
+	value := ls.options.AmplitudeStart + (ls.options.AmplitudeEnd-ls.options.AmplitudeStart)*progress
+	return metrics.ScrapeResult{Value: value}
+}
+
+// Duration reports the duration of the continuous function.
+func (ls *LogisticSegmentDataIterator) Duration() time.Duration {
+	return ls.options.DurationLength
+}
+
+// Describe describes the continuous function.
+func (ls *LogisticSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeLogisticSegment, "Continuous Logistic Segment", ls.options)
+}
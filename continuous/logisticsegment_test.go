@@ -0,0 +1,66 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func TestLogisticSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given that transition duration is zero", func(t *testing.T) {
+		_, err := continuous.NewLogisticSegmentDataIterator(continuous.LogisticSegmentDataIteratorOptions{
+			AmplitudeStart: 0,
+			AmplitudeEnd:   100,
+			DurationLength: time.Minute,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "transition duration cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should go from 10% to 90% within the transition duration, closed interval", func(t *testing.T) {
+		lsDataIterator, err := continuous.NewLogisticSegmentDataIterator(continuous.LogisticSegmentDataIteratorOptions{
+			AmplitudeStart:     0,
+			AmplitudeEnd:       100,
+			Midpoint:           time.Minute,
+			TransitionDuration: 30 * time.Second,
+			DurationLength:     2 * time.Minute,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, lsDataIterator, functionStartTime)
+
+		require.Equal(t, 9, len(results))
+		assert.InDelta(t, 10, results[3].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 50, results[4].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 90, results[5].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 0, results[0].scrapeResult.Value, 0.1)
+		assert.InDelta(t, 100, results[8].scrapeResult.Value, 0.1)
+	})
+
+	t.Run("should go down given an end amplitude lower than the start amplitude", func(t *testing.T) {
+		lsDataIterator, err := continuous.NewLogisticSegmentDataIterator(continuous.LogisticSegmentDataIteratorOptions{
+			AmplitudeStart:     100,
+			AmplitudeEnd:       20,
+			Midpoint:           30 * time.Second,
+			TransitionDuration: 30 * time.Second,
+			DurationLength:     time.Minute,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, lsDataIterator, functionStartTime)
+
+		require.Equal(t, 5, len(results))
+		assert.InDelta(t, 92, results[1].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 60, results[2].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 28, results[3].scrapeResult.Value, 0.001)
+	})
+}
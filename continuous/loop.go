@@ -0,0 +1,114 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// LoopDataIteratorOptions contains the options for the LoopDataIterator.
+type LoopDataIteratorOptions struct {
+	// Count sets the number of times the function is repeated.
+	// A count of zero means the function is repeated forever.
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
+}
+
+// Check at compile time whether LoopDataIterator implements DataIterator interface.
+var _ DataIterator = (*LoopDataIterator)(nil)
+
+// LoopDataIterator repeats a continuous function, back to back in time, N times or forever.
+// Each repetition starts when the previous one ends, according to the Duration of the function.
+// The interval bounds of the function are honoured at the boundaries between repetitions, just like in the
+// JoinDataIterator.
+// The Duration of a loop that repeats forever is the maximum duration possible, hence such a loop cannot be repeated by
+// another loop.
+// The zero value is not useful. Use NewLoopDataIterator function.
+type LoopDataIterator struct {
+	dataIterator DataIterator
+	options      LoopDataIteratorOptions
+}
+
+// NewLoopDataIterator returns a new instance of LoopDataIterator.
+func NewLoopDataIterator(dataIterator DataIterator, options LoopDataIteratorOptions) (*LoopDataIterator, error) {
+	if dataIterator == nil {
+		return &LoopDataIterator{}, fmt.Errorf("data iterator cannot be nil")
+	}
+
+	if dataIterator.Duration() <= 0 {
+		return &LoopDataIterator{}, fmt.Errorf("data iterator duration must be greater than zero")
+	}
+
+	// A function lasting forever, e.g., another loop repeating forever, would never get to the next repetition.
+	if dataIterator.Duration() == time.Duration(math.MaxInt64) {
+		return &LoopDataIterator{}, fmt.Errorf("data iterator duration cannot be infinite")
+	}
+
+	if options.Count < 0 {
+		return &LoopDataIterator{}, fmt.Errorf("count cannot be less than zero")
+	}
+
+	return &LoopDataIterator{
+		dataIterator: dataIterator,
+		options:      options,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (l *LoopDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime := scrapeInfo.IterationTime.Sub(scrapeInfo.FunctionStartTime)
+	if elapsedTime < 0 {
+		return l.dataIterator.Evaluate(scrapeInfo)
+	}
+
+	duration := l.dataIterator.Duration()
+	functionStartTime := scrapeInfo.FunctionStartTime
+
+	// repetition is the repetition the scrape lands on. When the scrape lands on the boundary between two repetitions,
+	// the previous repetition is evaluated first, so its right bound is honoured.
+	repetition := int(elapsedTime / duration)
+	if elapsedTime%duration == 0 && repetition > 0 {
+		repetition--
+	}
+
+	for ; l.options.Count == 0 || repetition < l.options.Count; repetition++ {
+		scrapeInfo.FunctionStartTime = functionStartTime.Add(time.Duration(repetition) * duration)
+
+		result := l.dataIterator.Evaluate(scrapeInfo)
+		if result.Exhausted {
+			continue
+		}
+
+		return result
+	}
+
+	return metrics.ScrapeResult{Exhausted: true}
+}
+
+// Duration reports the duration of the continuous function.
+// The duration saturates at the maximum duration possible, just like in the JoinDataIterator.
+func (l *LoopDataIterator) Duration() time.Duration {
+	if l.options.Count == 0 || l.dataIterator.Duration() > time.Duration(math.MaxInt64)/time.Duration(l.options.Count) {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(l.options.Count) * l.dataIterator.Duration()
+}
+
+// Describe describes the continuous function, along with the function repeated.
+// The name of the loop shows the number of repetitions, or 'forever', e.g., "Continuous Loop [forever]".
+func (l *LoopDataIterator) Describe() metrics.DataSpec {
+	repetitions := "forever"
+	if l.options.Count > 0 {
+		repetitions = strconv.Itoa(l.options.Count)
+	}
+
+	return metrics.NewContainerDataSpec(
+		DataGeneratorTypeLoop,
+		fmt.Sprintf("Continuous Loop [%s]", repetitions),
+		l.options,
+		[]metrics.DataSpec{l.dataIterator.Describe()},
+	)
+}
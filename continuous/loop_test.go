@@ -0,0 +1,124 @@
+package continuous_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestLoopDataIterator(t *testing.T) {
+	functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	t.Run("should fail given a function without duration", func(t *testing.T) {
+		_, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 1, AmplitudeEnd: 1}),
+			continuous.LoopDataIteratorOptions{Count: 2},
+		)
+		require.Error(t, err)
+		assert.Equal(t, "data iterator duration must be greater than zero", err.Error())
+	})
+
+	t.Run("should fail given a function that lasts forever", func(t *testing.T) {
+		foreverDataIterator, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second}),
+			continuous.LoopDataIteratorOptions{},
+		)
+		require.NoError(t, err)
+
+		_, err = continuous.NewLoopDataIterator(foreverDataIterator, continuous.LoopDataIteratorOptions{Count: 2})
+		require.Error(t, err)
+		assert.Equal(t, "data iterator duration cannot be infinite", err.Error())
+	})
+
+	t.Run("should saturate the duration of a loop repeating a long function", func(t *testing.T) {
+		dataIterator, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: time.Duration(math.MaxInt64 / 2)}),
+			continuous.LoopDataIteratorOptions{Count: 3},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(math.MaxInt64), dataIterator.Duration())
+	})
+
+	t.Run("should repeat the function N times", func(t *testing.T) {
+		dataIterator, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second}),
+			continuous.LoopDataIteratorOptions{Count: 3},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 90*time.Second, dataIterator.Duration())
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+
+		// The right bound is closed, so the boundaries belong to the previous repetition.
+		var values []float64
+		for _, result := range results {
+			values = append(values, result.scrapeResult.Value)
+		}
+		assert.InDeltaSlice(t, []float64{0, 15, 30, 15, 30, 15, 30}, values, 0.001)
+	})
+
+	t.Run("should start the next repetition at the boundary given an open right bound", func(t *testing.T) {
+		dataIterator, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second, IntervalRightBoundOpen: true,
+			}),
+			continuous.LoopDataIteratorOptions{Count: 2},
+		)
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+
+		var values []float64
+		for _, result := range results {
+			values = append(values, result.scrapeResult.Value)
+		}
+		assert.InDeltaSlice(t, []float64{0, 15, 0, 15}, values, 0.001)
+	})
+
+	t.Run("should repeat the function forever", func(t *testing.T) {
+		dataIterator, err := continuous.NewLoopDataIterator(
+			helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+				AmplitudeStart: 0, AmplitudeEnd: 60, DurationLength: time.Minute, IntervalRightBoundOpen: true,
+			}),
+			continuous.LoopDataIteratorOptions{},
+		)
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataIterator, functionStartTime)
+		require.Equal(t, 100, len(results))
+		assert.InDelta(t, 45, results[99].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should describe the loop and the function repeated", func(t *testing.T) {
+		options := continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second}
+		dataIterator, err := continuous.NewLoopDataIterator(helperLinearSegment(t, options), continuous.LoopDataIteratorOptions{Count: 3})
+		require.NoError(t, err)
+
+		loopDataSpec, ok := dataIterator.Describe().(discrete.ContainerDataSpec)
+		require.True(t, ok)
+		assert.Equal(t, continuous.DataGeneratorTypeLoop, loopDataSpec.DataGeneratorType())
+		assert.Equal(t, "Continuous Loop [3]", loopDataSpec.Name())
+		assert.Equal(t, continuous.LoopDataIteratorOptions{Count: 3}, loopDataSpec.Options())
+		require.Equal(t, 1, len(loopDataSpec.Children()))
+
+		funcDataSpec, ok := loopDataSpec.Children()[0].(discrete.DataNodeDataSpec)
+		require.True(t, ok)
+		assert.Equal(t, continuous.DataGeneratorTypeLinearSegment, funcDataSpec.DataGeneratorType())
+		assert.Equal(t, options, funcDataSpec.Options())
+	})
+
+	t.Run("should describe a loop repeating the function forever", func(t *testing.T) {
+		options := continuous.LinearSegmentDataIteratorOptions{AmplitudeStart: 0, AmplitudeEnd: 30, DurationLength: 30 * time.Second}
+		dataIterator, err := continuous.NewLoopDataIterator(helperLinearSegment(t, options), continuous.LoopDataIteratorOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Continuous Loop [forever]\n  Continuous Linear Segment", discrete.Describe(dataIterator.Describe()))
+		assert.Contains(t, discrete.RenderMermaid(dataIterator.Describe()), "Continuous Loop [forever]")
+	})
+}
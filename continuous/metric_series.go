@@ -0,0 +1,106 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+// Check at compile time whether MetricTimeSeries implements promadapter.MetricTimeSeriesObservable interface.
+var _ promadapter.MetricTimeSeriesObservable = (*MetricTimeSeries)(nil)
+
+// MetricTimeSeries represents a metric time series (counter or gauge) backed by a continuous function.
+// When the continuous function comes to an end, the metrics.EndStrategy decides on what to do next. Unlike the
+// discrete.MetricTimeSeries, the loop end strategy repeats the function back to back in time, i.e., the function
+// restarts at the end of its Duration rather than on the next scrape.
+// The function is anchored at the start time. If the start time is zero, every iterator anchors the function at the
+// time of the first scrape it evaluates.
+// The zero value of MetricTimeSeries is not useful. Use NewMetricTimeSeries function.
+type MetricTimeSeries struct {
+	labels map[string]string
+
+	dataIterator DataIterator
+	startTime    time.Time
+	endStrategy  metrics.EndStrategy
+}
+
+// NewMetricTimeSeries creates a new instance of MetricTimeSeries.
+func NewMetricTimeSeries(labels map[string]string, dataIterator DataIterator, startTime time.Time, endStrategy metrics.EndStrategy) (*MetricTimeSeries, error) {
+	if dataIterator == nil {
+		return &MetricTimeSeries{}, fmt.Errorf("error validating metric time series configuration: data iterator cannot be nil")
+	}
+
+	// A function lasting forever never gets to the end strategy, so there is nothing to loop over.
+	if endStrategy.EndStrategyType == metrics.EndStrategyTypeLoop && dataIterator.Duration() != time.Duration(math.MaxInt64) {
+		loopDataIterator, err := NewLoopDataIterator(dataIterator, LoopDataIteratorOptions{})
+		if err != nil {
+			return &MetricTimeSeries{}, fmt.Errorf("error validating metric time series configuration: %w", err)
+		}
+		dataIterator = loopDataIterator
+	}
+
+	return &MetricTimeSeries{
+		labels:       labels,
+		dataIterator: dataIterator,
+		startTime:    startTime,
+		endStrategy:  endStrategy,
+	}, nil
+}
+
+// Iterator returns a time series iterator that can be used to iterate over the data.
+func (ts *MetricTimeSeries) Iterator() metrics.DataIterator {
+	dataIterator := ts.dataIterator
+	functionStartTime := ts.startTime
+
+	return &MetricTimeSeriesDataIterator{
+		timeSeriesIterator: metrics.NewTimeSeriesIterator(func() metrics.Evaluator[metrics.ScrapeResult] {
+			return metrics.DataIteratorFunc(func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+				if functionStartTime.IsZero() {
+					functionStartTime = scrapeInfo.IterationTime
+				}
+
+				return dataIterator.Evaluate(newScrapeInfo(scrapeInfo, functionStartTime))
+			})
+		}, ts.endStrategy),
+	}
+}
+
+// Labels returns the labels associated with the time series.
+func (ts *MetricTimeSeries) Labels() map[string]string {
+	return ts.labels
+}
+
+// IsInfinite reports whether this time series is infinite.
+// In other words, whether this time series will never stop generating samples.
+func (ts *MetricTimeSeries) IsInfinite() bool {
+	return ts.endStrategy.EndStrategyType != metrics.EndStrategyTypeRemoveTimeSeries
+}
+
+// Check at compile time whether MetricTimeSeriesDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*MetricTimeSeriesDataIterator)(nil)
+
+// MetricTimeSeriesDataIterator iterates over the samples of a MetricTimeSeries, anchoring the continuous function at
+// the start time of the time series, or at the time of the first scrape.
+// The end strategy is evaluated by metrics.TimeSeriesIterator.
+type MetricTimeSeriesDataIterator struct {
+	timeSeriesIterator *metrics.TimeSeriesIterator[metrics.ScrapeResult]
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *MetricTimeSeriesDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	return di.timeSeriesIterator.Evaluate(scrapeInfo)
+}
+
+// newScrapeInfo turns the metrics.ScrapeInfo into a ScrapeInfo, given the time the continuous function is anchored at.
+func newScrapeInfo(scrapeInfo metrics.ScrapeInfo, functionStartTime time.Time) ScrapeInfo {
+	return ScrapeInfo{
+		FirstIterationTime: scrapeInfo.FirstIterationTime,
+		IterationIndex:     scrapeInfo.IterationIndex,
+		IterationTime:      scrapeInfo.IterationTime,
+		FunctionStartTime:  functionStartTime,
+	}
+}
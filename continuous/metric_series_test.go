@@ -0,0 +1,103 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestMetricTimeSeries(t *testing.T) {
+	scraperStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	// helperScrapeTimeSeries scrapes the time series 8 times, every 15 seconds.
+	helperScrapeTimeSeries := func(t *testing.T, timeSeries *continuous.MetricTimeSeries) []metrics.ScrapeResult {
+		t.Helper()
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      scraperStartTime,
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(8),
+		)
+		require.NoError(t, err)
+
+		var results []metrics.ScrapeResult
+		err = scraper.ScrapeDataIterator(timeSeries.Iterator(), func(_ metrics.ScrapeInfo, scrapeResult metrics.ScrapeResult) error {
+			results = append(results, scrapeResult)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return results
+	}
+
+	dataIterator := helperLinearSegment(t, continuous.LinearSegmentDataIteratorOptions{
+		AmplitudeStart: 0, AmplitudeEnd: 40, DurationLength: 40 * time.Second,
+	})
+
+	t.Run("should loop over the function back to back in time", func(t *testing.T) {
+		timeSeries, err := continuous.NewMetricTimeSeries(nil, dataIterator, time.Time{}, metrics.NewEndStrategyLoop())
+		require.NoError(t, err)
+		assert.True(t, timeSeries.IsInfinite())
+
+		results := helperScrapeTimeSeries(t, timeSeries)
+
+		// The function restarts every 40 seconds, regardless of the scrape interval.
+		var values []float64
+		for _, result := range results {
+			values = append(values, result.Value)
+		}
+		assert.InDeltaSlice(t, []float64{0, 15, 30, 5, 20, 35, 10, 25}, values, 0.001)
+	})
+
+	t.Run("should accept a function lasting forever given the loop end strategy", func(t *testing.T) {
+		foreverDataIterator, err := continuous.NewLoopDataIterator(dataIterator, continuous.LoopDataIteratorOptions{})
+		require.NoError(t, err)
+
+		timeSeries, err := continuous.NewMetricTimeSeries(nil, foreverDataIterator, time.Time{}, metrics.NewEndStrategyLoop())
+		require.NoError(t, err)
+
+		results := helperScrapeTimeSeries(t, timeSeries)
+
+		var values []float64
+		for _, result := range results {
+			values = append(values, result.Value)
+		}
+		assert.InDeltaSlice(t, []float64{0, 15, 30, 5, 20, 35, 10, 25}, values, 0.001)
+	})
+
+	t.Run("should send the last value forever given the send last value end strategy", func(t *testing.T) {
+		timeSeries, err := continuous.NewMetricTimeSeries(nil, dataIterator, scraperStartTime, metrics.NewEndStrategySendLastValue())
+		require.NoError(t, err)
+
+		results := helperScrapeTimeSeries(t, timeSeries)
+		require.Equal(t, 8, len(results))
+		assert.InDelta(t, 30, results[7].Value, 0.001)
+	})
+
+	t.Run("should send the custom value forever given the send custom value end strategy", func(t *testing.T) {
+		timeSeries, err := continuous.NewMetricTimeSeries(nil, dataIterator, scraperStartTime, metrics.NewEndStrategySendCustomValue(metrics.ScrapeResult{Missing: true}))
+		require.NoError(t, err)
+
+		results := helperScrapeTimeSeries(t, timeSeries)
+		require.Equal(t, 8, len(results))
+		assert.False(t, results[2].Missing)
+		assert.True(t, results[3].Missing)
+		assert.True(t, results[7].Missing)
+	})
+
+	t.Run("should remove the time series given the remove time series end strategy", func(t *testing.T) {
+		timeSeries, err := continuous.NewMetricTimeSeries(nil, dataIterator, scraperStartTime, metrics.NewEndStrategyRemoveTimeSeries())
+		require.NoError(t, err)
+		assert.False(t, timeSeries.IsInfinite())
+
+		results := helperScrapeTimeSeries(t, timeSeries)
+		assert.Equal(t, 3, len(results))
+	})
+}
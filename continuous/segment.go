@@ -10,21 +10,21 @@ import (
 // LinearSegmentDataIteratorOptions contains the options for the LinearSegmentDataIterator.
 type LinearSegmentDataIteratorOptions struct {
 	// AmplitudeStart represents the initial value for the segment.
-	AmplitudeStart float64
+	AmplitudeStart float64 `json:"amplitude_start" yaml:"amplitude_start"`
 
 	// AmplitudeEnd represents the end value for the segment.
-	AmplitudeEnd float64
+	AmplitudeEnd float64 `json:"amplitude_end" yaml:"amplitude_end"`
 
 	// DurationLength sets the max duration between the first and the last value.
-	DurationLength time.Duration
+	DurationLength time.Duration `json:"duration_length" yaml:"duration_length"`
 
 	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
 	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
-	IntervalLeftBoundOpen bool
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
 
 	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
-	// If a scrape time lands on the right bound and this option is set to true, the function will return a missing value.
-	IntervalRightBoundOpen bool
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
 }
 
 // Check at compile time whether LinearSegmentDataIterator implements DataIterator interface.
@@ -59,18 +59,9 @@ func NewLinearSegmentDataIterator(options LinearSegmentDataIteratorOptions) (*Li
 
 // Evaluate computes a sample given a scrape.
 func (ls *LinearSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
-	// Normalize
-	currentElapsedTime := scrapeInfo.IterationTime.Sub(scrapeInfo.FunctionStartTime)
-
-	// Deal with boundaries
-	if scrapeInfo.IterationTime.Before(scrapeInfo.FunctionStartTime) { // if before time, return missing
-		return metrics.ScrapeResult{Missing: true}
-	} else if scrapeInfo.IterationTime.Equal(scrapeInfo.FunctionStartTime) && ls.options.IntervalLeftBoundOpen {
-		return metrics.ScrapeResult{Missing: true}
-	} else if currentElapsedTime == ls.options.DurationLength && ls.options.IntervalRightBoundOpen {
-		return metrics.ScrapeResult{Exhausted: true}
-	} else if currentElapsedTime > ls.options.DurationLength { // we are past the duration length for this function
-		return metrics.ScrapeResult{Exhausted: true}
+	currentElapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, ls.options.DurationLength, ls.options.IntervalLeftBoundOpen, ls.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
 	}
 
 	// If we have a horizontal line, there is no need to do any computation
@@ -87,3 +78,31 @@ func (ls *LinearSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.Scr
 func (ls *LinearSegmentDataIterator) Duration() time.Duration {
 	return ls.options.DurationLength
 }
+
+// Describe describes the continuous function.
+func (ls *LinearSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeLinearSegment, "Continuous Linear Segment", ls.options)
+}
+
+// evaluateInterval checks whether the scrape lands within the interval of a function with the given duration, taking
+// into account whether the bounds of the interval are open.
+// It returns the time elapsed since the start of the function and true if the scrape lands within the interval.
+// Otherwise, it returns the result the function should return: a missing sample before the start of the function and an
+// exhausted result after its end.
+func evaluateInterval(scrapeInfo ScrapeInfo, duration time.Duration, leftBoundOpen bool, rightBoundOpen bool) (time.Duration, metrics.ScrapeResult, bool) {
+	// Normalize
+	elapsedTime := scrapeInfo.IterationTime.Sub(scrapeInfo.FunctionStartTime)
+
+	// Deal with boundaries
+	if elapsedTime < 0 { // if before time, return missing
+		return 0, metrics.ScrapeResult{Missing: true}, false
+	} else if elapsedTime == 0 && leftBoundOpen {
+		return 0, metrics.ScrapeResult{Missing: true}, false
+	} else if elapsedTime == duration && rightBoundOpen {
+		return 0, metrics.ScrapeResult{Exhausted: true}, false
+	} else if elapsedTime > duration { // we are past the duration length for this function
+		return 0, metrics.ScrapeResult{Exhausted: true}, false
+	}
+
+	return elapsedTime, metrics.ScrapeResult{}, true
+}
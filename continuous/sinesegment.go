@@ -0,0 +1,86 @@
+package continuous
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// SineSegmentDataIteratorOptions contains the options for the SineSegmentDataIterator.
+// The value returned is given by: Offset + Amplitude * sin(2π * (elapsed time + Phase) / Period).
+type SineSegmentDataIteratorOptions struct {
+	// Amplitude represents the peak deviation of the wave from the Offset.
+	Amplitude float64 `json:"amplitude" yaml:"amplitude"`
+
+	// Offset represents the value the wave oscillates around.
+	Offset float64 `json:"offset" yaml:"offset"`
+
+	// Period represents the time it takes for the wave to complete a full cycle.
+	Period time.Duration `json:"period" yaml:"period"`
+
+	// Phase shifts the wave by the given duration.
+	// A positive phase moves the wave to the left, a negative phase moves the wave to the right.
+	Phase time.Duration `json:"phase" yaml:"phase"`
+
+	// DurationLength sets the max duration between the first and the last value.
+	DurationLength time.Duration `json:"duration_length" yaml:"duration_length"`
+
+	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
+	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
+
+	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
+}
+
+// Check at compile time whether SineSegmentDataIterator implements DataIterator interface.
+var _ DataIterator = (*SineSegmentDataIterator)(nil)
+
+// SineSegmentDataIterator returns a DataIterator representing a sine wave.
+// It's useful to simulate daily or weekly seasonality, e.g., with a period of 24 hours.
+// Note that it's an error to use a sine segment containing negative values with counters. It's the user
+// responsibility to make sure negative numbers only appear in gauges.
+// The zero value is not useful. Use NewSineSegmentDataIterator function.
+type SineSegmentDataIterator struct {
+	options SineSegmentDataIteratorOptions
+}
+
+// NewSineSegmentDataIterator returns a new instance of SineSegmentDataIterator.
+func NewSineSegmentDataIterator(options SineSegmentDataIteratorOptions) (*SineSegmentDataIterator, error) {
+	if options.DurationLength < 0 {
+		return &SineSegmentDataIterator{}, fmt.Errorf("duration length cannot be less than zero")
+	}
+
+	if options.Period <= 0 {
+		return &SineSegmentDataIterator{}, fmt.Errorf("period cannot be less than or equal to zero")
+	}
+
+	return &SineSegmentDataIterator{
+		options: options,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (ss *SineSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, ss.options.DurationLength, ss.options.IntervalLeftBoundOpen, ss.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
+	}
+
+	cycles := float64(elapsedTime+ss.options.Phase) / float64(ss.options.Period)
+	value := ss.options.Offset + ss.options.Amplitude*math.Sin(2*math.Pi*cycles)
+	return metrics.ScrapeResult{Value: value}
+}
+
+// Duration reports the duration of the continuous function.
+func (ss *SineSegmentDataIterator) Duration() time.Duration {
+	return ss.options.DurationLength
+}
+
+// Describe describes the continuous function.
+func (ss *SineSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeSineSegment, "Continuous Sine Segment", ss.options)
+}
@@ -0,0 +1,65 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func TestSineSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given that period is zero", func(t *testing.T) {
+		_, err := continuous.NewSineSegmentDataIterator(continuous.SineSegmentDataIteratorOptions{
+			Amplitude:      10,
+			DurationLength: time.Minute,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "period cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should produce a full cycle of the wave, closed interval", func(t *testing.T) {
+		ssDataIterator, err := continuous.NewSineSegmentDataIterator(continuous.SineSegmentDataIteratorOptions{
+			Amplitude:      10,
+			Offset:         50,
+			Period:         time.Minute,
+			DurationLength: time.Minute,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, ssDataIterator, functionStartTime)
+
+		expectedValues := []float64{50, 60, 50, 40, 50}
+		require.Equal(t, len(expectedValues), len(results))
+		for i, expectedValue := range expectedValues {
+			assert.InDelta(t, expectedValue, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should shift the wave given a phase, right open interval", func(t *testing.T) {
+		ssDataIterator, err := continuous.NewSineSegmentDataIterator(continuous.SineSegmentDataIteratorOptions{
+			Amplitude:              10,
+			Offset:                 50,
+			Period:                 time.Minute,
+			Phase:                  15 * time.Second,
+			DurationLength:         time.Minute,
+			IntervalRightBoundOpen: true,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, ssDataIterator, functionStartTime)
+
+		expectedValues := []float64{60, 50, 40, 50}
+		require.Equal(t, len(expectedValues), len(results))
+		for i, expectedValue := range expectedValues {
+			assert.InDelta(t, expectedValue, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+}
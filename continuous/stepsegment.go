@@ -0,0 +1,81 @@
+package continuous
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// StepSegmentDataIteratorOptions contains the options for the StepSegmentDataIterator.
+type StepSegmentDataIteratorOptions struct {
+	// AmplitudeStart represents the value before the step.
+	AmplitudeStart float64 `json:"amplitude_start" yaml:"amplitude_start"`
+
+	// AmplitudeEnd represents the value from the step onwards.
+	AmplitudeEnd float64 `json:"amplitude_end" yaml:"amplitude_end"`
+
+	// StepTime represents the time, since the start of the segment, at which the step happens.
+	StepTime time.Duration `json:"step_time" yaml:"step_time"`
+
+	// DurationLength sets the max duration between the first and the last value.
+	DurationLength time.Duration `json:"duration_length" yaml:"duration_length"`
+
+	// IntervalLeftBoundOpen specifies whether the left bound of the duration interval should be open.
+	// If a scrape time lands on the left bound and this option is set to true, the function will return a missing value.
+	IntervalLeftBoundOpen bool `json:"interval_left_bound_open" yaml:"interval_left_bound_open"`
+
+	// IntervalRightBoundOpen specifies whether the right bound of the duration interval should be open.
+	// If a scrape time lands on the right bound and this option is set to true, the function will return an exhausted value.
+	IntervalRightBoundOpen bool `json:"interval_right_bound_open" yaml:"interval_right_bound_open"`
+}
+
+// Check at compile time whether StepSegmentDataIterator implements DataIterator interface.
+var _ DataIterator = (*StepSegmentDataIterator)(nil)
+
+// StepSegmentDataIterator returns a DataIterator representing a step function.
+// The value is AmplitudeStart before the StepTime and AmplitudeEnd from the StepTime onwards.
+// Several steps can be put together with the InterpolationSegmentDataIterator, using the step interpolation.
+// The zero value is not useful. Use NewStepSegmentDataIterator function.
+type StepSegmentDataIterator struct {
+	options StepSegmentDataIteratorOptions
+}
+
+// NewStepSegmentDataIterator returns a new instance of StepSegmentDataIterator.
+func NewStepSegmentDataIterator(options StepSegmentDataIteratorOptions) (*StepSegmentDataIterator, error) {
+	if options.DurationLength < 0 {
+		return &StepSegmentDataIterator{}, fmt.Errorf("duration length cannot be less than zero")
+	}
+
+	if options.StepTime < 0 || options.StepTime > options.DurationLength {
+		return &StepSegmentDataIterator{}, fmt.Errorf("step time must be within the duration length")
+	}
+
+	return &StepSegmentDataIterator{
+		options: options,
+	}, nil
+}
+
+// Evaluate computes a sample given a scrape.
+func (ss *StepSegmentDataIterator) Evaluate(scrapeInfo ScrapeInfo) metrics.ScrapeResult {
+	elapsedTime, boundaryResult, ok := evaluateInterval(scrapeInfo, ss.options.DurationLength, ss.options.IntervalLeftBoundOpen, ss.options.IntervalRightBoundOpen)
+	if !ok {
+		return boundaryResult
+	}
+
+	if elapsedTime < ss.options.StepTime {
+		return metrics.ScrapeResult{Value: ss.options.AmplitudeStart}
+	}
+
+	return metrics.ScrapeResult{Value: ss.options.AmplitudeEnd}
+}
+
+// Duration reports the duration of the continuous function.
+func (ss *StepSegmentDataIterator) Duration() time.Duration {
+	return ss.options.DurationLength
+}
+
+// Describe describes the continuous function.
+func (ss *StepSegmentDataIterator) Describe() metrics.DataSpec {
+	return metrics.NewDataNodeDataSpec(DataGeneratorTypeStepSegment, "Continuous Step Segment", ss.options)
+}
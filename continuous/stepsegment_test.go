@@ -0,0 +1,45 @@
+package continuous_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/continuous"
+)
+
+func TestStepSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given a step time outside the duration length", func(t *testing.T) {
+		_, err := continuous.NewStepSegmentDataIterator(continuous.StepSegmentDataIteratorOptions{
+			AmplitudeStart: 10,
+			AmplitudeEnd:   20,
+			StepTime:       2 * time.Minute,
+			DurationLength: time.Minute,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "step time must be within the duration length"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should switch to the end amplitude at the step time, closed interval", func(t *testing.T) {
+		ssDataIterator, err := continuous.NewStepSegmentDataIterator(continuous.StepSegmentDataIteratorOptions{
+			AmplitudeStart: 10,
+			AmplitudeEnd:   20,
+			StepTime:       30 * time.Second,
+			DurationLength: time.Minute,
+		})
+		require.NoError(t, err)
+
+		functionStartTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		results := helperScraper(t, ssDataIterator, functionStartTime)
+
+		expectedValues := []float64{10, 10, 20, 20, 20}
+		require.Equal(t, len(expectedValues), len(results))
+		for i, expectedValue := range expectedValues {
+			assert.Equal(t, expectedValue, results[i].scrapeResult.Value)
+		}
+	})
+}
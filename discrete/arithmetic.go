@@ -0,0 +1,233 @@
+package discrete
+
+import (
+	"math"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+type ArithmeticOperationType string
+
+const (
+	ArithmeticOperationTypeAdd      ArithmeticOperationType = "arithmetic_operation_type-add"
+	ArithmeticOperationTypeSubtract ArithmeticOperationType = "arithmetic_operation_type-subtract"
+	ArithmeticOperationTypeMultiply ArithmeticOperationType = "arithmetic_operation_type-multiply"
+	ArithmeticOperationTypeDivide   ArithmeticOperationType = "arithmetic_operation_type-divide"
+	ArithmeticOperationTypeMin      ArithmeticOperationType = "arithmetic_operation_type-min"
+	ArithmeticOperationTypeMax      ArithmeticOperationType = "arithmetic_operation_type-max"
+)
+
+// Check at compile time whether ArithmeticDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*ArithmeticDataGenerator)(nil)
+
+// ArithmeticDataGenerator combines the samples of two or more DataGenerators into a single sample.
+// On each scrape, every child DataGenerator is evaluated once, and their values are combined, from left to right,
+// according to the arithmetic operation. For example, subtracting the generators [a, b, c] results in a - b - c.
+//
+// The following rules apply:
+//   - If any of the children returns a missing sample, the resulting sample is missing as well.
+//   - Otherwise, if any of the children returns a stale sample, the resulting sample is stale as well.
+//   - The children are evaluated in lockstep. The generator is exhausted as soon as any of its children is exhausted,
+//     which means the generator produces as many samples as the shortest child. Use the LoopDataGenerator or the
+//     JoinDataGenerator to extend the shorter children if needed.
+//   - Dividing by zero results in a missing sample.
+//   - If no children are provided, the generator does not produce any samples.
+//
+// The zero value is not useful. Use one of the helper functions NewAddDataGenerator, NewSubtractDataGenerator,
+// NewMultiplyDataGenerator, NewDivideDataGenerator, NewMinDataGenerator or NewMaxDataGenerator.
+type ArithmeticDataGenerator struct {
+	dataGenerators []DataGenerator
+	operationType  ArithmeticOperationType
+}
+
+// NewAddDataGenerator returns an ArithmeticDataGenerator that adds up the samples of all DataGenerators.
+func NewAddDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeAdd)
+}
+
+// NewSubtractDataGenerator returns an ArithmeticDataGenerator that subtracts the samples of all remaining
+// DataGenerators from the first one.
+func NewSubtractDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeSubtract)
+}
+
+// NewMultiplyDataGenerator returns an ArithmeticDataGenerator that multiplies the samples of all DataGenerators.
+func NewMultiplyDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeMultiply)
+}
+
+// NewDivideDataGenerator returns an ArithmeticDataGenerator that divides the sample of the first DataGenerator by the
+// samples of all remaining DataGenerators.
+func NewDivideDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeDivide)
+}
+
+// NewMinDataGenerator returns an ArithmeticDataGenerator that returns the smallest sample of all DataGenerators.
+func NewMinDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeMin)
+}
+
+// NewMaxDataGenerator returns an ArithmeticDataGenerator that returns the largest sample of all DataGenerators.
+func NewMaxDataGenerator(dataGenerators []DataGenerator) *ArithmeticDataGenerator {
+	return newArithmeticDataGenerator(dataGenerators, ArithmeticOperationTypeMax)
+}
+
+func newArithmeticDataGenerator(dataGenerators []DataGenerator, operationType ArithmeticOperationType) *ArithmeticDataGenerator {
+	return &ArithmeticDataGenerator{
+		dataGenerators: dataGenerators,
+		operationType:  operationType,
+	}
+}
+
+func (dg *ArithmeticDataGenerator) Iterator() metrics.DataIterator {
+	return &ArithmeticDataIterator{
+		arithmeticDataGenerator: *dg,
+	}
+}
+
+// WithSeed returns a copy of the ArithmeticDataGenerator where each child is seeded with a seed derived from the seed
+// provided.
+// A seed of zero returns the ArithmeticDataGenerator unchanged, so children keep the seeds they were created with.
+func (dg *ArithmeticDataGenerator) WithSeed(seed int64) DataGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	dataGenerators := make([]DataGenerator, len(dg.dataGenerators))
+	for i, dataGenerator := range dg.dataGenerators {
+		dataGenerators[i] = WithSeed(dataGenerator, DeriveSeed(seed, i))
+	}
+
+	return &ArithmeticDataGenerator{
+		dataGenerators: dataGenerators,
+		operationType:  dg.operationType,
+	}
+}
+
+func (dg *ArithmeticDataGenerator) Describe() DataSpec {
+	var dataSpecs []DataSpec
+
+	for _, dataGenerator := range dg.dataGenerators {
+		dataSpecs = append(dataSpecs, dataGenerator.Describe())
+	}
+
+	return ArithmeticDataSpec{
+		OperationType: dg.operationType,
+		Children:      dataSpecs,
+	}
+}
+
+// Check at compile time whether ArithmeticDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*ArithmeticDataIterator)(nil)
+
+type ArithmeticDataIterator struct {
+	arithmeticDataGenerator ArithmeticDataGenerator
+
+	// these variables keep track of the current state of the iterator
+	dataIterators []metrics.DataIterator
+	exhausted     bool
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *ArithmeticDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	if di.exhausted || len(di.arithmeticDataGenerator.dataGenerators) == 0 {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	if di.dataIterators == nil {
+		for _, dataGenerator := range di.arithmeticDataGenerator.dataGenerators {
+			di.dataIterators = append(di.dataIterators, dataGenerator.Iterator())
+		}
+	}
+
+	// All children are evaluated on every scrape, even if one of them is missing, so they all stay in lockstep.
+	results := make([]metrics.ScrapeResult, len(di.dataIterators))
+	for i, dataIterator := range di.dataIterators {
+		results[i] = dataIterator.Evaluate(scrapeInfo)
+	}
+
+	missing := false
+	stale := false
+	for _, result := range results {
+		if result.Exhausted {
+			di.exhausted = true
+			return metrics.ScrapeResult{Exhausted: true}
+		}
+
+		if result.Missing {
+			missing = true
+		}
+
+		if result.Stale {
+			stale = true
+		}
+	}
+
+	if missing {
+		return metrics.ScrapeResult{Missing: true}
+	}
+
+	if stale {
+		return metrics.ScrapeResult{Stale: true}
+	}
+
+	value := results[0].Value
+	for _, result := range results[1:] {
+		switch di.arithmeticDataGenerator.operationType {
+		case ArithmeticOperationTypeAdd:
+			value += result.Value
+		case ArithmeticOperationTypeSubtract:
+			value -= result.Value
+		case ArithmeticOperationTypeMultiply:
+			value *= result.Value
+		case ArithmeticOperationTypeDivide:
+			if result.Value == 0 {
+				return metrics.ScrapeResult{Missing: true}
+			}
+			value /= result.Value
+		case ArithmeticOperationTypeMin:
+			value = math.Min(value, result.Value)
+		case ArithmeticOperationTypeMax:
+			value = math.Max(value, result.Value)
+		}
+	}
+
+	return metrics.ScrapeResult{Value: value}
+}
+
+// Check at compile time whether ArithmeticDataSpec implements DataSpec interface.
+var _ DataSpec = (*ArithmeticDataSpec)(nil)
+
+// ArithmeticDataSpec implements a generic DataSpec for the ArithmeticDataGenerator container.
+type ArithmeticDataSpec struct {
+	OperationType ArithmeticOperationType
+	Children      []DataSpec
+}
+
+func (ds ArithmeticDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
+	return DataGeneratorNodeTypeArithmetic
+}
+
+func (ds ArithmeticDataSpec) DataGeneratorType() DataGeneratorType {
+	return DataGeneratorTypeArithmetic
+}
+
+func (ds ArithmeticDataSpec) Name() string {
+	switch ds.OperationType {
+	case ArithmeticOperationTypeAdd:
+		return "Add"
+	case ArithmeticOperationTypeSubtract:
+		return "Subtract"
+	case ArithmeticOperationTypeMultiply:
+		return "Multiply"
+	case ArithmeticOperationTypeDivide:
+		return "Divide"
+	case ArithmeticOperationTypeMin:
+		return "Min"
+	case ArithmeticOperationTypeMax:
+		return "Max"
+	default:
+		return "Arithmetic"
+	}
+}
@@ -0,0 +1,121 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestArithmeticDataIterator(t *testing.T) {
+	t.Run("should not return any sample when no data generators are provided", func(t *testing.T) {
+		dataGenerator := discrete.NewAddDataGenerator(nil)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 0, len(results))
+	})
+
+	t.Run("should produce valid results for each operation", func(t *testing.T) {
+		newDataGenerators := func() []discrete.DataGenerator {
+			return []discrete.DataGenerator{
+				discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 12}, {Value: 3}}),
+				discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 2}, {Value: 6}}),
+				discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 3}, {Value: 1}}),
+			}
+		}
+
+		testCases := map[string]struct {
+			dataGenerator  discrete.DataGenerator
+			expectedValues []float64
+		}{
+			"add":      {discrete.NewAddDataGenerator(newDataGenerators()), []float64{17, 10}},
+			"subtract": {discrete.NewSubtractDataGenerator(newDataGenerators()), []float64{7, -4}},
+			"multiply": {discrete.NewMultiplyDataGenerator(newDataGenerators()), []float64{72, 18}},
+			"divide":   {discrete.NewDivideDataGenerator(newDataGenerators()), []float64{2, 0.5}},
+			"min":      {discrete.NewMinDataGenerator(newDataGenerators()), []float64{2, 1}},
+			"max":      {discrete.NewMaxDataGenerator(newDataGenerators()), []float64{12, 6}},
+		}
+
+		for name, testCase := range testCases {
+			t.Run(name, func(t *testing.T) {
+				results := helperScraper(t, testCase.dataGenerator.Iterator())
+
+				require.Equal(t, len(testCase.expectedValues), len(results))
+				for i, value := range testCase.expectedValues {
+					assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+				}
+			})
+		}
+	})
+
+	t.Run("should stop producing samples once the shortest data generator is exhausted", func(t *testing.T) {
+		lsDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      10,
+			AmplitudeEnd:        100,
+			IterationCountLimit: 10,
+		})
+		require.NoError(t, err)
+
+		dataGenerator := discrete.NewAddDataGenerator([]discrete.DataGenerator{
+			lsDataGenerator,
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Value: 2}, {Value: 3}}),
+		})
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results))
+		assert.InDelta(t, 11, results[0].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 22, results[1].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 33, results[2].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should return missing samples when any of the data generators returns a missing sample", func(t *testing.T) {
+		dataGenerator := discrete.NewMultiplyDataGenerator([]discrete.DataGenerator{
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Missing: true}, {Value: 3}}),
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Missing: true}, {Value: 2}, {Value: 3}}),
+		})
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results))
+		assert.True(t, results[0].scrapeResult.Missing)
+		assert.True(t, results[1].scrapeResult.Missing)
+		assert.False(t, results[2].scrapeResult.Missing)
+		assert.InDelta(t, 9, results[2].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should return missing samples when dividing by zero", func(t *testing.T) {
+		dataGenerator := discrete.NewDivideDataGenerator([]discrete.DataGenerator{
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 5}, {Value: 0}, {Value: 8}}),
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 0}, {Value: 4}, {Value: 4}}),
+		})
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results))
+		assert.True(t, results[0].scrapeResult.Missing)
+		assert.False(t, results[1].scrapeResult.Missing)
+		assert.InDelta(t, 0, results[1].scrapeResult.Value, 0.001)
+		assert.False(t, results[2].scrapeResult.Missing)
+		assert.InDelta(t, 2, results[2].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should describe the tree of data generators", func(t *testing.T) {
+		dataGenerator := discrete.NewAddDataGenerator([]discrete.DataGenerator{
+			discrete.NewVoidSegmentDataGenerator(2),
+			discrete.NewMaxDataGenerator([]discrete.DataGenerator{
+				discrete.NewVoidSegmentDataGenerator(2),
+				discrete.NewVoidSegmentDataGenerator(2),
+			}),
+		})
+
+		dataSpec := dataGenerator.Describe()
+		assert.Equal(t, discrete.DataGeneratorNodeTypeArithmetic, dataSpec.DataGeneratorNodeType())
+
+		expected := "Add\n  Void\n  Max\n    Void\n    Void"
+		assert.Equal(t, expected, discrete.Describe(dataSpec))
+	})
+}
@@ -0,0 +1,219 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// CounterDataGeneratorOptions contains the options for the CounterDataGenerator.
+type CounterDataGeneratorOptions struct {
+	// InitialValue represents the value of the counter before the first increment is applied.
+	InitialValue float64 `json:"initial_value" yaml:"initial_value"`
+
+	// ResetIterations contains the iterations at which the counter resets to zero.
+	// Iterations are counted from the first sample returned by the counter, starting at zero.
+	ResetIterations []int `json:"reset_iterations,omitempty" yaml:"reset_iterations,omitempty"`
+
+	// ResetEvery resets the counter to zero every N iterations.
+	// A value of zero disables periodic resets.
+	ResetEvery int `json:"reset_every" yaml:"reset_every"`
+
+	// ResetProbability represents the probability, in the range [0,1], of the counter resetting to zero on any given
+	// iteration.
+	// A value of zero disables probabilistic resets.
+	ResetProbability float64 `json:"reset_probability" yaml:"reset_probability"`
+
+	// Seed seeds the source of randomness used for probabilistic resets, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o *CounterDataGeneratorOptions) validate() error {
+	if o.InitialValue < 0 {
+		return fmt.Errorf("initial value cannot be less than zero")
+	}
+
+	for _, resetIteration := range o.ResetIterations {
+		if resetIteration < 0 {
+			return fmt.Errorf("reset iterations cannot be less than zero")
+		}
+	}
+
+	if o.ResetEvery < 0 {
+		return fmt.Errorf("reset every cannot be less than zero")
+	}
+
+	if o.ResetProbability < 0 || o.ResetProbability > 1 || math.IsNaN(o.ResetProbability) {
+		return fmt.Errorf("reset probability must be in the range [0,1]")
+	}
+
+	return nil
+}
+
+// Check at compile time whether CounterDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*CounterDataGenerator)(nil)
+
+// CounterDataGenerator returns a DataGenerator representing a monotonic counter.
+// On each scrape, the increment DataGenerator is evaluated and its value is added to the counter.
+// The counter is guaranteed to never decrease other than when it resets. Negative increments are ignored.
+// The counter resets to zero at the iterations specified in the options, in which case the sample returned is zero and
+// the increment for that iteration is discarded. This can be used to exercise the reset handling of the rate() and
+// increase() functions.
+// If the increment DataGenerator returns a missing (or stale) sample, the counter also returns a missing (or stale)
+// sample and its value remains unchanged.
+// The counter is exhausted when the increment DataGenerator is exhausted.
+// The zero value is not useful.
+type CounterDataGenerator struct {
+	incrementDataGenerator DataGenerator
+	options                CounterDataGeneratorOptions
+
+	// resetIterations is a set built from the ResetIterations option.
+	resetIterations map[int]struct{}
+}
+
+// NewCounterDataGenerator returns a new instance of CounterDataGenerator.
+func NewCounterDataGenerator(incrementDataGenerator DataGenerator, options CounterDataGeneratorOptions) (*CounterDataGenerator, error) {
+	if incrementDataGenerator == nil {
+		return &CounterDataGenerator{}, fmt.Errorf("increment data generator cannot be nil")
+	}
+
+	if err := options.validate(); err != nil {
+		return &CounterDataGenerator{}, fmt.Errorf("error validating counter data generator configuration: %w", err)
+	}
+
+	resetIterations := make(map[int]struct{})
+	for _, resetIteration := range options.ResetIterations {
+		resetIterations[resetIteration] = struct{}{}
+	}
+
+	return &CounterDataGenerator{
+		incrementDataGenerator: incrementDataGenerator,
+		options:                options,
+		resetIterations:        resetIterations,
+	}, nil
+}
+
+func (dg *CounterDataGenerator) Iterator() metrics.DataIterator {
+	return &CounterDataIterator{
+		counterDataGenerator: *dg,
+		rand:                 random.NewRand(dg.options.Seed),
+		value:                dg.options.InitialValue,
+	}
+}
+
+// WithSeed returns a copy of the CounterDataGenerator seeded with the seed provided.
+// The increment DataGenerator is seeded with a seed derived from the seed provided.
+// A seed of zero returns the CounterDataGenerator unchanged, so the increment DataGenerator keeps its own seed.
+func (dg *CounterDataGenerator) WithSeed(seed int64) DataGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	options := dg.options
+	options.Seed = seed
+
+	return &CounterDataGenerator{
+		incrementDataGenerator: WithSeed(dg.incrementDataGenerator, DeriveSeed(seed, 0)),
+		options:                options,
+		resetIterations:        dg.resetIterations,
+	}
+}
+
+func (dg *CounterDataGenerator) Describe() DataSpec {
+	return CounterDataSpec{
+		Options:   dg.options,
+		Increment: dg.incrementDataGenerator.Describe(),
+	}
+}
+
+// Check at compile time whether CounterDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*CounterDataIterator)(nil)
+
+type CounterDataIterator struct {
+	// read-only access
+	counterDataGenerator CounterDataGenerator
+
+	// these variables keep track of the current state of the iterator
+	incrementDataIterator metrics.DataIterator
+	rand                  *rand.Rand
+	iterIndex             int
+	value                 float64
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *CounterDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	if di.incrementDataIterator == nil {
+		di.incrementDataIterator = di.counterDataGenerator.incrementDataGenerator.Iterator()
+	}
+
+	increment := di.incrementDataIterator.Evaluate(scrapeInfo)
+	if increment.Exhausted {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	// Make sure to increment the iterator index before leaving the function
+	defer func() { di.iterIndex++ }()
+
+	if di.shouldReset() {
+		di.value = 0
+		return metrics.ScrapeResult{Value: di.value}
+	}
+
+	if increment.Missing {
+		return metrics.ScrapeResult{Missing: true}
+	}
+
+	if increment.Stale {
+		return metrics.ScrapeResult{Stale: true}
+	}
+
+	if increment.Value > 0 {
+		di.value += increment.Value
+	}
+
+	return metrics.ScrapeResult{Value: di.value}
+}
+
+// shouldReset reports whether the counter should reset on the current iteration.
+func (di *CounterDataIterator) shouldReset() bool {
+	options := di.counterDataGenerator.options
+
+	if _, ok := di.counterDataGenerator.resetIterations[di.iterIndex]; ok {
+		return true
+	}
+
+	if options.ResetEvery > 0 && di.iterIndex > 0 && di.iterIndex%options.ResetEvery == 0 {
+		return true
+	}
+
+	if options.ResetProbability > 0 && di.rand.Float64() < options.ResetProbability {
+		return true
+	}
+
+	return false
+}
+
+// Check at compile time whether CounterDataSpec implements DataSpec interface.
+var _ DataSpec = (*CounterDataSpec)(nil)
+
+// CounterDataSpec implements a generic DataSpec for the CounterDataGenerator container.
+type CounterDataSpec struct {
+	Options   CounterDataGeneratorOptions
+	Increment DataSpec
+}
+
+func (ds CounterDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
+	return DataGeneratorNodeTypeCounter
+}
+
+func (ds CounterDataSpec) DataGeneratorType() DataGeneratorType {
+	return DataGeneratorTypeCounter
+}
+
+func (ds CounterDataSpec) Name() string {
+	return "Counter"
+}
@@ -0,0 +1,127 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestCounterDataIterator(t *testing.T) {
+	t.Run("should fail given that the increment data generator is nil", func(t *testing.T) {
+		_, err := discrete.NewCounterDataGenerator(nil, discrete.CounterDataGeneratorOptions{})
+		require.Error(t, err)
+		assert.Equal(t, "increment data generator cannot be nil", err.Error())
+	})
+
+	t.Run("should fail given that the initial value is negative", func(t *testing.T) {
+		_, err := discrete.NewCounterDataGenerator(
+			discrete.NewVoidSegmentDataGenerator(5),
+			discrete.CounterDataGeneratorOptions{InitialValue: -1},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating counter data generator configuration: initial value cannot be less than zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given that the reset probability is out of range", func(t *testing.T) {
+		_, err := discrete.NewCounterDataGenerator(
+			discrete.NewVoidSegmentDataGenerator(5),
+			discrete.CounterDataGeneratorOptions{ResetProbability: 1.5},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating counter data generator configuration: reset probability must be in the range [0,1]"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+
+		_, err = discrete.NewCounterDataGenerator(
+			discrete.NewVoidSegmentDataGenerator(5),
+			discrete.CounterDataGeneratorOptions{ResetProbability: math.NaN()},
+		)
+		require.Error(t, err)
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should accumulate increments and ignore negative increments", func(t *testing.T) {
+		incrementDataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 5}, {Value: 10}, {Value: -20}, {Missing: true}, {Value: 0}, {Value: 2.5},
+		})
+
+		dataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{
+			InitialValue: 100,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 6, len(results))
+		assert.InDelta(t, 105, results[0].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 115, results[1].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 115, results[2].scrapeResult.Value, 0.001)
+		assert.True(t, results[3].scrapeResult.Missing)
+		assert.InDelta(t, 115, results[4].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 117.5, results[5].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should reset the counter at the scheduled iterations", func(t *testing.T) {
+		incrementDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      10,
+			AmplitudeEnd:        10,
+			IterationCountLimit: 8,
+		})
+		require.NoError(t, err)
+
+		dataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{
+			ResetIterations: []int{2},
+			ResetEvery:      5,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 8, len(results))
+		expected := []float64{10, 20, 0, 10, 20, 0, 10, 20}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should reset the counter on every iteration given a reset probability of one", func(t *testing.T) {
+		incrementDataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 5}, {Value: 10}, {Value: 15},
+		})
+
+		dataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{
+			ResetProbability: 1,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results))
+		for _, result := range results {
+			assert.InDelta(t, 0, result.scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should restart from the initial value for each new iterator", func(t *testing.T) {
+		incrementDataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Value: 1}})
+
+		dataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{
+			InitialValue: 10,
+		})
+		require.NoError(t, err)
+
+		loopDataGenerator := discrete.NewLoopDataGenerator(dataGenerator, 2)
+
+		results := helperScraper(t, loopDataGenerator.Iterator())
+
+		require.Equal(t, 4, len(results))
+		expected := []float64{11, 12, 11, 12}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+}
@@ -30,9 +30,7 @@ func (dg *CustomValuesSegmentDataGenerator) Iterator() metrics.DataIterator {
 }
 
 func (dg *CustomValuesSegmentDataGenerator) Describe() DataSpec {
-	return DataNodeDataSpec{
-		name: "Custom Values",
-	}
+	return NewDataNodeDataSpec(DataGeneratorTypeCustomValuesSegment, "Custom Values", dg.values)
 }
 
 // Check at compile time whether CustomValuesSegmentDataIterator implements DataIterator interface.
@@ -61,15 +59,19 @@ func (di *CustomValuesSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInf
 	return metrics.ScrapeResult{
 		Value:   result.Value,
 		Missing: result.Missing,
+		Stale:   result.Stale,
 	}
 }
 
 // CustomValueSample contains the scrape value to be returned by CustomValuesSegmentDataIterator.
 type CustomValueSample struct {
 	// Value is the value of the sample.
-	Value float64
+	Value float64 `json:"value" yaml:"value"`
 
 	// Missing indicates whether the scrape failed to retrieve a sample.
 	// Used to simulate failed scrapes.
-	Missing bool
+	Missing bool `json:"missing,omitempty" yaml:"missing,omitempty"`
+
+	// Stale indicates whether a stale marker is sent instead of a sample.
+	Stale bool `json:"stale,omitempty" yaml:"stale,omitempty"`
 }
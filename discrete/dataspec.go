@@ -0,0 +1,346 @@
+package discrete
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dataSpecDocument is the serialized representation of a DataSpec.
+// The Type field is the discriminator which determines what other fields are relevant:
+//   - data nodes only use the Options.
+//   - Join uses the Children.
+//   - Loop uses the Count, the Seed and the Func.
+//   - Arithmetic uses the OperationType and the Children.
+//   - Counter uses the Options and the Increment.
+//   - containers defined outside this package use the Options and the Children.
+//
+// R is the type used to hold the options, which is the options value itself when marshalling, and the raw serialized
+// options when unmarshalling, since the type of the options is only known once the Type field has been decoded.
+type dataSpecDocument[R any] struct {
+	Type          DataGeneratorType       `json:"type" yaml:"type"`
+	Options       R                       `json:"options,omitempty" yaml:"options,omitempty"`
+	OperationType ArithmeticOperationType `json:"operation_type,omitempty" yaml:"operation_type,omitempty"`
+	Count         int                     `json:"count,omitempty" yaml:"count,omitempty"`
+	Seed          int64                   `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Func          *dataSpecDocument[R]    `json:"func,omitempty" yaml:"func,omitempty"`
+	Increment     *dataSpecDocument[R]    `json:"increment,omitempty" yaml:"increment,omitempty"`
+	Children      []dataSpecDocument[R]   `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// MarshalDataSpecJSON returns the JSON encoding of the DataSpec provided.
+// The DataSpec can be decoded back with Registry.UnmarshalDataSpecJSON.
+// Note that JSON has no representation for infinities and NaN, hence options holding those values cannot be encoded.
+func MarshalDataSpecJSON(dataSpec DataSpec) ([]byte, error) {
+	document, err := newDataSpecDocument(dataSpec)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling data spec: %w", err)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling data spec: %w", err)
+	}
+
+	return data, nil
+}
+
+// MarshalDataSpecYAML returns the YAML encoding of the DataSpec provided.
+// The DataSpec can be decoded back with Registry.UnmarshalDataSpecYAML.
+func MarshalDataSpecYAML(dataSpec DataSpec) ([]byte, error) {
+	document, err := newDataSpecDocument(dataSpec)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling data spec: %w", err)
+	}
+
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling data spec: %w", err)
+	}
+
+	return data, nil
+}
+
+func newDataSpecDocument(dataSpec DataSpec) (dataSpecDocument[any], error) {
+	switch dataSpecConcrete := dataSpec.(type) {
+	case DataNodeDataSpec:
+		if dataSpecConcrete.DataGeneratorType() == "" {
+			return dataSpecDocument[any]{}, fmt.Errorf("data spec %q has no data generator type", dataSpecConcrete.Name())
+		}
+
+		return dataSpecDocument[any]{
+			Type:    dataSpecConcrete.DataGeneratorType(),
+			Options: dataSpecConcrete.Options(),
+		}, nil
+	case ContainerDataSpec:
+		if dataSpecConcrete.DataGeneratorType() == "" {
+			return dataSpecDocument[any]{}, fmt.Errorf("data spec %q has no data generator type", dataSpecConcrete.Name())
+		}
+
+		children, err := newDataSpecDocuments(dataSpecConcrete.Children())
+		if err != nil {
+			return dataSpecDocument[any]{}, err
+		}
+
+		return dataSpecDocument[any]{
+			Type:     dataSpecConcrete.DataGeneratorType(),
+			Options:  dataSpecConcrete.Options(),
+			Children: children,
+		}, nil
+	case JoinDataSpec:
+		children, err := newDataSpecDocuments(dataSpecConcrete.Children)
+		if err != nil {
+			return dataSpecDocument[any]{}, err
+		}
+
+		return dataSpecDocument[any]{
+			Type:     DataGeneratorTypeJoin,
+			Children: children,
+		}, nil
+	case LoopDataSpec:
+		funcDocument, err := newDataSpecDocument(dataSpecConcrete.Func)
+		if err != nil {
+			return dataSpecDocument[any]{}, err
+		}
+
+		return dataSpecDocument[any]{
+			Type:  DataGeneratorTypeLoop,
+			Count: dataSpecConcrete.Count,
+			Seed:  dataSpecConcrete.Seed,
+			Func:  &funcDocument,
+		}, nil
+	case ArithmeticDataSpec:
+		children, err := newDataSpecDocuments(dataSpecConcrete.Children)
+		if err != nil {
+			return dataSpecDocument[any]{}, err
+		}
+
+		return dataSpecDocument[any]{
+			Type:          DataGeneratorTypeArithmetic,
+			OperationType: dataSpecConcrete.OperationType,
+			Children:      children,
+		}, nil
+	case CounterDataSpec:
+		incrementDocument, err := newDataSpecDocument(dataSpecConcrete.Increment)
+		if err != nil {
+			return dataSpecDocument[any]{}, err
+		}
+
+		return dataSpecDocument[any]{
+			Type:      DataGeneratorTypeCounter,
+			Options:   dataSpecConcrete.Options,
+			Increment: &incrementDocument,
+		}, nil
+	default:
+		return dataSpecDocument[any]{}, fmt.Errorf("unknown data spec %T", dataSpec)
+	}
+}
+
+func newDataSpecDocuments(dataSpecs []DataSpec) ([]dataSpecDocument[any], error) {
+	documents := make([]dataSpecDocument[any], 0, len(dataSpecs))
+
+	for _, dataSpec := range dataSpecs {
+		document, err := newDataSpecDocument(dataSpec)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+// UnmarshalDataSpecJSON decodes a DataSpec encoded in JSON by MarshalDataSpecJSON.
+// The options of data nodes are decoded according to the builders registered in the Registry, and validated by
+// building the data nodes. Unknown fields are rejected.
+func (r *Registry) UnmarshalDataSpecJSON(data []byte) (DataSpec, error) {
+	var document dataSpecDocument[json.RawMessage]
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error unmarshalling data spec: %w", err)
+	}
+
+	dataSpec, err := newDataSpecFromDocument(r, document, decodeJSONOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling data spec: %w", err)
+	}
+
+	return dataSpec, nil
+}
+
+// UnmarshalDataSpecYAML decodes a DataSpec encoded in YAML by MarshalDataSpecYAML.
+// The options of data nodes are decoded according to the builders registered in the Registry, and validated by
+// building the data nodes. Unknown fields are rejected.
+func (r *Registry) UnmarshalDataSpecYAML(data []byte) (DataSpec, error) {
+	var document dataSpecDocument[yaml.Node]
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error unmarshalling data spec: %w", err)
+	}
+
+	dataSpec, err := newDataSpecFromDocument(r, document, decodeYAMLOptions)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling data spec: %w", err)
+	}
+
+	return dataSpec, nil
+}
+
+func decodeJSONOptions(options json.RawMessage, target any) error {
+	// Options left out are treated as the zero value.
+	if len(options) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(options))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+func decodeYAMLOptions(options yaml.Node, target any) error {
+	// Options left out are treated as the zero value.
+	if options.Kind == 0 {
+		return nil
+	}
+
+	// yaml.Node.Decode doesn't reject unknown fields, so the options are encoded again and decoded with a decoder
+	// that does.
+	data, err := yaml.Marshal(&options)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(target)
+}
+
+func newDataSpecFromDocument[R any](registry *Registry, document dataSpecDocument[R], decode func(options R, target any) error) (DataSpec, error) {
+	decodeOptions := func(target any) error {
+		if err := decode(document.Options, target); err != nil {
+			return fmt.Errorf("error decoding options for data generator type %q: %w", document.Type, err)
+		}
+		return nil
+	}
+
+	switch document.Type {
+	case DataGeneratorTypeJoin:
+		children, err := newDataSpecsFromDocuments(registry, document.Children, decode)
+		if err != nil {
+			return nil, err
+		}
+
+		return JoinDataSpec{
+			Children: children,
+		}, nil
+	case DataGeneratorTypeLoop:
+		if document.Func == nil {
+			return nil, fmt.Errorf("loop data spec is missing the data spec to loop over")
+		}
+
+		funcDataSpec, err := newDataSpecFromDocument(registry, *document.Func, decode)
+		if err != nil {
+			return nil, err
+		}
+
+		return LoopDataSpec{
+			Count: document.Count,
+			Func:  funcDataSpec,
+			Seed:  document.Seed,
+		}, nil
+	case DataGeneratorTypeArithmetic:
+		children, err := newDataSpecsFromDocuments(registry, document.Children, decode)
+		if err != nil {
+			return nil, err
+		}
+
+		return ArithmeticDataSpec{
+			OperationType: document.OperationType,
+			Children:      children,
+		}, nil
+	case DataGeneratorTypeCounter:
+		if document.Increment == nil {
+			return nil, fmt.Errorf("counter data spec is missing the increment data spec")
+		}
+
+		var options CounterDataGeneratorOptions
+		if err := decodeOptions(&options); err != nil {
+			return nil, err
+		}
+
+		incrementDataSpec, err := newDataSpecFromDocument(registry, *document.Increment, decode)
+		if err != nil {
+			return nil, err
+		}
+
+		return CounterDataSpec{
+			Options:   options,
+			Increment: incrementDataSpec,
+		}, nil
+	default:
+		if containerBuilder, ok := registry.containerBuilders[document.Type]; ok {
+			options, err := containerBuilder.DecodeOptions(decodeOptions)
+			if err != nil {
+				return nil, err
+			}
+
+			children, err := newDataSpecsFromDocuments(registry, document.Children, decode)
+			if err != nil {
+				return nil, err
+			}
+
+			childrenDataGenerators, err := registry.buildAll(children)
+			if err != nil {
+				return nil, err
+			}
+
+			// Just like data nodes, building the container validates the options and provides the DataSpec as
+			// described by the container itself.
+			dataGenerator, err := containerBuilder.Build(options, childrenDataGenerators)
+			if err != nil {
+				return nil, fmt.Errorf("error validating options for data generator type %q: %w", document.Type, err)
+			}
+
+			return dataGenerator.Describe(), nil
+		}
+
+		builder, ok := registry.dataNodeBuilders[document.Type]
+		if !ok {
+			return nil, fmt.Errorf("data generator type %q is not registered", document.Type)
+		}
+
+		options, err := builder.DecodeOptions(decodeOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		// Building the data node validates the options and provides the DataSpec as described by the data
+		// generator itself.
+		dataGenerator, err := builder.Build(options)
+		if err != nil {
+			return nil, fmt.Errorf("error validating options for data generator type %q: %w", document.Type, err)
+		}
+
+		return dataGenerator.Describe(), nil
+	}
+}
+
+func newDataSpecsFromDocuments[R any](registry *Registry, documents []dataSpecDocument[R], decode func(options R, target any) error) ([]DataSpec, error) {
+	dataSpecs := make([]DataSpec, 0, len(documents))
+
+	for _, document := range documents {
+		dataSpec, err := newDataSpecFromDocument(registry, document, decode)
+		if err != nil {
+			return nil, err
+		}
+		dataSpecs = append(dataSpecs, dataSpec)
+	}
+
+	return dataSpecs, nil
+}
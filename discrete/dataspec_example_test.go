@@ -38,3 +38,27 @@ func ExampleDataSpec() {
 	//   Loop [3]
 	//     Linear Segment
 }
+
+func ExampleDescribeWithOptions() {
+	lsDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+		AmplitudeStart:      50,
+		AmplitudeEnd:        70,
+		IterationCountLimit: 5,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	dataGenerator := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+		lsDataGenerator,
+		discrete.NewLoopDataGenerator(discrete.NewVoidSegmentDataGenerator(2), 3),
+	})
+
+	result := discrete.DescribeWithOptions(dataGenerator.Describe())
+	fmt.Println(result)
+	// Output:
+	// Join
+	//   Linear Segment {AmplitudeStart:50 AmplitudeEnd:70 IterationCountLimit:5}
+	//   Loop [3]
+	//     Void 2
+}
@@ -0,0 +1,211 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+// helperDataGeneratorTree returns a seeded tree making use of all the container data generators.
+func helperDataGeneratorTree(t *testing.T) discrete.DataGenerator {
+	t.Helper()
+
+	lsDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+		AmplitudeStart:      10,
+		AmplitudeEnd:        20,
+		IterationCountLimit: 5,
+	})
+	require.NoError(t, err)
+
+	normalDataGenerator, err := discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+		Mean:                100,
+		StdDev:              10,
+		Clamp:               &discrete.ClampBounds{Min: 90, Max: 110},
+		IterationCountLimit: 3,
+	})
+	require.NoError(t, err)
+
+	sineDataGenerator, err := discrete.NewSineSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+		Amplitude:           5,
+		Offset:              10,
+		Period:              4,
+		IterationCountLimit: 4,
+	})
+	require.NoError(t, err)
+
+	poissonDataGenerator, err := discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+		Lambda:              3,
+		IterationCountLimit: 6,
+	})
+	require.NoError(t, err)
+
+	counterDataGenerator, err := discrete.NewCounterDataGenerator(poissonDataGenerator, discrete.CounterDataGeneratorOptions{
+		InitialValue:    5,
+		ResetIterations: []int{3},
+	})
+	require.NoError(t, err)
+
+	dataGenerator := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+		lsDataGenerator,
+		discrete.NewVoidSegmentDataGenerator(2),
+		discrete.NewLoopDataGenerator(normalDataGenerator, 2),
+		discrete.NewAddDataGenerator([]discrete.DataGenerator{
+			sineDataGenerator,
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Missing: true}, {Value: 3}}),
+		}),
+		counterDataGenerator,
+	})
+
+	return discrete.WithSeed(dataGenerator, 42)
+}
+
+func TestMarshalDataSpec(t *testing.T) {
+	t.Run("should encode the options and the type discriminator in JSON", func(t *testing.T) {
+		dataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      1,
+			AmplitudeEnd:        5,
+			IterationCountLimit: 3,
+		})
+		require.NoError(t, err)
+
+		data, err := discrete.MarshalDataSpecJSON(discrete.NewLoopDataGenerator(dataGenerator, 2).Describe())
+		require.NoError(t, err)
+
+		expected := `{"type":"data_generator_type-loop","count":2,"func":{"type":"data_generator_type-linear_segment",` +
+			`"options":{"amplitude_start":1,"amplitude_end":5,"iteration_count_limit":3}}}`
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("should encode the options and the type discriminator in YAML", func(t *testing.T) {
+		data, err := discrete.MarshalDataSpecYAML(discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			discrete.NewVoidSegmentDataGenerator(3),
+		}).Describe())
+		require.NoError(t, err)
+
+		expected := "type: data_generator_type-join\n" +
+			"children:\n" +
+			"    - type: data_generator_type-void_segment\n" +
+			"      options: 3\n"
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("should only encode the flags of the custom values that are set", func(t *testing.T) {
+		data, err := discrete.MarshalDataSpecJSON(discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 1}, {Missing: true}, {Stale: true},
+		}).Describe())
+		require.NoError(t, err)
+
+		expected := `{"type":"data_generator_type-custom_values_segment",` +
+			`"options":[{"value":1},{"value":0,"missing":true},{"value":0,"stale":true}]}`
+		assert.Equal(t, expected, string(data))
+	})
+
+	t.Run("should fail given a data spec without data generator type", func(t *testing.T) {
+		dataSpec := discrete.NewDataNodeDataSpec("", "Unknown", nil)
+
+		_, err := discrete.MarshalDataSpecJSON(dataSpec)
+		require.Error(t, err)
+		expectedErrorMessage := `error marshalling data spec: data spec "Unknown" has no data generator type`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
+
+func TestUnmarshalDataSpec(t *testing.T) {
+	registry := discrete.NewRegistry()
+
+	t.Run("should round trip the data spec through JSON and YAML", func(t *testing.T) {
+		dataGenerator := helperDataGeneratorTree(t)
+		expectedResults := helperScraper(t, dataGenerator.Iterator())
+
+		testCases := map[string]struct {
+			marshal   func(discrete.DataSpec) ([]byte, error)
+			unmarshal func([]byte) (discrete.DataSpec, error)
+		}{
+			"json": {marshal: discrete.MarshalDataSpecJSON, unmarshal: registry.UnmarshalDataSpecJSON},
+			"yaml": {marshal: discrete.MarshalDataSpecYAML, unmarshal: registry.UnmarshalDataSpecYAML},
+		}
+
+		for name, testCase := range testCases {
+			t.Run(name, func(t *testing.T) {
+				data, err := testCase.marshal(dataGenerator.Describe())
+				require.NoError(t, err)
+
+				dataSpec, err := testCase.unmarshal(data)
+				require.NoError(t, err)
+				assert.Equal(t, discrete.Describe(dataGenerator.Describe()), discrete.Describe(dataSpec))
+
+				rebuiltDataGenerator, err := registry.Build(dataSpec)
+				require.NoError(t, err)
+
+				results := helperScraper(t, rebuiltDataGenerator.Iterator())
+				require.Equal(t, len(expectedResults), len(results))
+				for i := range expectedResults {
+					assert.Equal(t, expectedResults[i].scrapeResult, results[i].scrapeResult)
+				}
+			})
+		}
+	})
+
+	t.Run("should decode a data spec written by hand", func(t *testing.T) {
+		data := []byte(`
+type: data_generator_type-arithmetic
+operation_type: arithmetic_operation_type-multiply
+children:
+  - type: data_generator_type-linear_segment
+    options:
+      amplitude_start: 1
+      amplitude_end: 3
+      iteration_count_limit: 3
+  - type: data_generator_type-custom_values_segment
+    options:
+      - value: 10
+      - missing: true
+      - value: 10
+`)
+
+		dataSpec, err := registry.UnmarshalDataSpecYAML(data)
+		require.NoError(t, err)
+
+		dataGenerator, err := registry.Build(dataSpec)
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results))
+		assert.InDelta(t, 10, results[0].scrapeResult.Value, 0.001)
+		assert.True(t, results[1].scrapeResult.Missing)
+		assert.InDelta(t, 30, results[2].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should fail given an unknown data generator type", func(t *testing.T) {
+		_, err := registry.UnmarshalDataSpecJSON([]byte(`{"type":"data_generator_type-unknown"}`))
+		require.Error(t, err)
+		expectedErrorMessage := `error unmarshalling data spec: data generator type "data_generator_type-unknown" is not registered`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given unknown options", func(t *testing.T) {
+		_, err := registry.UnmarshalDataSpecYAML([]byte(`
+type: data_generator_type-random_segment
+options:
+  amplitude_min: 1
+  amplitude_maximum: 3
+  iteration_count_limit: 3
+`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `error decoding options for data generator type "data_generator_type-random_segment"`)
+		assert.Contains(t, err.Error(), "amplitude_maximum")
+	})
+
+	t.Run("should fail given invalid options", func(t *testing.T) {
+		_, err := registry.UnmarshalDataSpecJSON([]byte(`{"type":"data_generator_type-sine_segment","options":{"period":0}}`))
+		require.Error(t, err)
+		expectedErrorMessage := `error unmarshalling data spec: error validating options for data generator type ` +
+			`"data_generator_type-sine_segment": error validating periodic segment data generator configuration: ` +
+			`period cannot be less than or equal to zero`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
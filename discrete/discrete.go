@@ -9,67 +9,198 @@ import (
 
 // DataGenerator generates data according to the generator.
 // It's meant to be used by Counter and Gauge metrics.
-type DataGenerator interface {
-	Iterator() metrics.DataIterator
-	Describe() DataSpec
-}
+// It's the metrics.DataGenerator, which continuous data generators comply with as well, so they can be mixed with
+// discrete data generators in the same tree.
+type DataGenerator = metrics.DataGenerator
 
 // DataSpec defines the data node type.
 // It's necessary to type assert to the type returned by the DataGeneratorNodeType method.
-type DataSpec interface {
-	DataGeneratorNodeType() DataGeneratorNodeType
-	Name() string
-}
+type DataSpec = metrics.DataSpec
 
-type DataGeneratorNodeType string
+type DataGeneratorNodeType = metrics.DataGeneratorNodeType
 
 const (
-	DataGeneratorNodeTypeData DataGeneratorNodeType = "data_generator_node_type-data"
-	DataGeneratorNodeTypeJoin DataGeneratorNodeType = "data_generator_node_type-join"
-	DataGeneratorNodeTypeLoop DataGeneratorNodeType = "data_generator_node_type-loop"
+	DataGeneratorNodeTypeData       DataGeneratorNodeType = metrics.DataGeneratorNodeTypeData
+	DataGeneratorNodeTypeJoin       DataGeneratorNodeType = "data_generator_node_type-join"
+	DataGeneratorNodeTypeLoop       DataGeneratorNodeType = "data_generator_node_type-loop"
+	DataGeneratorNodeTypeArithmetic DataGeneratorNodeType = "data_generator_node_type-arithmetic"
+	DataGeneratorNodeTypeCounter    DataGeneratorNodeType = "data_generator_node_type-counter"
+	DataGeneratorNodeTypeContainer  DataGeneratorNodeType = metrics.DataGeneratorNodeTypeContainer
 )
 
-// Check at compile time whether DataNodeDataSpec implements DataSpec interface.
-var _ DataSpec = (*DataNodeDataSpec)(nil)
+// DataGeneratorType identifies a concrete data generator.
+// It's used as the type discriminator when serializing a DataSpec.
+type DataGeneratorType = metrics.DataGeneratorType
+
+const (
+	DataGeneratorTypeLinearSegment        DataGeneratorType = "data_generator_type-linear_segment"
+	DataGeneratorTypeCustomValuesSegment  DataGeneratorType = "data_generator_type-custom_values_segment"
+	DataGeneratorTypeRandomSegment        DataGeneratorType = "data_generator_type-random_segment"
+	DataGeneratorTypeVoidSegment          DataGeneratorType = "data_generator_type-void_segment"
+	DataGeneratorTypeSineSegment          DataGeneratorType = "data_generator_type-sine_segment"
+	DataGeneratorTypeSquareSegment        DataGeneratorType = "data_generator_type-square_segment"
+	DataGeneratorTypeTriangleSegment      DataGeneratorType = "data_generator_type-triangle_segment"
+	DataGeneratorTypeSawtoothSegment      DataGeneratorType = "data_generator_type-sawtooth_segment"
+	DataGeneratorTypeNormalSegment        DataGeneratorType = "data_generator_type-normal_segment"
+	DataGeneratorTypeLogNormalSegment     DataGeneratorType = "data_generator_type-log_normal_segment"
+	DataGeneratorTypeExponentialSegment   DataGeneratorType = "data_generator_type-exponential_segment"
+	DataGeneratorTypePoissonSegment       DataGeneratorType = "data_generator_type-poisson_segment"
+	DataGeneratorTypeParetoSegment        DataGeneratorType = "data_generator_type-pareto_segment"
+	DataGeneratorTypeRandomWalkSegment    DataGeneratorType = "data_generator_type-random_walk_segment"
+	DataGeneratorTypeMeanRevertingSegment DataGeneratorType = "data_generator_type-mean_reverting_segment"
+	DataGeneratorTypeJoin                 DataGeneratorType = "data_generator_type-join"
+	DataGeneratorTypeLoop                 DataGeneratorType = "data_generator_type-loop"
+	DataGeneratorTypeArithmetic           DataGeneratorType = "data_generator_type-arithmetic"
+	DataGeneratorTypeCounter              DataGeneratorType = "data_generator_type-counter"
+)
 
 // DataNodeDataSpec implements a generic DataSpec for data shapes.
-type DataNodeDataSpec struct {
-	name string
-}
+// It's the metrics.DataNodeDataSpec, which data generators defined outside this package use as well.
+type DataNodeDataSpec = metrics.DataNodeDataSpec
 
-func (ds DataNodeDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
-	return DataGeneratorNodeTypeData
+// NewDataNodeDataSpec returns a new instance of DataNodeDataSpec.
+// The options must hold the arguments the data generator is created with.
+func NewDataNodeDataSpec(dataGeneratorType DataGeneratorType, name string, options any) DataNodeDataSpec {
+	return metrics.NewDataNodeDataSpec(dataGeneratorType, name, options)
 }
 
-func (ds DataNodeDataSpec) Name() string {
-	return ds.name
+// ContainerDataSpec implements a generic DataSpec for containers defined outside this package, i.e., data generators
+// made up of other data generators, such as the continuous Join and Loop.
+// It's the metrics.ContainerDataSpec.
+type ContainerDataSpec = metrics.ContainerDataSpec
+
+// NewContainerDataSpec returns a new instance of ContainerDataSpec.
+// The options must hold the arguments the container is created with, other than its children. They can be nil if the
+// container doesn't take any options.
+func NewContainerDataSpec(dataGeneratorType DataGeneratorType, name string, options any, children []DataSpec) ContainerDataSpec {
+	return metrics.NewContainerDataSpec(dataGeneratorType, name, options, children)
 }
 
-// Describe generates the tree of all nodes.
+// Describe generates the tree of all nodes, showing only their names.
+// Use DescribeWithOptions to see the parameters the nodes were created with as well.
 func Describe(rootDataSpec DataSpec) string {
-	result := describe(rootDataSpec, 0, nil)
+	result := describe(rootDataSpec, 0, nil, false)
+	return strings.Join(result, "\n")
+}
+
+// DescribeWithOptions generates the tree of all nodes, just like Describe, along with the options of every node.
+func DescribeWithOptions(rootDataSpec DataSpec) string {
+	result := describe(rootDataSpec, 0, nil, true)
 	return strings.Join(result, "\n")
 }
 
-func describe(dataSpec DataSpec, indent int, result []string) []string {
+func describe(dataSpec DataSpec, indent int, result []string, withOptions bool) []string {
 	prefix := strings.Repeat("  ", indent)
 
+	// describeOptions appends the options to the name of the node, if requested.
+	describeOptions := func(name string, options any) string {
+		if !withOptions || options == nil {
+			return name
+		}
+		return fmt.Sprintf("%s %+v", name, options)
+	}
+
 	switch dataSpecConcrete := dataSpec.(type) {
 	case DataNodeDataSpec:
-		result = append(result, fmt.Sprintf("%s%s", prefix, dataSpecConcrete.Name()))
+		result = append(result, prefix+describeOptions(dataSpecConcrete.Name(), dataSpecConcrete.Options()))
 		return result
 	case JoinDataSpec:
 		result = append(result, fmt.Sprintf("%s%s", prefix, dataSpecConcrete.Name()))
 		for _, children := range dataSpecConcrete.Children {
-			result = describe(children, indent+1, result)
+			result = describe(children, indent+1, result, withOptions)
 		}
 		return result
+	case ArithmeticDataSpec:
+		result = append(result, fmt.Sprintf("%s%s", prefix, dataSpecConcrete.Name()))
+		for _, children := range dataSpecConcrete.Children {
+			result = describe(children, indent+1, result, withOptions)
+		}
+		return result
+	case CounterDataSpec:
+		result = append(result, prefix+describeOptions(dataSpecConcrete.Name(), dataSpecConcrete.Options))
+		result = describe(dataSpecConcrete.Increment, indent+1, result, withOptions)
+		return result
 	case LoopDataSpec:
 		result = append(result, fmt.Sprintf("%s%s [%d]", prefix, dataSpecConcrete.Name(), dataSpecConcrete.Count))
-		result = describe(dataSpecConcrete.Func, indent+1, result)
+		result = describe(dataSpecConcrete.Func, indent+1, result, withOptions)
+		return result
+	case ContainerDataSpec:
+		result = append(result, prefix+describeOptions(dataSpecConcrete.Name(), dataSpecConcrete.Options()))
+		for _, children := range dataSpecConcrete.Children() {
+			result = describe(children, indent+1, result, withOptions)
+		}
 		return result
 	default:
 		result = append(result, "||--- error")
 		return result
 	}
 }
+
+// IterationCount returns the number of iterations the data generator described by the DataSpec produces before being
+// exhausted.
+// The second return value reports whether the number of iterations could be determined, which isn't the case for data
+// nodes defined outside this package.
+func IterationCount(dataSpec DataSpec) (int, bool) {
+	switch dataSpecConcrete := dataSpec.(type) {
+	case DataNodeDataSpec:
+		return dataNodeIterationCount(dataSpecConcrete)
+	case JoinDataSpec:
+		total := 0
+		for _, child := range dataSpecConcrete.Children {
+			count, ok := IterationCount(child)
+			if !ok {
+				return 0, false
+			}
+			total += count
+		}
+		return total, true
+	case LoopDataSpec:
+		count, ok := IterationCount(dataSpecConcrete.Func)
+		if !ok {
+			return 0, false
+		}
+		return count * dataSpecConcrete.Count, true
+	case ArithmeticDataSpec:
+		// The shortest child determines when the arithmetic data generator gets exhausted.
+		shortest := 0
+		for i, child := range dataSpecConcrete.Children {
+			count, ok := IterationCount(child)
+			if !ok {
+				return 0, false
+			}
+			if i == 0 || count < shortest {
+				shortest = count
+			}
+		}
+		return shortest, true
+	case CounterDataSpec:
+		return IterationCount(dataSpecConcrete.Increment)
+	default:
+		return 0, false
+	}
+}
+
+func dataNodeIterationCount(dataSpec DataNodeDataSpec) (int, bool) {
+	switch optionsConcrete := dataSpec.Options().(type) {
+	case LinearSegmentDataGeneratorOptions:
+		return optionsConcrete.IterationCountLimit, true
+	case RandomSegmentDataGeneratorOptions:
+		return optionsConcrete.IterationCountLimit, true
+	case PeriodicSegmentDataGeneratorOptions:
+		return optionsConcrete.IterationCountLimit, true
+	case RandomWalkSegmentDataGeneratorOptions:
+		return optionsConcrete.IterationCountLimit, true
+	case MeanRevertingSegmentDataGeneratorOptions:
+		return optionsConcrete.IterationCountLimit, true
+	case distributionOptions:
+		return optionsConcrete.iterationCountLimit(), true
+	case []CustomValueSample:
+		return len(optionsConcrete), true
+	case int:
+		if dataSpec.DataGeneratorType() == DataGeneratorTypeVoidSegment {
+			return optionsConcrete, true
+		}
+	}
+
+	return 0, false
+}
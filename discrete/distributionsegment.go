@@ -0,0 +1,540 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+type DistributionType string
+
+const (
+	DistributionTypeNormal      DistributionType = "distribution_type-normal"
+	DistributionTypeLogNormal   DistributionType = "distribution_type-log_normal"
+	DistributionTypeExponential DistributionType = "distribution_type-exponential"
+	DistributionTypePoisson     DistributionType = "distribution_type-poisson"
+	DistributionTypePareto      DistributionType = "distribution_type-pareto"
+)
+
+// ClampBounds restricts values to the closed interval [Min,Max].
+// Values below Min are replaced by Min and values above Max are replaced by Max.
+type ClampBounds struct {
+	// Min represents the lower bound of the interval.
+	Min float64 `json:"min" yaml:"min"`
+
+	// Max represents the upper bound of the interval.
+	Max float64 `json:"max" yaml:"max"`
+}
+
+func (cb *ClampBounds) validate() error {
+	if !isFinite(cb.Min) || !isFinite(cb.Max) {
+		return fmt.Errorf("clamp min and clamp max must be finite numbers")
+	}
+
+	if cb.Min > cb.Max {
+		return fmt.Errorf("clamp min cannot be greater than clamp max")
+	}
+
+	return nil
+}
+
+// String returns the interval in the format [Min,Max].
+func (cb *ClampBounds) String() string {
+	if cb == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("[%g,%g]", cb.Min, cb.Max)
+}
+
+// clamp restricts the value to the bounds. A nil ClampBounds leaves the value unchanged.
+func (cb *ClampBounds) clamp(value float64) float64 {
+	if cb == nil {
+		return value
+	}
+
+	return math.Min(math.Max(value, cb.Min), cb.Max)
+}
+
+// distributionOptions is implemented by the options of every distribution supported by the
+// DistributionSegmentDataGenerator.
+type distributionOptions interface {
+	validate() error
+	sample(r *rand.Rand) float64
+	iterationCountLimit() int
+	clampBounds() *ClampBounds
+	seed() int64
+	withSeed(seed int64) distributionOptions
+}
+
+// NormalSegmentDataGeneratorOptions contains the options for a DistributionSegmentDataGenerator that samples from a
+// normal (gaussian) distribution.
+type NormalSegmentDataGeneratorOptions struct {
+	// Mean represents the mean of the distribution.
+	Mean float64 `json:"mean" yaml:"mean"`
+
+	// StdDev represents the standard deviation of the distribution.
+	StdDev float64 `json:"std_dev" yaml:"std_dev"`
+
+	// Clamp optionally restricts the values sampled to a given interval.
+	Clamp *ClampBounds `json:"clamp,omitempty" yaml:"clamp,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the samples are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o NormalSegmentDataGeneratorOptions) validate() error {
+	if !isFinite(o.Mean) {
+		return fmt.Errorf("mean must be a finite number")
+	}
+
+	if o.StdDev < 0 {
+		return fmt.Errorf("standard deviation cannot be less than zero")
+	}
+
+	if !isFinite(o.StdDev) {
+		return fmt.Errorf("standard deviation must be a finite number")
+	}
+
+	return validateDistributionCommonOptions(o.Clamp, o.IterationCountLimit)
+}
+
+func (o NormalSegmentDataGeneratorOptions) sample(r *rand.Rand) float64 {
+	return r.NormFloat64()*o.StdDev + o.Mean
+}
+
+func (o NormalSegmentDataGeneratorOptions) iterationCountLimit() int {
+	return o.IterationCountLimit
+}
+
+func (o NormalSegmentDataGeneratorOptions) clampBounds() *ClampBounds {
+	return o.Clamp
+}
+
+func (o NormalSegmentDataGeneratorOptions) seed() int64 {
+	return o.Seed
+}
+
+func (o NormalSegmentDataGeneratorOptions) withSeed(seed int64) distributionOptions {
+	o.Seed = seed
+	return o
+}
+
+// LogNormalSegmentDataGeneratorOptions contains the options for a DistributionSegmentDataGenerator that samples from a
+// log-normal distribution.
+// The natural logarithm of the values sampled is normally distributed with mean Mu and standard deviation Sigma.
+type LogNormalSegmentDataGeneratorOptions struct {
+	// Mu represents the mean of the underlying normal distribution.
+	Mu float64 `json:"mu" yaml:"mu"`
+
+	// Sigma represents the standard deviation of the underlying normal distribution.
+	Sigma float64 `json:"sigma" yaml:"sigma"`
+
+	// Clamp optionally restricts the values sampled to a given interval.
+	Clamp *ClampBounds `json:"clamp,omitempty" yaml:"clamp,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the samples are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) validate() error {
+	if !isFinite(o.Mu) {
+		return fmt.Errorf("mu must be a finite number")
+	}
+
+	if o.Sigma < 0 {
+		return fmt.Errorf("sigma cannot be less than zero")
+	}
+
+	if !isFinite(o.Sigma) {
+		return fmt.Errorf("sigma must be a finite number")
+	}
+
+	return validateDistributionCommonOptions(o.Clamp, o.IterationCountLimit)
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) sample(r *rand.Rand) float64 {
+	return math.Exp(o.Mu + o.Sigma*r.NormFloat64())
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) iterationCountLimit() int {
+	return o.IterationCountLimit
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) clampBounds() *ClampBounds {
+	return o.Clamp
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) seed() int64 {
+	return o.Seed
+}
+
+func (o LogNormalSegmentDataGeneratorOptions) withSeed(seed int64) distributionOptions {
+	o.Seed = seed
+	return o
+}
+
+// ExponentialSegmentDataGeneratorOptions contains the options for a DistributionSegmentDataGenerator that samples from
+// an exponential distribution.
+type ExponentialSegmentDataGeneratorOptions struct {
+	// Rate represents the rate parameter (lambda) of the distribution. The mean of the distribution is 1/Rate.
+	Rate float64 `json:"rate" yaml:"rate"`
+
+	// Clamp optionally restricts the values sampled to a given interval.
+	Clamp *ClampBounds `json:"clamp,omitempty" yaml:"clamp,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the samples are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) validate() error {
+	if o.Rate <= 0 {
+		return fmt.Errorf("rate cannot be less than or equal to zero")
+	}
+
+	if !isFinite(o.Rate) {
+		return fmt.Errorf("rate must be a finite number")
+	}
+
+	return validateDistributionCommonOptions(o.Clamp, o.IterationCountLimit)
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) sample(r *rand.Rand) float64 {
+	return r.ExpFloat64() / o.Rate
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) iterationCountLimit() int {
+	return o.IterationCountLimit
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) clampBounds() *ClampBounds {
+	return o.Clamp
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) seed() int64 {
+	return o.Seed
+}
+
+func (o ExponentialSegmentDataGeneratorOptions) withSeed(seed int64) distributionOptions {
+	o.Seed = seed
+	return o
+}
+
+// PoissonSegmentDataGeneratorOptions contains the options for a DistributionSegmentDataGenerator that samples from a
+// poisson distribution.
+// The values sampled are non-negative integers, which makes this distribution a good fit for request counts.
+type PoissonSegmentDataGeneratorOptions struct {
+	// Lambda represents the expected number of events per iteration, which is both the mean and the variance of the
+	// distribution.
+	Lambda float64 `json:"lambda" yaml:"lambda"`
+
+	// Clamp optionally restricts the values sampled to a given interval.
+	Clamp *ClampBounds `json:"clamp,omitempty" yaml:"clamp,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the samples are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o PoissonSegmentDataGeneratorOptions) validate() error {
+	if o.Lambda <= 0 {
+		return fmt.Errorf("lambda cannot be less than or equal to zero")
+	}
+
+	if !isFinite(o.Lambda) {
+		return fmt.Errorf("lambda must be a finite number")
+	}
+
+	return validateDistributionCommonOptions(o.Clamp, o.IterationCountLimit)
+}
+
+func (o PoissonSegmentDataGeneratorOptions) sample(r *rand.Rand) float64 {
+	return samplePoisson(r, o.Lambda)
+}
+
+func (o PoissonSegmentDataGeneratorOptions) iterationCountLimit() int {
+	return o.IterationCountLimit
+}
+
+func (o PoissonSegmentDataGeneratorOptions) clampBounds() *ClampBounds {
+	return o.Clamp
+}
+
+func (o PoissonSegmentDataGeneratorOptions) seed() int64 {
+	return o.Seed
+}
+
+func (o PoissonSegmentDataGeneratorOptions) withSeed(seed int64) distributionOptions {
+	o.Seed = seed
+	return o
+}
+
+// ParetoSegmentDataGeneratorOptions contains the options for a DistributionSegmentDataGenerator that samples from a
+// pareto distribution.
+// The pareto distribution is heavy-tailed, which makes it a good fit for latencies.
+type ParetoSegmentDataGeneratorOptions struct {
+	// Scale represents the minimum value of the distribution (x_m).
+	Scale float64 `json:"scale" yaml:"scale"`
+
+	// Shape represents the tail index of the distribution (alpha). The smaller the shape, the heavier the tail.
+	Shape float64 `json:"shape" yaml:"shape"`
+
+	// Clamp optionally restricts the values sampled to a given interval.
+	Clamp *ClampBounds `json:"clamp,omitempty" yaml:"clamp,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the samples are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o ParetoSegmentDataGeneratorOptions) validate() error {
+	if o.Scale <= 0 {
+		return fmt.Errorf("scale cannot be less than or equal to zero")
+	}
+
+	if !isFinite(o.Scale) {
+		return fmt.Errorf("scale must be a finite number")
+	}
+
+	if o.Shape <= 0 {
+		return fmt.Errorf("shape cannot be less than or equal to zero")
+	}
+
+	if !isFinite(o.Shape) {
+		return fmt.Errorf("shape must be a finite number")
+	}
+
+	return validateDistributionCommonOptions(o.Clamp, o.IterationCountLimit)
+}
+
+func (o ParetoSegmentDataGeneratorOptions) sample(r *rand.Rand) float64 {
+	// 1-r.Float64() is in the range ]0,1], which avoids dividing by zero.
+	return o.Scale / math.Pow(1-r.Float64(), 1/o.Shape)
+}
+
+func (o ParetoSegmentDataGeneratorOptions) iterationCountLimit() int {
+	return o.IterationCountLimit
+}
+
+func (o ParetoSegmentDataGeneratorOptions) clampBounds() *ClampBounds {
+	return o.Clamp
+}
+
+func (o ParetoSegmentDataGeneratorOptions) seed() int64 {
+	return o.Seed
+}
+
+func (o ParetoSegmentDataGeneratorOptions) withSeed(seed int64) distributionOptions {
+	o.Seed = seed
+	return o
+}
+
+func validateDistributionCommonOptions(clamp *ClampBounds, iterationCountLimit int) error {
+	if clamp != nil {
+		if err := clamp.validate(); err != nil {
+			return err
+		}
+	}
+
+	if iterationCountLimit <= 0 {
+		return fmt.Errorf("iteration count limit cannot be less than or equal to zero")
+	}
+
+	return nil
+}
+
+// Check at compile time whether DistributionSegmentDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*DistributionSegmentDataGenerator)(nil)
+
+// DistributionSegmentDataGenerator returns a DataGenerator representing a sequence of samples drawn from a statistical
+// distribution.
+// Note that it's an error to use negative values with counters. It's the user responsibility to make sure negative
+// numbers only appear in gauges.
+// The zero value is not useful. Use one of the helper functions NewNormalSegmentDataGenerator,
+// NewLogNormalSegmentDataGenerator, NewExponentialSegmentDataGenerator, NewPoissonSegmentDataGenerator or
+// NewParetoSegmentDataGenerator.
+type DistributionSegmentDataGenerator struct {
+	options          distributionOptions
+	distributionType DistributionType
+}
+
+// NewNormalSegmentDataGenerator returns a new instance of DistributionSegmentDataGenerator sampling from a normal
+// distribution.
+func NewNormalSegmentDataGenerator(options NormalSegmentDataGeneratorOptions) (*DistributionSegmentDataGenerator, error) {
+	return newDistributionSegmentDataGenerator(options, DistributionTypeNormal)
+}
+
+// NewLogNormalSegmentDataGenerator returns a new instance of DistributionSegmentDataGenerator sampling from a
+// log-normal distribution.
+func NewLogNormalSegmentDataGenerator(options LogNormalSegmentDataGeneratorOptions) (*DistributionSegmentDataGenerator, error) {
+	return newDistributionSegmentDataGenerator(options, DistributionTypeLogNormal)
+}
+
+// NewExponentialSegmentDataGenerator returns a new instance of DistributionSegmentDataGenerator sampling from an
+// exponential distribution.
+func NewExponentialSegmentDataGenerator(options ExponentialSegmentDataGeneratorOptions) (*DistributionSegmentDataGenerator, error) {
+	return newDistributionSegmentDataGenerator(options, DistributionTypeExponential)
+}
+
+// NewPoissonSegmentDataGenerator returns a new instance of DistributionSegmentDataGenerator sampling from a poisson
+// distribution.
+func NewPoissonSegmentDataGenerator(options PoissonSegmentDataGeneratorOptions) (*DistributionSegmentDataGenerator, error) {
+	return newDistributionSegmentDataGenerator(options, DistributionTypePoisson)
+}
+
+// NewParetoSegmentDataGenerator returns a new instance of DistributionSegmentDataGenerator sampling from a pareto
+// distribution.
+func NewParetoSegmentDataGenerator(options ParetoSegmentDataGeneratorOptions) (*DistributionSegmentDataGenerator, error) {
+	return newDistributionSegmentDataGenerator(options, DistributionTypePareto)
+}
+
+func newDistributionSegmentDataGenerator(options distributionOptions, distributionType DistributionType) (*DistributionSegmentDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &DistributionSegmentDataGenerator{}, fmt.Errorf("error validating distribution segment data generator configuration: %w", err)
+	}
+
+	return &DistributionSegmentDataGenerator{
+		options:          options,
+		distributionType: distributionType,
+	}, nil
+}
+
+// DistributionType reports the distribution the samples are drawn from.
+func (dg *DistributionSegmentDataGenerator) DistributionType() DistributionType {
+	return dg.distributionType
+}
+
+func (dg *DistributionSegmentDataGenerator) Iterator() metrics.DataIterator {
+	return &DistributionSegmentDataIterator{
+		distributionSegmentDataGenerator: *dg,
+		rand:                             random.NewRand(dg.options.seed()),
+	}
+}
+
+func (dg *DistributionSegmentDataGenerator) WithSeed(seed int64) DataGenerator {
+	return &DistributionSegmentDataGenerator{
+		options:          dg.options.withSeed(seed),
+		distributionType: dg.distributionType,
+	}
+}
+
+func (dg *DistributionSegmentDataGenerator) Describe() DataSpec {
+	var dataGeneratorType DataGeneratorType
+	var name string
+
+	switch dg.distributionType {
+	case DistributionTypeNormal:
+		dataGeneratorType = DataGeneratorTypeNormalSegment
+		name = "Normal Segment"
+	case DistributionTypeLogNormal:
+		dataGeneratorType = DataGeneratorTypeLogNormalSegment
+		name = "Log-Normal Segment"
+	case DistributionTypeExponential:
+		dataGeneratorType = DataGeneratorTypeExponentialSegment
+		name = "Exponential Segment"
+	case DistributionTypePoisson:
+		dataGeneratorType = DataGeneratorTypePoissonSegment
+		name = "Poisson Segment"
+	case DistributionTypePareto:
+		dataGeneratorType = DataGeneratorTypeParetoSegment
+		name = "Pareto Segment"
+	default:
+		name = "Distribution Segment"
+	}
+
+	return NewDataNodeDataSpec(dataGeneratorType, name, dg.options)
+}
+
+// Check at compile time whether DistributionSegmentDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*DistributionSegmentDataIterator)(nil)
+
+type DistributionSegmentDataIterator struct {
+	// read-only access
+	distributionSegmentDataGenerator DistributionSegmentDataGenerator
+
+	// rand is the source of randomness for this iterator.
+	rand *rand.Rand
+
+	// iterIndex keeps track of the current iteration.
+	iterIndex int
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *DistributionSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	options := di.distributionSegmentDataGenerator.options
+
+	// Have we reached the end?
+	if di.iterIndex >= options.iterationCountLimit() {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	// Make sure to increment the iterator index before leaving the function
+	defer func() { di.iterIndex++ }()
+
+	value := options.clampBounds().clamp(options.sample(di.rand))
+
+	return metrics.ScrapeResult{Value: value}
+}
+
+// samplePoisson draws a sample from a poisson distribution with the given lambda.
+// For small values of lambda it uses Knuth's multiplication method, otherwise it uses the transformed rejection method
+// with squeeze (PTRS) by Hörmann, which runs in constant time regardless of lambda.
+func samplePoisson(r *rand.Rand, lambda float64) float64 {
+	// The rejection loops below never terminate given a non-finite lambda.
+	if !isFinite(lambda) {
+		return 0
+	}
+
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		k := 0.0
+		for p := r.Float64(); p > limit; p *= r.Float64() {
+			k++
+		}
+		return k
+	}
+
+	sqrtLambda := math.Sqrt(lambda)
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*sqrtLambda
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u := r.Float64() - 0.5
+		v := r.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+
+		logGamma, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-logGamma {
+			return k
+		}
+	}
+}
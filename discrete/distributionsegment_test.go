@@ -0,0 +1,275 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestDistributionSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given invalid distribution parameters", func(t *testing.T) {
+		_, err := discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+			StdDev:              -1,
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		assert.Equal(t, "error validating distribution segment data generator configuration: standard deviation cannot be less than zero", err.Error())
+
+		_, err = discrete.NewExponentialSegmentDataGenerator(discrete.ExponentialSegmentDataGeneratorOptions{
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		assert.Equal(t, "error validating distribution segment data generator configuration: rate cannot be less than or equal to zero", err.Error())
+
+		_, err = discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		assert.Equal(t, "error validating distribution segment data generator configuration: lambda cannot be less than or equal to zero", err.Error())
+
+		for _, lambda := range []float64{math.NaN(), math.Inf(1)} {
+			_, err = discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+				Lambda:              lambda,
+				IterationCountLimit: 10,
+			})
+			require.Error(t, err)
+			assert.Equal(t, "error validating distribution segment data generator configuration: lambda must be a finite number", err.Error())
+		}
+
+		_, err = discrete.NewParetoSegmentDataGenerator(discrete.ParetoSegmentDataGeneratorOptions{
+			Scale:               1,
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		assert.Equal(t, "error validating distribution segment data generator configuration: shape cannot be less than or equal to zero", err.Error())
+	})
+
+	t.Run("should fail given non-finite distribution parameters", func(t *testing.T) {
+		testCases := map[string]struct {
+			newDataGenerator     func() (*discrete.DistributionSegmentDataGenerator, error)
+			expectedErrorMessage string
+		}{
+			"normal": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+						Mean: 1, StdDev: math.NaN(), IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "standard deviation must be a finite number",
+			},
+			"log-normal": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewLogNormalSegmentDataGenerator(discrete.LogNormalSegmentDataGeneratorOptions{
+						Mu: 0, Sigma: math.Inf(1), IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "sigma must be a finite number",
+			},
+			"exponential": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewExponentialSegmentDataGenerator(discrete.ExponentialSegmentDataGeneratorOptions{
+						Rate: math.NaN(), IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "rate must be a finite number",
+			},
+			"poisson": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+						Lambda: math.Inf(1), IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "lambda must be a finite number",
+			},
+			"pareto": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewParetoSegmentDataGenerator(discrete.ParetoSegmentDataGeneratorOptions{
+						Scale: 1, Shape: math.NaN(), IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "shape must be a finite number",
+			},
+			"clamp": {
+				newDataGenerator: func() (*discrete.DistributionSegmentDataGenerator, error) {
+					return discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+						Mean: 1, StdDev: 1, Clamp: &discrete.ClampBounds{Min: math.NaN(), Max: 10}, IterationCountLimit: 10,
+					})
+				},
+				expectedErrorMessage: "clamp min and clamp max must be finite numbers",
+			},
+		}
+
+		for name, testCase := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := testCase.newDataGenerator()
+				require.Error(t, err)
+				assert.Equal(t, "error validating distribution segment data generator configuration: "+testCase.expectedErrorMessage, err.Error())
+			})
+		}
+	})
+
+	t.Run("should fail given that iteration count limit is set to zero", func(t *testing.T) {
+		_, err := discrete.NewLogNormalSegmentDataGenerator(discrete.LogNormalSegmentDataGeneratorOptions{
+			Mu:    1,
+			Sigma: 1,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution segment data generator configuration: iteration count limit cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given invalid clamp bounds", func(t *testing.T) {
+		_, err := discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+			Mean:                10,
+			StdDev:              1,
+			Clamp:               &discrete.ClampBounds{Min: 20, Max: 10},
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution segment data generator configuration: clamp min cannot be greater than clamp max"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should produce samples with the expected mean for each distribution", func(t *testing.T) {
+		const sampleCount = 5000
+
+		newGenerator := func(dataGenerator *discrete.DistributionSegmentDataGenerator, err error) discrete.DataGenerator {
+			require.NoError(t, err)
+			return dataGenerator
+		}
+
+		testCases := map[string]struct {
+			dataGenerator discrete.DataGenerator
+			expectedMean  float64
+			delta         float64
+		}{
+			"normal": {
+				dataGenerator: newGenerator(discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+					Mean: 100, StdDev: 10, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: 100,
+				delta:        1,
+			},
+			"log-normal": {
+				dataGenerator: newGenerator(discrete.NewLogNormalSegmentDataGenerator(discrete.LogNormalSegmentDataGeneratorOptions{
+					Mu: 0, Sigma: 0.5, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: math.Exp(0.125),
+				delta:        0.05,
+			},
+			"exponential": {
+				dataGenerator: newGenerator(discrete.NewExponentialSegmentDataGenerator(discrete.ExponentialSegmentDataGeneratorOptions{
+					Rate: 0.5, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: 2,
+				delta:        0.1,
+			},
+			"poisson small lambda": {
+				dataGenerator: newGenerator(discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+					Lambda: 4, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: 4,
+				delta:        0.1,
+			},
+			"poisson large lambda": {
+				dataGenerator: newGenerator(discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+					Lambda: 250, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: 250,
+				delta:        1,
+			},
+			"pareto": {
+				dataGenerator: newGenerator(discrete.NewParetoSegmentDataGenerator(discrete.ParetoSegmentDataGeneratorOptions{
+					Scale: 1, Shape: 3, IterationCountLimit: sampleCount, Seed: 1,
+				})),
+				expectedMean: 1.5,
+				delta:        0.05,
+			},
+		}
+
+		for name, testCase := range testCases {
+			t.Run(name, func(t *testing.T) {
+				dataIterator := testCase.dataGenerator.Iterator()
+
+				sum := 0.0
+				count := 0
+				for result := dataIterator.Evaluate(helperScrapeInfo(count)); !result.Exhausted; result = dataIterator.Evaluate(helperScrapeInfo(count)) {
+					sum += result.Value
+					count++
+				}
+
+				require.Equal(t, sampleCount, count)
+				assert.InDelta(t, testCase.expectedMean, sum/float64(count), testCase.delta)
+			})
+		}
+	})
+
+	t.Run("should produce integer samples for the poisson distribution", func(t *testing.T) {
+		dataGenerator, err := discrete.NewPoissonSegmentDataGenerator(discrete.PoissonSegmentDataGeneratorOptions{
+			Lambda:              50,
+			IterationCountLimit: 20,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 20, len(results))
+		for _, result := range results {
+			assert.Equal(t, math.Trunc(result.scrapeResult.Value), result.scrapeResult.Value)
+			assert.GreaterOrEqual(t, result.scrapeResult.Value, 0.0)
+		}
+	})
+
+	t.Run("should clamp samples to the bounds provided", func(t *testing.T) {
+		dataGenerator, err := discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+			Mean:                0,
+			StdDev:              100,
+			Clamp:               &discrete.ClampBounds{Min: -1, Max: 1},
+			IterationCountLimit: 50,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 50, len(results))
+		for _, result := range results {
+			assert.GreaterOrEqual(t, result.scrapeResult.Value, -1.0)
+			assert.LessOrEqual(t, result.scrapeResult.Value, 1.0)
+		}
+	})
+
+	t.Run("should produce the same sequence given the same seed", func(t *testing.T) {
+		dataGenerator, err := discrete.NewParetoSegmentDataGenerator(discrete.ParetoSegmentDataGeneratorOptions{
+			Scale:               2,
+			Shape:               1.5,
+			IterationCountLimit: 10,
+			Seed:                99,
+		})
+		require.NoError(t, err)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.WithSeed(99).Iterator())
+
+		require.Equal(t, 10, len(results1))
+		require.Equal(t, 10, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+	})
+
+	t.Run("should describe the distribution along with its options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewExponentialSegmentDataGenerator(discrete.ExponentialSegmentDataGeneratorOptions{
+			Rate:                2,
+			Clamp:               &discrete.ClampBounds{Min: 0, Max: 10},
+			IterationCountLimit: 10,
+		})
+		require.NoError(t, err)
+
+		result := discrete.DescribeWithOptions(dataGenerator.Describe())
+		assert.Equal(t, "Exponential Segment {Rate:2 Clamp:[0,10] IterationCountLimit:10 Seed:0}", result)
+	})
+}
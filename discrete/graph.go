@@ -0,0 +1,223 @@
+package discrete
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxRenderedCustomValues caps the number of custom values rendered in a node, so long series don't blow up the graph.
+const maxRenderedCustomValues = 10
+
+// RenderMermaid renders the tree of nodes as a Mermaid flowchart.
+// Each node shows its name, its parameters and the number of iterations it produces. Loop counts are also shown on the
+// edge to the data node being looped over.
+//
+// The result can be pasted in a Markdown document wrapped in a 'mermaid' code block.
+func RenderMermaid(rootDataSpec DataSpec) string {
+	graph := newDataSpecGraph(rootDataSpec)
+
+	var builder strings.Builder
+	builder.WriteString("flowchart TD\n")
+
+	for _, node := range graph.nodes {
+		label := make([]string, 0, len(node.label))
+		for _, line := range node.label {
+			label = append(label, escapeMermaid(line))
+		}
+		builder.WriteString(fmt.Sprintf("    n%d[\"%s\"]\n", node.id, strings.Join(label, "<br/>")))
+	}
+
+	for _, edge := range graph.edges {
+		if edge.label == "" {
+			builder.WriteString(fmt.Sprintf("    n%d --> n%d\n", edge.from, edge.to))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("    n%d -->|\"%s\"| n%d\n", edge.from, escapeMermaid(edge.label), edge.to))
+	}
+
+	return builder.String()
+}
+
+// RenderDOT renders the tree of nodes as a Graphviz DOT digraph.
+// Each node shows its name, its parameters and the number of iterations it produces. Loop counts are also shown on the
+// edge to the data node being looped over.
+//
+// The result can be rendered with the dot command (ex: dot -Tsvg tree.dot > tree.svg).
+func RenderDOT(rootDataSpec DataSpec) string {
+	graph := newDataSpecGraph(rootDataSpec)
+
+	var builder strings.Builder
+	builder.WriteString("digraph DataSpec {\n")
+	builder.WriteString("    ordering=out;\n")
+	builder.WriteString("    node [shape=box];\n")
+
+	for _, node := range graph.nodes {
+		label := make([]string, 0, len(node.label))
+		for _, line := range node.label {
+			label = append(label, escapeDOT(line))
+		}
+		builder.WriteString(fmt.Sprintf("    n%d [label=\"%s\"];\n", node.id, strings.Join(label, "\\n")))
+	}
+
+	for _, edge := range graph.edges {
+		if edge.label == "" {
+			builder.WriteString(fmt.Sprintf("    n%d -> n%d;\n", edge.from, edge.to))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("    n%d -> n%d [label=\"%s\"];\n", edge.from, edge.to, escapeDOT(edge.label)))
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func escapeMermaid(text string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;")
+	return replacer.Replace(text)
+}
+
+func escapeDOT(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return replacer.Replace(text)
+}
+
+// dataSpecGraph is the format agnostic representation of the tree of nodes used by the renderers.
+type dataSpecGraph struct {
+	nodes []dataSpecGraphNode
+	edges []dataSpecGraphEdge
+}
+
+type dataSpecGraphNode struct {
+	id int
+
+	// label contains the lines to be displayed in the node.
+	label []string
+}
+
+type dataSpecGraphEdge struct {
+	from  int
+	to    int
+	label string
+}
+
+func newDataSpecGraph(rootDataSpec DataSpec) dataSpecGraph {
+	graph := dataSpecGraph{}
+	graph.addNode(rootDataSpec)
+	return graph
+}
+
+// addNode adds the node and all its descendants to the graph.
+func (g *dataSpecGraph) addNode(dataSpec DataSpec) {
+	id := len(g.nodes)
+	g.nodes = append(g.nodes, dataSpecGraphNode{id: id})
+
+	var label []string
+	var children []DataSpec
+	var edgeLabel string
+
+	switch dataSpecConcrete := dataSpec.(type) {
+	case DataNodeDataSpec:
+		label = []string{dataSpecConcrete.Name()}
+		// The options of the void segment are the number of iterations, which are already shown.
+		if dataSpecConcrete.DataGeneratorType() != DataGeneratorTypeVoidSegment {
+			label = append(label, renderOptions(dataSpecConcrete.Options())...)
+		}
+	case JoinDataSpec:
+		label = []string{dataSpecConcrete.Name()}
+		children = dataSpecConcrete.Children
+	case LoopDataSpec:
+		label = []string{fmt.Sprintf("%s [%d]", dataSpecConcrete.Name(), dataSpecConcrete.Count)}
+		if dataSpecConcrete.Seed != 0 {
+			label = append(label, fmt.Sprintf("Seed: %d", dataSpecConcrete.Seed))
+		}
+		children = []DataSpec{dataSpecConcrete.Func}
+		edgeLabel = fmt.Sprintf("x%d", dataSpecConcrete.Count)
+	case ArithmeticDataSpec:
+		label = []string{dataSpecConcrete.Name()}
+		children = dataSpecConcrete.Children
+	case CounterDataSpec:
+		label = append([]string{dataSpecConcrete.Name()}, renderOptions(dataSpecConcrete.Options)...)
+		children = []DataSpec{dataSpecConcrete.Increment}
+		edgeLabel = "increment"
+	case ContainerDataSpec:
+		label = append([]string{dataSpecConcrete.Name()}, renderOptions(dataSpecConcrete.Options())...)
+		children = dataSpecConcrete.Children()
+	default:
+		label = []string{"error"}
+	}
+
+	if count, ok := IterationCount(dataSpec); ok {
+		label = append(label, fmt.Sprintf("Iterations: %d", count))
+	} else {
+		label = append(label, "Iterations: unknown")
+	}
+
+	g.nodes[id].label = label
+
+	for _, child := range children {
+		// The child gets the next id, which allows the edge to be added before the descendants of the child.
+		g.edges = append(g.edges, dataSpecGraphEdge{from: id, to: len(g.nodes), label: edgeLabel})
+		g.addNode(child)
+	}
+}
+
+// renderOptions renders the options of a node as a list of 'Field: value' lines.
+// Fields tagged with omitempty are left out when they hold the zero value, since those are optional. The
+// IterationCountLimit field is also left out, since the number of iterations is shown for every node.
+func renderOptions(options any) []string {
+	if options == nil {
+		return nil
+	}
+
+	if values, ok := options.([]CustomValueSample); ok {
+		return []string{"Values: " + renderCustomValues(values)}
+	}
+
+	value := reflect.ValueOf(options)
+	if value.Kind() != reflect.Struct {
+		return []string{fmt.Sprintf("%v", options)}
+	}
+
+	var lines []string
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Name == "IterationCountLimit" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.IsZero() && strings.Contains(field.Tag.Get("json"), "omitempty") {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %v", field.Name, fieldValue.Interface()))
+	}
+
+	return lines
+}
+
+func renderCustomValues(values []CustomValueSample) string {
+	items := make([]string, 0, maxRenderedCustomValues+1)
+
+	for i, value := range values {
+		if i == maxRenderedCustomValues {
+			items = append(items, "...")
+			break
+		}
+
+		if value.Missing {
+			items = append(items, "_")
+			continue
+		}
+		if value.Stale {
+			items = append(items, "stale")
+			continue
+		}
+		items = append(items, formatSeriesValue(value.Value))
+	}
+
+	return strings.Join(items, " ")
+}
@@ -0,0 +1,142 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func helperGraphDataSpec(t *testing.T) discrete.DataSpec {
+	t.Helper()
+
+	lsDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+		AmplitudeStart:      1,
+		AmplitudeEnd:        5,
+		IterationCountLimit: 3,
+	})
+	require.NoError(t, err)
+
+	normalDataGenerator, err := discrete.NewNormalSegmentDataGenerator(discrete.NormalSegmentDataGeneratorOptions{
+		Mean:                1,
+		StdDev:              2,
+		Clamp:               &discrete.ClampBounds{Min: 0, Max: 3},
+		IterationCountLimit: 4,
+	})
+	require.NoError(t, err)
+
+	return discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+		lsDataGenerator,
+		discrete.NewLoopDataGenerator(normalDataGenerator, 3),
+		discrete.NewVoidSegmentDataGenerator(2),
+		discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Missing: true}}),
+	}).Describe()
+}
+
+func TestRenderMermaid(t *testing.T) {
+	t.Run("should render the tree with parameters, loop counts and iterations", func(t *testing.T) {
+		result := discrete.RenderMermaid(helperGraphDataSpec(t))
+
+		expected := `flowchart TD
+    n0["Join<br/>Iterations: 19"]
+    n1["Linear Segment<br/>AmplitudeStart: 1<br/>AmplitudeEnd: 5<br/>Iterations: 3"]
+    n2["Loop [3]<br/>Iterations: 12"]
+    n3["Normal Segment<br/>Mean: 1<br/>StdDev: 2<br/>Clamp: [0,3]<br/>Iterations: 4"]
+    n4["Void<br/>Iterations: 2"]
+    n5["Custom Values<br/>Values: 1 _<br/>Iterations: 2"]
+    n0 --> n1
+    n0 --> n2
+    n2 -->|"x3"| n3
+    n0 --> n4
+    n0 --> n5
+`
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestRenderDOT(t *testing.T) {
+	t.Run("should render the tree with parameters, loop counts and iterations", func(t *testing.T) {
+		result := discrete.RenderDOT(helperGraphDataSpec(t))
+
+		expected := `digraph DataSpec {
+    ordering=out;
+    node [shape=box];
+    n0 [label="Join\nIterations: 19"];
+    n1 [label="Linear Segment\nAmplitudeStart: 1\nAmplitudeEnd: 5\nIterations: 3"];
+    n2 [label="Loop [3]\nIterations: 12"];
+    n3 [label="Normal Segment\nMean: 1\nStdDev: 2\nClamp: [0,3]\nIterations: 4"];
+    n4 [label="Void\nIterations: 2"];
+    n5 [label="Custom Values\nValues: 1 _\nIterations: 2"];
+    n0 -> n1;
+    n0 -> n2;
+    n2 -> n3 [label="x3"];
+    n0 -> n4;
+    n0 -> n5;
+}
+`
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should render the counter options and the increment edge", func(t *testing.T) {
+		counterDataGenerator, err := discrete.NewCounterDataGenerator(discrete.NewVoidSegmentDataGenerator(5), discrete.CounterDataGeneratorOptions{
+			ResetEvery: 2,
+		})
+		require.NoError(t, err)
+
+		result := discrete.RenderDOT(counterDataGenerator.Describe())
+
+		expected := `digraph DataSpec {
+    ordering=out;
+    node [shape=box];
+    n0 [label="Counter\nInitialValue: 0\nResetEvery: 2\nResetProbability: 0\nIterations: 5"];
+    n1 [label="Void\nIterations: 5"];
+    n0 -> n1 [label="increment"];
+}
+`
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should render stale custom values as stale markers", func(t *testing.T) {
+		dataSpec := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 1}, {Stale: true}, {Missing: true},
+		}).Describe()
+
+		result := discrete.RenderDOT(dataSpec)
+
+		expected := `digraph DataSpec {
+    ordering=out;
+    node [shape=box];
+    n0 [label="Custom Values\nValues: 1 stale _\nIterations: 3"];
+}
+`
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestIterationCount(t *testing.T) {
+	t.Run("should sum joins, multiply loops and take the shortest arithmetic child", func(t *testing.T) {
+		dataSpec := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			discrete.NewLoopDataGenerator(discrete.NewVoidSegmentDataGenerator(2), 3),
+			discrete.NewAddDataGenerator([]discrete.DataGenerator{
+				discrete.NewVoidSegmentDataGenerator(5),
+				discrete.NewVoidSegmentDataGenerator(4),
+			}),
+		}).Describe()
+
+		count, ok := discrete.IterationCount(dataSpec)
+		require.True(t, ok)
+		assert.Equal(t, 10, count)
+	})
+
+	t.Run("should not determine the count of data nodes defined outside the package", func(t *testing.T) {
+		dataSpec := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			discrete.NewVoidSegmentDataGenerator(2),
+			&constantDataGenerator{options: constantDataGeneratorOptions{Value: 1, Count: 2}},
+		}).Describe()
+
+		_, ok := discrete.IterationCount(dataSpec)
+		assert.False(t, ok)
+	})
+}
@@ -62,3 +62,39 @@ type resultContainer struct {
 	scrapeInfo   metrics.ScrapeInfo
 	scrapeResult metrics.ScrapeResult
 }
+
+// helperScrapeInfo returns the ScrapeInfo for the given iteration index, as generated by helperScraper.
+// Useful when a DataIterator needs to be evaluated more times than the helperScraper allows.
+func helperScrapeInfo(iterationIndex int) metrics.ScrapeInfo {
+	firstIterationTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	return metrics.ScrapeInfo{
+		FirstIterationTime: firstIterationTime,
+		IterationIndex:     iterationIndex,
+		IterationTime:      firstIterationTime.Add(time.Duration(iterationIndex) * 15 * time.Second),
+	}
+}
+
+// helperResultScraper computes the results of a histogram or summary iterator, given the Scraper method that scrapes
+// it, e.g., (*metrics.Scraper).ScrapeDataSummaryIterator.
+func helperResultScraper[I any, R any, H ~func(metrics.ScrapeInfo, R) error](t *testing.T, scrape func(*metrics.Scraper, I, H) error, iterator I) []R {
+	t.Helper()
+
+	scraper, err := metrics.NewScraper(
+		metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		},
+		metrics.WithScraperIterationCountLimit(100), // It's good practice to set an upper bound in tests
+	)
+	require.NoError(t, err)
+
+	var results []R
+	err = scrape(scraper, iterator, func(_ metrics.ScrapeInfo, result R) error {
+		results = append(results, result)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return results
+}
@@ -0,0 +1,352 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// DataHistogramGenerator generates histogram data according to the generator.
+// It's meant to be used by Histogram metrics.
+type DataHistogramGenerator interface {
+	Iterator() metrics.DataHistogramIterator
+}
+
+// HistogramDistributionParameters contains the DataGenerators driving the parameters of the distribution the
+// observations are drawn from.
+// On each scrape, every DataGenerator is evaluated once, which means the parameters can change over time. For example,
+// a JoinDataGenerator of two linear segments can be used as the Mean to simulate a latency regression.
+type HistogramDistributionParameters struct {
+	// Mean drives the mean of the observations.
+	Mean DataGenerator
+
+	// Spread drives the standard deviation of the observations.
+	// It's ignored by the exponential distribution, whose standard deviation is equal to its mean, in which case it can
+	// be nil.
+	Spread DataGenerator
+
+	// ObservationRate drives the expected number of observations recorded between two scrapes.
+	// The actual number of observations is drawn from a poisson distribution with the rate as its mean.
+	ObservationRate DataGenerator
+}
+
+func (p *HistogramDistributionParameters) validate(distributionType DistributionType) error {
+	if p.Mean == nil {
+		return fmt.Errorf("mean data generator cannot be nil")
+	}
+
+	if p.Spread == nil && distributionType != DistributionTypeExponential {
+		return fmt.Errorf("spread data generator cannot be nil")
+	}
+
+	if p.ObservationRate == nil {
+		return fmt.Errorf("observation rate data generator cannot be nil")
+	}
+
+	return nil
+}
+
+// HistogramDataGeneratorOptions contains the options for the DistributionHistogramDataGenerator.
+type HistogramDataGeneratorOptions struct {
+	// Buckets contains the upper bounds (inclusive) of the buckets, in increasing order.
+	// The +Inf bucket is implicit and must not be provided, its value is the Count of the histogram.
+	// The metrics.LinearBuckets, metrics.ExponentialBuckets and metrics.ExplicitBuckets functions can be used to build
+	// common bucket layouts.
+	Buckets []float64 `json:"buckets" yaml:"buckets"`
+
+	// DistributionType sets the distribution the observations are drawn from.
+	// Only the normal, log-normal and exponential distributions are supported.
+	DistributionType DistributionType `json:"distribution_type" yaml:"distribution_type"`
+
+	// Seed seeds the source of randomness used to draw the observations, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o *HistogramDataGeneratorOptions) validate() error {
+	if len(o.Buckets) == 0 {
+		return fmt.Errorf("buckets cannot be empty")
+	}
+
+	for i, bucket := range o.Buckets {
+		if math.IsInf(bucket, 0) || math.IsNaN(bucket) {
+			return fmt.Errorf("buckets must be finite numbers")
+		}
+
+		if i > 0 && bucket <= o.Buckets[i-1] {
+			return fmt.Errorf("buckets must be in strictly increasing order")
+		}
+	}
+
+	switch o.DistributionType {
+	case DistributionTypeNormal, DistributionTypeLogNormal, DistributionTypeExponential:
+	default:
+		return fmt.Errorf("distribution type %q is not supported", o.DistributionType)
+	}
+
+	return nil
+}
+
+// Check at compile time whether DistributionHistogramDataGenerator implements DataHistogramGenerator interface.
+var _ DataHistogramGenerator = (*DistributionHistogramDataGenerator)(nil)
+
+// DistributionHistogramDataGenerator returns a DataHistogramGenerator representing a histogram whose observations are
+// drawn from a statistical distribution.
+// On each scrape, a number of observations are drawn and recorded in the histogram. The histogram is cumulative, which
+// means the buckets, Count and Sum never decrease, just like a histogram exposed by a real application.
+//
+// The parameters of the distribution are driven by DataGenerators, as described in HistogramDistributionParameters.
+// The mean and the spread are always expressed in the unit of the observations, regardless of the distribution.
+//
+// The following rules apply:
+//   - If any of the parameters returns a missing sample, the scrape is missing and no observations are recorded.
+//     Since histograms have no stale markers, a stale sample is treated as a missing sample.
+//   - The parameters are evaluated in lockstep. The generator is exhausted as soon as any of its parameters is
+//     exhausted.
+//   - A negative observation rate or a negative spread is treated as zero.
+//   - The log-normal and exponential distributions only produce positive observations, so no observations are
+//     recorded while their mean is less than or equal to zero.
+//
+// The zero value is not useful. Use NewDistributionHistogramDataGenerator function.
+type DistributionHistogramDataGenerator struct {
+	parameters HistogramDistributionParameters
+	options    HistogramDataGeneratorOptions
+}
+
+// NewDistributionHistogramDataGenerator returns a new instance of DistributionHistogramDataGenerator.
+func NewDistributionHistogramDataGenerator(parameters HistogramDistributionParameters, options HistogramDataGeneratorOptions) (*DistributionHistogramDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &DistributionHistogramDataGenerator{}, fmt.Errorf("error validating distribution histogram data generator configuration: %w", err)
+	}
+
+	if err := parameters.validate(options.DistributionType); err != nil {
+		return &DistributionHistogramDataGenerator{}, fmt.Errorf("error validating distribution histogram data generator configuration: %w", err)
+	}
+
+	// Copy the buckets, so later changes to the slice provided don't affect the data generator.
+	buckets := make([]float64, len(options.Buckets))
+	copy(buckets, options.Buckets)
+	options.Buckets = buckets
+
+	return &DistributionHistogramDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}, nil
+}
+
+func (dg *DistributionHistogramDataGenerator) Iterator() metrics.DataHistogramIterator {
+	return &DistributionHistogramDataIterator{
+		distributionHistogramDataGenerator: *dg,
+		rand:                               random.NewRand(dg.options.Seed),
+		bucketCounts:                       make([]float64, len(dg.options.Buckets)),
+	}
+}
+
+// WithSeed returns a copy of the DistributionHistogramDataGenerator seeded with the seed provided.
+// The parameters are seeded with seeds derived from the seed provided.
+// A seed of zero returns the DistributionHistogramDataGenerator unchanged, so the parameters keep their own seeds.
+func (dg *DistributionHistogramDataGenerator) WithSeed(seed int64) DataHistogramGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	options := dg.options
+	options.Seed = seed
+
+	parameters := HistogramDistributionParameters{
+		Mean:            WithSeed(dg.parameters.Mean, DeriveSeed(seed, 0)),
+		ObservationRate: WithSeed(dg.parameters.ObservationRate, DeriveSeed(seed, 2)),
+	}
+	if dg.parameters.Spread != nil {
+		parameters.Spread = WithSeed(dg.parameters.Spread, DeriveSeed(seed, 1))
+	}
+
+	return &DistributionHistogramDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}
+}
+
+// Check at compile time whether DistributionHistogramDataIterator implements metrics.DataHistogramIterator interface.
+var _ metrics.DataHistogramIterator = (*DistributionHistogramDataIterator)(nil)
+
+type DistributionHistogramDataIterator struct {
+	// read-only access
+	distributionHistogramDataGenerator DistributionHistogramDataGenerator
+
+	// these variables keep track of the current state of the iterator
+	parametersIterator *histogramParametersIterator
+	rand               *rand.Rand
+
+	// bucketCounts contains the cumulative count of each bucket, i.e., the number of observations less than or equal to
+	// the upper bound of the bucket.
+	bucketCounts []float64
+	count        float64
+	sum          float64
+}
+
+// Evaluate fulfills the metrics.DataHistogramIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *DistributionHistogramDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+	if di.parametersIterator == nil {
+		di.parametersIterator = newHistogramParametersIterator(di.distributionHistogramDataGenerator.parameters)
+	}
+
+	parameters := di.parametersIterator.evaluate(scrapeInfo)
+
+	if parameters.exhausted {
+		return metrics.ScrapeHistogramResult{Exhausted: true}
+	}
+
+	if parameters.missing {
+		return metrics.ScrapeHistogramResult{Missing: true}
+	}
+
+	sampleObservations(di.rand, di.distributionHistogramDataGenerator.options.DistributionType, parameters, di.observe)
+
+	return di.result()
+}
+
+// observe records the observation in the histogram.
+func (di *DistributionHistogramDataIterator) observe(observation float64) {
+	for i, upperBound := range di.distributionHistogramDataGenerator.options.Buckets {
+		if observation <= upperBound {
+			di.bucketCounts[i]++
+		}
+	}
+
+	di.count++
+	di.sum += observation
+}
+
+func (di *DistributionHistogramDataIterator) result() metrics.ScrapeHistogramResult {
+	buckets := make([]metrics.HistogramBucketScrape, len(di.bucketCounts))
+	for i, upperBound := range di.distributionHistogramDataGenerator.options.Buckets {
+		buckets[i] = metrics.HistogramBucketScrape{
+			LE:    upperBound,
+			Value: di.bucketCounts[i],
+		}
+	}
+
+	return metrics.ScrapeHistogramResult{
+		Buckets: buckets,
+		Count:   di.count,
+		Sum:     di.sum,
+	}
+}
+
+// histogramParametersIterator evaluates the DataGenerators driving the parameters of the distribution in lockstep.
+// It's shared by the histogram data generators.
+type histogramParametersIterator struct {
+	meanDataIterator            metrics.DataIterator
+	spreadDataIterator          metrics.DataIterator
+	observationRateDataIterator metrics.DataIterator
+	exhausted                   bool
+}
+
+// histogramParameters contains the parameters of the distribution for a single scrape.
+type histogramParameters struct {
+	mean            float64
+	spread          float64
+	observationRate float64
+
+	missing   bool
+	exhausted bool
+}
+
+func newHistogramParametersIterator(parameters HistogramDistributionParameters) *histogramParametersIterator {
+	parametersIterator := &histogramParametersIterator{
+		meanDataIterator:            parameters.Mean.Iterator(),
+		observationRateDataIterator: parameters.ObservationRate.Iterator(),
+	}
+
+	if parameters.Spread != nil {
+		parametersIterator.spreadDataIterator = parameters.Spread.Iterator()
+	}
+
+	return parametersIterator
+}
+
+func (pi *histogramParametersIterator) evaluate(scrapeInfo metrics.ScrapeInfo) histogramParameters {
+	if pi.exhausted {
+		return histogramParameters{exhausted: true}
+	}
+
+	// All parameters are evaluated on every scrape, even if one of them is missing, so they all stay in lockstep.
+	mean := pi.meanDataIterator.Evaluate(scrapeInfo)
+	observationRate := pi.observationRateDataIterator.Evaluate(scrapeInfo)
+	spread := metrics.ScrapeResult{}
+	if pi.spreadDataIterator != nil {
+		spread = pi.spreadDataIterator.Evaluate(scrapeInfo)
+	}
+
+	if mean.Exhausted || observationRate.Exhausted || spread.Exhausted {
+		pi.exhausted = true
+		return histogramParameters{exhausted: true}
+	}
+
+	// A stale parameter has no value to draw the observations from.
+	if mean.Missing || observationRate.Missing || spread.Missing || mean.Stale || observationRate.Stale || spread.Stale {
+		return histogramParameters{missing: true}
+	}
+
+	// Observations can't be drawn from non-finite parameters either, so the scrape is treated as missing.
+	if !isFinite(mean.Value) || !isFinite(observationRate.Value) || !isFinite(spread.Value) {
+		return histogramParameters{missing: true}
+	}
+
+	return histogramParameters{
+		mean:            mean.Value,
+		spread:          math.Max(spread.Value, 0),
+		observationRate: observationRate.Value,
+	}
+}
+
+// sampleObservations draws the observations of a single scrape and records each one of them with the observe function.
+// The number of observations is drawn from a poisson distribution with the observation rate as its mean.
+func sampleObservations(r *rand.Rand, distributionType DistributionType, parameters histogramParameters, observe func(observation float64)) {
+	if parameters.observationRate <= 0 {
+		return
+	}
+
+	observationCount := int(samplePoisson(r, parameters.observationRate))
+	for i := 0; i < observationCount; i++ {
+		observation, ok := sampleObservation(r, distributionType, parameters.mean, parameters.spread)
+		if !ok {
+			return
+		}
+		observe(observation)
+	}
+}
+
+// sampleObservation draws an observation from the distribution with the given mean and standard deviation.
+// It reports false if the distribution can't produce observations with the parameters provided.
+func sampleObservation(r *rand.Rand, distributionType DistributionType, mean float64, stdDev float64) (float64, bool) {
+	switch distributionType {
+	case DistributionTypeNormal:
+		return r.NormFloat64()*stdDev + mean, true
+	case DistributionTypeLogNormal:
+		if mean <= 0 {
+			return 0, false
+		}
+		// Convert the mean and standard deviation of the observations into the parameters of the underlying normal
+		// distribution.
+		sigmaSquared := math.Log(1 + (stdDev*stdDev)/(mean*mean))
+		mu := math.Log(mean) - sigmaSquared/2
+		return math.Exp(mu + math.Sqrt(sigmaSquared)*r.NormFloat64()), true
+	case DistributionTypeExponential:
+		if mean <= 0 {
+			return 0, false
+		}
+		return r.ExpFloat64() * mean, true
+	default:
+		return 0, false
+	}
+}
+
+// isFinite reports whether the value is neither infinite nor NaN.
+func isFinite(value float64) bool {
+	return !math.IsInf(value, 0) && !math.IsNaN(value)
+}
@@ -0,0 +1,72 @@
+package discrete
+
+import (
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+// Check at compile time whether HistogramMetricTimeSeries implements promadapter.HistogramMetricTimeSeriesObservable
+// interface.
+var _ promadapter.HistogramMetricTimeSeriesObservable = (*HistogramMetricTimeSeries)(nil)
+
+// HistogramMetricTimeSeries represents a histogram metric time series.
+// When the time series iterator gets to the end of the DataHistogramGenerator provided it will evaluate the
+// metrics.EndStrategy to decide on what to do next.
+// Since the end strategy custom value is not a histogram, the metrics.EndStrategyTypeSendCustomValue strategy only
+// honours the Missing and Exhausted fields of the custom value, otherwise it behaves like
+// metrics.EndStrategyTypeSendLastValue.
+// Looping starts a new iterator, which resets the histogram, just like an application restart would.
+// The zero value of HistogramMetricTimeSeries is not useful. Use NewHistogramMetricTimeSeries function.
+type HistogramMetricTimeSeries struct {
+	labels map[string]string
+
+	dataHistogramGenerator DataHistogramGenerator
+	endStrategy            metrics.EndStrategy
+}
+
+// NewHistogramMetricTimeSeries creates a new instance of HistogramMetricTimeSeries.
+func NewHistogramMetricTimeSeries(labels map[string]string, data DataHistogramGenerator, endStrategy metrics.EndStrategy) *HistogramMetricTimeSeries {
+	return &HistogramMetricTimeSeries{
+		labels:                 labels,
+		dataHistogramGenerator: data,
+		endStrategy:            endStrategy,
+	}
+}
+
+// Iterator returns a time series iterator that can be used to iterate over the data.
+func (ts *HistogramMetricTimeSeries) Iterator() metrics.DataHistogramIterator {
+	dataHistogramGenerator := ts.dataHistogramGenerator
+
+	return &HistogramMetricTimeSeriesDataIterator{
+		timeSeriesIterator: metrics.NewTimeSeriesIterator(func() metrics.Evaluator[metrics.ScrapeHistogramResult] {
+			return dataHistogramGenerator.Iterator()
+		}, ts.endStrategy),
+	}
+}
+
+// Labels returns the labels associated with the time series.
+func (ts *HistogramMetricTimeSeries) Labels() map[string]string {
+	return ts.labels
+}
+
+// IsInfinite reports whether this time series is infinite.
+// In other words, whether this time series will never stop generating samples.
+func (ts *HistogramMetricTimeSeries) IsInfinite() bool {
+	return ts.endStrategy.EndStrategyType != metrics.EndStrategyTypeRemoveTimeSeries
+}
+
+// Check at compile time whether HistogramMetricTimeSeriesDataIterator implements metrics.DataHistogramIterator
+// interface.
+var _ metrics.DataHistogramIterator = (*HistogramMetricTimeSeriesDataIterator)(nil)
+
+// HistogramMetricTimeSeriesDataIterator iterates over the data of a HistogramMetricTimeSeries.
+// The end strategy is evaluated by metrics.TimeSeriesIterator.
+type HistogramMetricTimeSeriesDataIterator struct {
+	timeSeriesIterator *metrics.TimeSeriesIterator[metrics.ScrapeHistogramResult]
+}
+
+// Evaluate fulfills the metrics.DataHistogramIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *HistogramMetricTimeSeriesDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+	return di.timeSeriesIterator.Evaluate(scrapeInfo)
+}
@@ -0,0 +1,311 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func helperConstantDataGenerator(t *testing.T, value float64, iterationCountLimit int) discrete.DataGenerator {
+	t.Helper()
+
+	dataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+		AmplitudeStart:      value,
+		AmplitudeEnd:        value,
+		IterationCountLimit: iterationCountLimit,
+	})
+	require.NoError(t, err)
+
+	return dataGenerator
+}
+
+func TestDistributionHistogramDataIterator(t *testing.T) {
+	buckets := []float64{0.1, 0.25, 0.5, 1, 2.5}
+
+	t.Run("should fail given buckets that are not in increasing order", func(t *testing.T) {
+		_, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          []float64{1, 0.5},
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution histogram data generator configuration: buckets must be in strictly increasing order"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given an unsupported distribution", func(t *testing.T) {
+		_, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypePoisson,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := `error validating distribution histogram data generator configuration: distribution type "distribution_type-poisson" is not supported`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given a normal distribution without spread", func(t *testing.T) {
+		_, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeNormal,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution histogram data generator configuration: spread data generator cannot be nil"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should produce cumulative buckets, count and sum", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 0.4, 20),
+				Spread:          helperConstantDataGenerator(t, 0.2, 20),
+				ObservationRate: helperConstantDataGenerator(t, 50, 20),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeLogNormal,
+				Seed:             42,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 20, len(results))
+
+		for i, result := range results {
+			require.Equal(t, len(buckets), len(result.Buckets))
+
+			for j, bucket := range result.Buckets {
+				assert.Equal(t, buckets[j], bucket.LE)
+				assert.LessOrEqual(t, bucket.Value, result.Count)
+				if j > 0 {
+					assert.LessOrEqual(t, result.Buckets[j-1].Value, bucket.Value)
+				}
+				if i > 0 {
+					assert.LessOrEqual(t, results[i-1].Buckets[j].Value, bucket.Value)
+				}
+			}
+
+			if i > 0 {
+				assert.LessOrEqual(t, results[i-1].Count, result.Count)
+				assert.LessOrEqual(t, results[i-1].Sum, result.Sum)
+			}
+		}
+
+		last := results[len(results)-1]
+		assert.InDelta(t, 1000, last.Count, 150)
+		assert.InDelta(t, 0.4, last.Sum/last.Count, 0.05)
+	})
+
+	t.Run("should not be affected by later changes to the buckets provided", func(t *testing.T) {
+		callerBuckets := []float64{0.1, 0.5, 1}
+
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 0.4, 1),
+				Spread:          helperConstantDataGenerator(t, 0.2, 1),
+				ObservationRate: helperConstantDataGenerator(t, 10, 1),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          callerBuckets,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             1,
+			},
+		)
+		require.NoError(t, err)
+
+		callerBuckets[0] = 100
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 1, len(results))
+		require.Equal(t, 3, len(results[0].Buckets))
+		assert.Equal(t, 0.1, results[0].Buckets[0].LE)
+	})
+
+	t.Run("should produce the same histograms given the same seed", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 0.3, 10),
+				ObservationRate: helperConstantDataGenerator(t, 20, 10),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.NoError(t, err)
+
+		seededDataGenerator := dataGenerator.WithSeed(7)
+
+		assert.Equal(t,
+			helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, seededDataGenerator.Iterator()),
+			helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, seededDataGenerator.Iterator()),
+		)
+	})
+
+	t.Run("should report missing scrapes and stop at the shortest parameter", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean: discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+					{Value: 0.2}, {Missing: true}, {Value: 0.2},
+				}),
+				Spread:          helperConstantDataGenerator(t, 0.05, 10),
+				ObservationRate: helperConstantDataGenerator(t, 10, 10),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             1,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 3, len(results))
+		assert.False(t, results[0].Missing)
+		assert.True(t, results[1].Missing)
+		assert.False(t, results[2].Missing)
+		assert.LessOrEqual(t, results[0].Count, results[2].Count)
+	})
+
+	t.Run("should report missing scrapes given a stale parameter", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:   helperConstantDataGenerator(t, 0.2, 3),
+				Spread: helperConstantDataGenerator(t, 0.05, 3),
+				ObservationRate: discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+					{Value: 10}, {Stale: true}, {Value: 10},
+				}),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             1,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 3, len(results))
+		assert.False(t, results[0].Missing)
+		assert.Equal(t, metrics.ScrapeHistogramResult{Missing: true}, results[1])
+		assert.False(t, results[2].Missing)
+		assert.Less(t, results[0].Count, results[2].Count)
+	})
+
+	t.Run("should report missing scrapes given a non-finite parameter", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean: discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+					{Value: 0.2}, {Value: 0.2}, {Value: math.Inf(1)}, {Value: 0.2},
+				}),
+				Spread: helperConstantDataGenerator(t, 0.05, 4),
+				ObservationRate: discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+					{Value: 10}, {Value: math.NaN()}, {Value: 10}, {Value: math.Inf(1)},
+				}),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             1,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 4, len(results))
+		assert.False(t, results[0].Missing)
+		assert.Equal(t, metrics.ScrapeHistogramResult{Missing: true}, results[1])
+		assert.Equal(t, metrics.ScrapeHistogramResult{Missing: true}, results[2])
+		assert.Equal(t, metrics.ScrapeHistogramResult{Missing: true}, results[3])
+	})
+
+	t.Run("should shift the observations when the mean changes", func(t *testing.T) {
+		// Simulates a latency regression: the mean goes from 100ms to 800ms halfway through.
+		mean := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			helperConstantDataGenerator(t, 0.1, 10),
+			helperConstantDataGenerator(t, 0.8, 10),
+		})
+
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            mean,
+				Spread:          helperConstantDataGenerator(t, 0.02, 20),
+				ObservationRate: helperConstantDataGenerator(t, 100, 20),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          buckets,
+				DistributionType: discrete.DistributionTypeLogNormal,
+				Seed:             3,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 20, len(results))
+
+		firstHalf := results[9]
+		secondHalf := results[19]
+
+		// Before the regression, nearly every observation falls in the 0.25 bucket.
+		assert.InDelta(t, firstHalf.Count, firstHalf.Buckets[1].Value, firstHalf.Count*0.01)
+
+		// After the regression, the new observations fall between the 0.5 and 1 buckets.
+		newObservations := secondHalf.Count - firstHalf.Count
+		assert.InDelta(t, 0, secondHalf.Buckets[2].Value-firstHalf.Buckets[2].Value, newObservations*0.01)
+		assert.InDelta(t, newObservations, secondHalf.Buckets[3].Value-firstHalf.Buckets[3].Value, newObservations*0.01)
+	})
+}
+
+func TestHistogramMetricTimeSeries(t *testing.T) {
+	dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+		discrete.HistogramDistributionParameters{
+			Mean:            helperConstantDataGenerator(t, 0.3, 3),
+			ObservationRate: helperConstantDataGenerator(t, 20, 3),
+		},
+		discrete.HistogramDataGeneratorOptions{
+			Buckets:          []float64{0.1, 0.5, 1},
+			DistributionType: discrete.DistributionTypeExponential,
+			Seed:             5,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("should send the last histogram forever given the send last value end strategy", func(t *testing.T) {
+		timeSeries := discrete.NewHistogramMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategySendLastValue())
+		assert.True(t, timeSeries.IsInfinite())
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, timeSeries.Iterator())
+		require.Equal(t, 100, len(results))
+		assert.Equal(t, results[2], results[99])
+	})
+
+	t.Run("should reset the histogram given the loop end strategy", func(t *testing.T) {
+		timeSeries := discrete.NewHistogramMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyLoop())
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataHistogramIterator, timeSeries.Iterator())
+		require.Equal(t, 100, len(results))
+		assert.Equal(t, results[0], results[3])
+	})
+}
@@ -4,8 +4,8 @@ import (
 	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
 )
 
-// Check at compile time whether JoinDataGenerator implements DataGenerator interface.
-var _ DataGenerator = (*JoinDataGenerator)(nil)
+// Check at compile time whether JoinDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*JoinDataGenerator)(nil)
 
 // JoinDataGenerator joins several segments together to form a bigger and more complex segments.
 // It joins all DataGenerators one after the next.
@@ -26,6 +26,24 @@ func (dg *JoinDataGenerator) Iterator() metrics.DataIterator {
 	}
 }
 
+// WithSeed returns a copy of the JoinDataGenerator where each child is seeded with a seed derived from the seed
+// provided.
+// A seed of zero returns the JoinDataGenerator unchanged, so children keep the seeds they were created with.
+func (dg *JoinDataGenerator) WithSeed(seed int64) DataGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	dataGenerators := make([]DataGenerator, len(dg.dataGenerators))
+	for i, dataGenerator := range dg.dataGenerators {
+		dataGenerators[i] = WithSeed(dataGenerator, DeriveSeed(seed, i))
+	}
+
+	return &JoinDataGenerator{
+		dataGenerators: dataGenerators,
+	}
+}
+
 func (dg *JoinDataGenerator) Describe() DataSpec {
 	var dataSpecs []DataSpec
 
@@ -81,6 +99,10 @@ func (ds JoinDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
 	return DataGeneratorNodeTypeJoin
 }
 
+func (ds JoinDataSpec) DataGeneratorType() DataGeneratorType {
+	return DataGeneratorTypeJoin
+}
+
 func (ds JoinDataSpec) Name() string {
 	return "Join"
 }
@@ -9,13 +9,13 @@ import (
 // LinearSegmentDataGeneratorOptions contains the options for the LinearSegmentDataGenerator.
 type LinearSegmentDataGeneratorOptions struct {
 	// AmplitudeStart represents the initial value for the segment.
-	AmplitudeStart float64
+	AmplitudeStart float64 `json:"amplitude_start" yaml:"amplitude_start"`
 
 	// AmplitudeEnd represents the end value for the segment.
-	AmplitudeEnd float64
+	AmplitudeEnd float64 `json:"amplitude_end" yaml:"amplitude_end"`
 
 	// IterationCountLimit sets the number of iterations to be used by the segment.
-	IterationCountLimit int
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
 }
 
 func (o *LinearSegmentDataGeneratorOptions) validate() error {
@@ -63,9 +63,7 @@ func (dg *LinearSegmentDataGenerator) Iterator() metrics.DataIterator {
 }
 
 func (dg *LinearSegmentDataGenerator) Describe() DataSpec {
-	return DataNodeDataSpec{
-		name: "Linear Segment",
-	}
+	return NewDataNodeDataSpec(DataGeneratorTypeLinearSegment, "Linear Segment", dg.options)
 }
 
 // Check at compile time whether LinearSegmentDataIterator implements metrics.DataIterator interface.
@@ -4,13 +4,18 @@ import (
 	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
 )
 
-// Check at compile time whether LoopDataGenerator implements DataGenerator interface.
-var _ DataGenerator = (*LoopDataGenerator)(nil)
+// Check at compile time whether LoopDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*LoopDataGenerator)(nil)
 
 // LoopDataGenerator loops over the DataGenerator N times.
+// If the LoopDataGenerator is seeded, each repetition of the DataGenerator is seeded with a different seed derived
+// from the loop seed, so repetitions differ from each other while the whole loop remains reproducible.
 type LoopDataGenerator struct {
 	dataGenerator DataGenerator
 	count         int
+
+	// seed is the seed used to derive the seeds for each repetition. Zero means the loop is not seeded.
+	seed int64
 }
 
 // NewLoopDataGenerator creates a new instance of LoopDataGenerator.
@@ -28,10 +33,21 @@ func (dg *LoopDataGenerator) Iterator() metrics.DataIterator {
 	}
 }
 
+// WithSeed returns a copy of the LoopDataGenerator where each repetition is seeded with a seed derived from the seed
+// provided.
+func (dg *LoopDataGenerator) WithSeed(seed int64) DataGenerator {
+	return &LoopDataGenerator{
+		dataGenerator: dg.dataGenerator,
+		count:         dg.count,
+		seed:          seed,
+	}
+}
+
 func (dg *LoopDataGenerator) Describe() DataSpec {
 	return LoopDataSpec{
 		Count: dg.count,
 		Func:  dg.dataGenerator.Describe(),
+		Seed:  dg.seed,
 	}
 }
 
@@ -51,7 +67,12 @@ type LoopDataIterator struct {
 func (ldi *LoopDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
 	for ; ldi.dataGeneratorLoopCount < ldi.loopDataGenerator.count; ldi.dataGeneratorLoopCount++ {
 		if ldi.dataIterator == nil {
-			ldi.dataIterator = ldi.loopDataGenerator.dataGenerator.Iterator()
+			dataGenerator := ldi.loopDataGenerator.dataGenerator
+			if ldi.loopDataGenerator.seed != 0 {
+				dataGenerator = WithSeed(dataGenerator, DeriveSeed(ldi.loopDataGenerator.seed, ldi.dataGeneratorLoopCount))
+			}
+
+			ldi.dataIterator = dataGenerator.Iterator()
 		}
 
 		result := ldi.dataIterator.Evaluate(scrapeInfo)
@@ -73,12 +94,19 @@ var _ DataSpec = (*LoopDataSpec)(nil)
 type LoopDataSpec struct {
 	Count int
 	Func  DataSpec
+
+	// Seed contains the seed set on the LoopDataGenerator, if any.
+	Seed int64
 }
 
 func (ds LoopDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
 	return DataGeneratorNodeTypeLoop
 }
 
+func (ds LoopDataSpec) DataGeneratorType() DataGeneratorType {
+	return DataGeneratorTypeLoop
+}
+
 func (ds LoopDataSpec) Name() string {
 	return "Loop"
 }
@@ -0,0 +1,146 @@
+package discrete
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// MeanRevertingSegmentDataGeneratorOptions contains the options for the MeanRevertingSegmentDataGenerator.
+type MeanRevertingSegmentDataGeneratorOptions struct {
+	// StartValue represents the first value returned by the process.
+	StartValue float64 `json:"start_value" yaml:"start_value"`
+
+	// Mean represents the long term mean the process reverts to.
+	Mean float64 `json:"mean" yaml:"mean"`
+
+	// ReversionStrength represents the fraction, in the range [0,1], of the distance to the Mean the process covers on
+	// each iteration. Zero turns the process into a plain random walk, one makes the process forget its previous value.
+	ReversionStrength float64 `json:"reversion_strength" yaml:"reversion_strength"`
+
+	// Volatility represents the standard deviation of the random shock applied on each iteration.
+	Volatility float64 `json:"volatility" yaml:"volatility"`
+
+	// Bounds optionally restricts the process to a given interval.
+	Bounds *WalkBounds `json:"bounds,omitempty" yaml:"bounds,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the shocks are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o *MeanRevertingSegmentDataGeneratorOptions) validate() error {
+	if o.ReversionStrength < 0 || o.ReversionStrength > 1 {
+		return fmt.Errorf("reversion strength must be in the range [0,1]")
+	}
+
+	if o.Volatility < 0 {
+		return fmt.Errorf("volatility cannot be less than zero")
+	}
+
+	if o.Bounds != nil {
+		if err := o.Bounds.validate(o.StartValue); err != nil {
+			return err
+		}
+	}
+
+	if o.IterationCountLimit <= 0 {
+		return fmt.Errorf("iteration count limit cannot be less than or equal to zero")
+	}
+
+	return nil
+}
+
+// Check at compile time whether MeanRevertingSegmentDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*MeanRevertingSegmentDataGenerator)(nil)
+
+// MeanRevertingSegmentDataGenerator returns a DataGenerator representing a mean-reverting process (discrete
+// Ornstein–Uhlenbeck process).
+// On each iteration the value moves towards the Mean by a fraction of the distance, given by the ReversionStrength,
+// and is then shocked by a normally distributed random value, given by the Volatility:
+//
+//	value[n+1] = value[n] + ReversionStrength*(Mean-value[n]) + Volatility*N(0,1)
+//
+// This is useful to simulate metrics like memory usage or CPU usage, which drift around a stable value.
+// Note that it's an error to use negative values with counters. It's the user responsibility to make sure negative
+// numbers only appear in gauges.
+// The zero value is not useful.
+type MeanRevertingSegmentDataGenerator struct {
+	options MeanRevertingSegmentDataGeneratorOptions
+}
+
+// NewMeanRevertingSegmentDataGenerator returns a new instance of MeanRevertingSegmentDataGenerator.
+func NewMeanRevertingSegmentDataGenerator(options MeanRevertingSegmentDataGeneratorOptions) (*MeanRevertingSegmentDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &MeanRevertingSegmentDataGenerator{}, fmt.Errorf("error validating mean reverting segment data generator configuration: %w", err)
+	}
+
+	return &MeanRevertingSegmentDataGenerator{
+		options: options,
+	}, nil
+}
+
+func (dg *MeanRevertingSegmentDataGenerator) Iterator() metrics.DataIterator {
+	return &MeanRevertingSegmentDataIterator{
+		meanRevertingSegmentDataGenerator: *dg,
+		rand:                              random.NewRand(dg.options.Seed),
+		value:                             dg.options.StartValue,
+	}
+}
+
+func (dg *MeanRevertingSegmentDataGenerator) WithSeed(seed int64) DataGenerator {
+	options := dg.options
+	options.Seed = seed
+
+	return &MeanRevertingSegmentDataGenerator{
+		options: options,
+	}
+}
+
+func (dg *MeanRevertingSegmentDataGenerator) Describe() DataSpec {
+	return NewDataNodeDataSpec(DataGeneratorTypeMeanRevertingSegment, "Mean Reverting Segment", dg.options)
+}
+
+// Check at compile time whether MeanRevertingSegmentDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*MeanRevertingSegmentDataIterator)(nil)
+
+type MeanRevertingSegmentDataIterator struct {
+	// read-only access
+	meanRevertingSegmentDataGenerator MeanRevertingSegmentDataGenerator
+
+	// rand is the source of randomness for this iterator.
+	rand *rand.Rand
+
+	// iterIndex keeps track of the current iteration.
+	iterIndex int
+
+	// value is the current value of the process.
+	value float64
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *MeanRevertingSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	options := di.meanRevertingSegmentDataGenerator.options
+
+	// Have we reached the end?
+	if di.iterIndex >= options.IterationCountLimit {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	// Make sure to increment the iterator index before leaving the function
+	defer func() { di.iterIndex++ }()
+
+	// The first sample is always the start value
+	if di.iterIndex > 0 {
+		drift := options.ReversionStrength * (options.Mean - di.value)
+		shock := di.rand.NormFloat64() * options.Volatility
+		di.value = options.Bounds.apply(di.value + drift + shock)
+	}
+
+	return metrics.ScrapeResult{Value: di.value}
+}
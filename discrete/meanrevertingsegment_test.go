@@ -0,0 +1,83 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestMeanRevertingSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given that reversion strength is out of range", func(t *testing.T) {
+		_, err := discrete.NewMeanRevertingSegmentDataGenerator(discrete.MeanRevertingSegmentDataGeneratorOptions{
+			Mean:                10,
+			ReversionStrength:   2,
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating mean reverting segment data generator configuration: reversion strength must be in the range [0,1]"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should converge towards the mean given no volatility", func(t *testing.T) {
+		dataGenerator, err := discrete.NewMeanRevertingSegmentDataGenerator(discrete.MeanRevertingSegmentDataGeneratorOptions{
+			StartValue:          0,
+			Mean:                100,
+			ReversionStrength:   0.5,
+			IterationCountLimit: 4,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 4, len(results))
+		assert.InDelta(t, 0, results[0].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 50, results[1].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 75, results[2].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 87.5, results[3].scrapeResult.Value, 0.001)
+	})
+
+	t.Run("should hover around the mean given volatility", func(t *testing.T) {
+		dataGenerator, err := discrete.NewMeanRevertingSegmentDataGenerator(discrete.MeanRevertingSegmentDataGeneratorOptions{
+			StartValue:          100,
+			Mean:                100,
+			ReversionStrength:   0.3,
+			Volatility:          2,
+			IterationCountLimit: 100,
+			Seed:                11,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 100, len(results))
+
+		sum := 0.0
+		for _, result := range results {
+			sum += result.scrapeResult.Value
+		}
+		assert.InDelta(t, 100, sum/float64(len(results)), 2)
+	})
+
+	t.Run("should keep the process within bounds", func(t *testing.T) {
+		dataGenerator, err := discrete.NewMeanRevertingSegmentDataGenerator(discrete.MeanRevertingSegmentDataGeneratorOptions{
+			StartValue:          50,
+			Mean:                50,
+			ReversionStrength:   0.1,
+			Volatility:          30,
+			Bounds:              &discrete.WalkBounds{Min: 0, Max: 100, BoundaryType: discrete.BoundaryTypeReflect},
+			IterationCountLimit: 100,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 100, len(results))
+		for _, result := range results {
+			assert.GreaterOrEqual(t, result.scrapeResult.Value, 0.0)
+			assert.LessOrEqual(t, result.scrapeResult.Value, 100.0)
+		}
+	})
+}
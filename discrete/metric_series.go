@@ -30,9 +30,12 @@ func NewMetricTimeSeries(labels map[string]string, data DataGenerator, endStrate
 
 // Iterator returns a time series iterator that can be used to iterate over the data.
 func (ts *MetricTimeSeries) Iterator() metrics.DataIterator {
+	dataGenerator := ts.dataGenerator
+
 	return &MetricTimeSeriesDataIterator{
-		timeseries: *ts,
-		state:      metrics.TimeSeriesIteratorStateRunning,
+		timeSeriesIterator: metrics.NewTimeSeriesIterator(func() metrics.Evaluator[metrics.ScrapeResult] {
+			return dataGenerator.Iterator()
+		}, ts.endStrategy),
 	}
 }
 
@@ -50,63 +53,14 @@ func (ts *MetricTimeSeries) IsInfinite() bool {
 // Check at compile time whether MetricTimeSeriesDataIterator implements metrics.DataIterator interface.
 var _ metrics.DataIterator = (*MetricTimeSeriesDataIterator)(nil)
 
+// MetricTimeSeriesDataIterator iterates over the data of a MetricTimeSeries.
+// The end strategy is evaluated by metrics.TimeSeriesIterator.
 type MetricTimeSeriesDataIterator struct {
-	timeseries MetricTimeSeries
-
-	// currentDataIterator contains the DataIterator for the current run (in case we use a loop over strategy)
-	currentDataIterator metrics.DataIterator
-
-	// Reports whether we are evaluating data or we are in the end strategy stage
-	state metrics.TimeSeriesIteratorState
-
-	// loopCount keeps track of how many times we've looped over the DataGenerator
-	// For example, if loopCount is 1, it means we've cycled through the iterator once and may be in the middle of
-	// cycling through the iterator for a second time.
-	loopCount int
-
-	// lastValue represents the last value returned by the DataIterator
-	lastValue metrics.ScrapeResult
+	timeSeriesIterator *metrics.TimeSeriesIterator[metrics.ScrapeResult]
 }
 
 // Evaluate fulfills the metrics.DataIterator interface.
 // This function is responsible for returning the data points one at a time.
 func (di *MetricTimeSeriesDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
-	// Need the loop as when we reach the end of the iterator, regardless of what the end strategy is, we need to
-	// evaluate the logic again, after setting the iterator state.
-	for {
-		if di.state == metrics.TimeSeriesIteratorStateEndStrategy {
-			switch di.timeseries.endStrategy.EndStrategyType {
-			case metrics.EndStrategyTypeLoop:
-				di.currentDataIterator = nil
-				di.loopCount++
-				di.state = metrics.TimeSeriesIteratorStateRunning
-				continue // it would be safe to let the logic run through as well
-			case metrics.EndStrategyTypeSendLastValue:
-				return di.lastValue
-			case metrics.EndStrategyTypeSendCustomValue:
-				return di.timeseries.endStrategy.CustomValue()
-			case metrics.EndStrategyTypeRemoveTimeSeries:
-				return metrics.ScrapeResult{Exhausted: true}
-			default:
-				// if the end strategy hasn't been set somehow, default to removing time series
-				return metrics.ScrapeResult{Exhausted: true}
-			}
-		}
-
-		// if we don't have an iterator, get one
-		if di.currentDataIterator == nil {
-			di.currentDataIterator = di.timeseries.dataGenerator.Iterator()
-		}
-
-		result := di.currentDataIterator.Evaluate(scrapeInfo)
-
-		// We reached the end of the iterator
-		if result.Exhausted {
-			di.state = metrics.TimeSeriesIteratorStateEndStrategy
-			continue
-		}
-
-		di.lastValue = result
-		return result
-	}
+	return di.timeSeriesIterator.Evaluate(scrapeInfo)
 }
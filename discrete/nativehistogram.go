@@ -0,0 +1,274 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+const (
+	// nativeHistogramSchemaMin is the lowest (coarsest) schema supported by native histograms.
+	nativeHistogramSchemaMin = -4
+	// nativeHistogramSchemaMax is the highest (finest) schema supported by native histograms.
+	nativeHistogramSchemaMax = 8
+
+	// nativeHistogramMaxSpanGap is the largest number of empty buckets included in a span, instead of starting a new one.
+	nativeHistogramMaxSpanGap = 2
+)
+
+// DataNativeHistogramGenerator generates native histogram data according to the generator.
+// It's meant to be used by Native Histogram metrics.
+type DataNativeHistogramGenerator interface {
+	Iterator() metrics.DataNativeHistogramIterator
+}
+
+// NativeHistogramDataGeneratorOptions contains the options for the DistributionNativeHistogramDataGenerator.
+type NativeHistogramDataGeneratorOptions struct {
+	// Schema defines the resolution of the buckets. Valid values go from -4 to 8.
+	// Each power of two is divided into 2^Schema buckets. A schema of 3 is a common choice, which results in buckets
+	// growing by roughly 9% each.
+	Schema int32
+
+	// ZeroThreshold is the width of the zero bucket. Observations in the interval [-ZeroThreshold,ZeroThreshold] are
+	// counted in the zero bucket.
+	ZeroThreshold float64
+
+	// DistributionType sets the distribution the observations are drawn from.
+	// Only the normal, log-normal and exponential distributions are supported.
+	DistributionType DistributionType
+
+	// Seed seeds the source of randomness used to draw the observations, see SeedableDataGenerator.
+	Seed int64
+}
+
+func (o *NativeHistogramDataGeneratorOptions) validate() error {
+	if o.Schema < nativeHistogramSchemaMin || o.Schema > nativeHistogramSchemaMax {
+		return fmt.Errorf("schema must be in the range [%d,%d]", nativeHistogramSchemaMin, nativeHistogramSchemaMax)
+	}
+
+	if o.ZeroThreshold < 0 || math.IsInf(o.ZeroThreshold, 0) || math.IsNaN(o.ZeroThreshold) {
+		return fmt.Errorf("zero threshold must be a finite number greater than or equal to zero")
+	}
+
+	switch o.DistributionType {
+	case DistributionTypeNormal, DistributionTypeLogNormal, DistributionTypeExponential:
+	default:
+		return fmt.Errorf("distribution type %q is not supported", o.DistributionType)
+	}
+
+	return nil
+}
+
+// Check at compile time whether DistributionNativeHistogramDataGenerator implements DataNativeHistogramGenerator
+// interface.
+var _ DataNativeHistogramGenerator = (*DistributionNativeHistogramDataGenerator)(nil)
+
+// DistributionNativeHistogramDataGenerator returns a DataNativeHistogramGenerator representing a native histogram whose
+// observations are drawn from a statistical distribution.
+// It behaves exactly like the DistributionHistogramDataGenerator, the only difference being that the observations are
+// recorded in exponential buckets, whose resolution is set by the schema, instead of in fixed buckets.
+// The zero value is not useful. Use NewDistributionNativeHistogramDataGenerator function.
+type DistributionNativeHistogramDataGenerator struct {
+	parameters HistogramDistributionParameters
+	options    NativeHistogramDataGeneratorOptions
+}
+
+// NewDistributionNativeHistogramDataGenerator returns a new instance of DistributionNativeHistogramDataGenerator.
+func NewDistributionNativeHistogramDataGenerator(parameters HistogramDistributionParameters, options NativeHistogramDataGeneratorOptions) (*DistributionNativeHistogramDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &DistributionNativeHistogramDataGenerator{}, fmt.Errorf("error validating distribution native histogram data generator configuration: %w", err)
+	}
+
+	if err := parameters.validate(options.DistributionType); err != nil {
+		return &DistributionNativeHistogramDataGenerator{}, fmt.Errorf("error validating distribution native histogram data generator configuration: %w", err)
+	}
+
+	return &DistributionNativeHistogramDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}, nil
+}
+
+func (dg *DistributionNativeHistogramDataGenerator) Iterator() metrics.DataNativeHistogramIterator {
+	return &DistributionNativeHistogramDataIterator{
+		distributionNativeHistogramDataGenerator: *dg,
+		rand:                                     random.NewRand(dg.options.Seed),
+		positiveBuckets:                          make(map[int]uint64),
+		negativeBuckets:                          make(map[int]uint64),
+	}
+}
+
+// WithSeed returns a copy of the DistributionNativeHistogramDataGenerator seeded with the seed provided.
+// The parameters are seeded with seeds derived from the seed provided.
+// A seed of zero returns the DistributionNativeHistogramDataGenerator unchanged, so the parameters keep their own
+// seeds.
+func (dg *DistributionNativeHistogramDataGenerator) WithSeed(seed int64) DataNativeHistogramGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	options := dg.options
+	options.Seed = seed
+
+	parameters := HistogramDistributionParameters{
+		Mean:            WithSeed(dg.parameters.Mean, DeriveSeed(seed, 0)),
+		ObservationRate: WithSeed(dg.parameters.ObservationRate, DeriveSeed(seed, 2)),
+	}
+	if dg.parameters.Spread != nil {
+		parameters.Spread = WithSeed(dg.parameters.Spread, DeriveSeed(seed, 1))
+	}
+
+	return &DistributionNativeHistogramDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}
+}
+
+// Check at compile time whether DistributionNativeHistogramDataIterator implements metrics.DataNativeHistogramIterator
+// interface.
+var _ metrics.DataNativeHistogramIterator = (*DistributionNativeHistogramDataIterator)(nil)
+
+type DistributionNativeHistogramDataIterator struct {
+	// read-only access
+	distributionNativeHistogramDataGenerator DistributionNativeHistogramDataGenerator
+
+	// these variables keep track of the current state of the iterator
+	parametersIterator *histogramParametersIterator
+	rand               *rand.Rand
+
+	// positiveBuckets and negativeBuckets contain the count of each bucket, indexed by the bucket index.
+	positiveBuckets map[int]uint64
+	negativeBuckets map[int]uint64
+	zeroCount       uint64
+	count           uint64
+	sum             float64
+}
+
+// Evaluate fulfills the metrics.DataNativeHistogramIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *DistributionNativeHistogramDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeNativeHistogramResult {
+	if di.parametersIterator == nil {
+		di.parametersIterator = newHistogramParametersIterator(di.distributionNativeHistogramDataGenerator.parameters)
+	}
+
+	parameters := di.parametersIterator.evaluate(scrapeInfo)
+
+	if parameters.exhausted {
+		return metrics.ScrapeNativeHistogramResult{Exhausted: true}
+	}
+
+	if parameters.missing {
+		return metrics.ScrapeNativeHistogramResult{Missing: true}
+	}
+
+	sampleObservations(di.rand, di.distributionNativeHistogramDataGenerator.options.DistributionType, parameters, di.observe)
+
+	return di.result()
+}
+
+// observe records the observation in the histogram.
+func (di *DistributionNativeHistogramDataIterator) observe(observation float64) {
+	options := di.distributionNativeHistogramDataGenerator.options
+
+	switch {
+	case math.Abs(observation) <= options.ZeroThreshold:
+		di.zeroCount++
+	case observation > 0:
+		di.positiveBuckets[nativeHistogramBucketIndex(observation, options.Schema)]++
+	default:
+		di.negativeBuckets[nativeHistogramBucketIndex(-observation, options.Schema)]++
+	}
+
+	di.count++
+	di.sum += observation
+}
+
+func (di *DistributionNativeHistogramDataIterator) result() metrics.ScrapeNativeHistogramResult {
+	options := di.distributionNativeHistogramDataGenerator.options
+
+	positiveSpans, positiveDeltas := encodeNativeHistogramBuckets(di.positiveBuckets)
+	negativeSpans, negativeDeltas := encodeNativeHistogramBuckets(di.negativeBuckets)
+
+	return metrics.ScrapeNativeHistogramResult{
+		Schema:         options.Schema,
+		ZeroThreshold:  options.ZeroThreshold,
+		ZeroCount:      di.zeroCount,
+		Count:          di.count,
+		Sum:            di.sum,
+		PositiveSpans:  positiveSpans,
+		PositiveDeltas: positiveDeltas,
+		NegativeSpans:  negativeSpans,
+		NegativeDeltas: negativeDeltas,
+	}
+}
+
+// nativeHistogramBucketIndex returns the index of the bucket the positive value falls into, given the schema.
+// The bucket with index i covers the interval ]2^((i-1)*2^-schema),2^(i*2^-schema)].
+// It follows the same logic as the prometheus client library, so exact powers of two end up in the same buckets.
+func nativeHistogramBucketIndex(value float64, schema int32) int {
+	frac, exp := math.Frexp(value)
+
+	if schema > 0 {
+		bucketsPerPowerOfTwo := 1 << schema
+		// The fraction is in the interval [0.5,1[, find the first bound greater than or equal to it.
+		index := sort.Search(bucketsPerPowerOfTwo, func(i int) bool {
+			return math.Exp2(float64(i)/float64(bucketsPerPowerOfTwo)-1) >= frac
+		})
+		return index + (exp-1)*bucketsPerPowerOfTwo
+	}
+
+	index := exp
+	// Exact powers of two are the upper bound of their bucket.
+	if frac == 0.5 {
+		index--
+	}
+	offset := (1 << -schema) - 1
+	return (index + offset) >> -schema
+}
+
+// encodeNativeHistogramBuckets encodes the buckets as spans of consecutive buckets and the deltas between the counts of
+// consecutive buckets.
+// Gaps of up to nativeHistogramMaxSpanGap empty buckets don't start a new span, just like in the prometheus client
+// library.
+func encodeNativeHistogramBuckets(buckets map[int]uint64) ([]metrics.NativeHistogramBucketSpan, []int64) {
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	indexes := make([]int, 0, len(buckets))
+	for index := range buckets {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var spans []metrics.NativeHistogramBucketSpan
+	deltas := make([]int64, 0, len(indexes))
+
+	var previousCount int64
+	for i, index := range indexes {
+		switch {
+		case i == 0:
+			spans = append(spans, metrics.NativeHistogramBucketSpan{Offset: int32(index), Length: 1})
+		case index-indexes[i-1] <= nativeHistogramMaxSpanGap+1:
+			// Small gaps are filled with empty buckets, which is cheaper than starting a new span.
+			for gap := index - indexes[i-1] - 1; gap > 0; gap-- {
+				deltas = append(deltas, -previousCount)
+				previousCount = 0
+				spans[len(spans)-1].Length++
+			}
+			spans[len(spans)-1].Length++
+		default:
+			spans = append(spans, metrics.NativeHistogramBucketSpan{Offset: int32(index - indexes[i-1] - 1), Length: 1})
+		}
+
+		count := int64(buckets[index])
+		deltas = append(deltas, count-previousCount)
+		previousCount = count
+	}
+
+	return spans, deltas
+}
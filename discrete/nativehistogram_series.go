@@ -0,0 +1,72 @@
+package discrete
+
+import (
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+// Check at compile time whether NativeHistogramMetricTimeSeries implements
+// promadapter.NativeHistogramMetricTimeSeriesObservable interface.
+var _ promadapter.NativeHistogramMetricTimeSeriesObservable = (*NativeHistogramMetricTimeSeries)(nil)
+
+// NativeHistogramMetricTimeSeries represents a native histogram metric time series.
+// When the time series iterator gets to the end of the DataNativeHistogramGenerator provided it will evaluate the
+// metrics.EndStrategy to decide on what to do next.
+// Since the end strategy custom value is not a histogram, the metrics.EndStrategyTypeSendCustomValue strategy only
+// honours the Missing and Exhausted fields of the custom value, otherwise it behaves like
+// metrics.EndStrategyTypeSendLastValue.
+// Looping starts a new iterator, which resets the histogram, just like an application restart would.
+// The zero value of NativeHistogramMetricTimeSeries is not useful. Use NewNativeHistogramMetricTimeSeries function.
+type NativeHistogramMetricTimeSeries struct {
+	labels map[string]string
+
+	dataNativeHistogramGenerator DataNativeHistogramGenerator
+	endStrategy                  metrics.EndStrategy
+}
+
+// NewNativeHistogramMetricTimeSeries creates a new instance of NativeHistogramMetricTimeSeries.
+func NewNativeHistogramMetricTimeSeries(labels map[string]string, data DataNativeHistogramGenerator, endStrategy metrics.EndStrategy) *NativeHistogramMetricTimeSeries {
+	return &NativeHistogramMetricTimeSeries{
+		labels:                       labels,
+		dataNativeHistogramGenerator: data,
+		endStrategy:                  endStrategy,
+	}
+}
+
+// Iterator returns a time series iterator that can be used to iterate over the data.
+func (ts *NativeHistogramMetricTimeSeries) Iterator() metrics.DataNativeHistogramIterator {
+	dataNativeHistogramGenerator := ts.dataNativeHistogramGenerator
+
+	return &NativeHistogramMetricTimeSeriesDataIterator{
+		timeSeriesIterator: metrics.NewTimeSeriesIterator(func() metrics.Evaluator[metrics.ScrapeNativeHistogramResult] {
+			return dataNativeHistogramGenerator.Iterator()
+		}, ts.endStrategy),
+	}
+}
+
+// Labels returns the labels associated with the time series.
+func (ts *NativeHistogramMetricTimeSeries) Labels() map[string]string {
+	return ts.labels
+}
+
+// IsInfinite reports whether this time series is infinite.
+// In other words, whether this time series will never stop generating samples.
+func (ts *NativeHistogramMetricTimeSeries) IsInfinite() bool {
+	return ts.endStrategy.EndStrategyType != metrics.EndStrategyTypeRemoveTimeSeries
+}
+
+// Check at compile time whether NativeHistogramMetricTimeSeriesDataIterator implements
+// metrics.DataNativeHistogramIterator interface.
+var _ metrics.DataNativeHistogramIterator = (*NativeHistogramMetricTimeSeriesDataIterator)(nil)
+
+// NativeHistogramMetricTimeSeriesDataIterator iterates over the data of a NativeHistogramMetricTimeSeries.
+// The end strategy is evaluated by metrics.TimeSeriesIterator.
+type NativeHistogramMetricTimeSeriesDataIterator struct {
+	timeSeriesIterator *metrics.TimeSeriesIterator[metrics.ScrapeNativeHistogramResult]
+}
+
+// Evaluate fulfills the metrics.DataNativeHistogramIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *NativeHistogramMetricTimeSeriesDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeNativeHistogramResult {
+	return di.timeSeriesIterator.Evaluate(scrapeInfo)
+}
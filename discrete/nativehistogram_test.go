@@ -0,0 +1,132 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestDistributionNativeHistogramDataIterator(t *testing.T) {
+	t.Run("should fail given a schema out of range", func(t *testing.T) {
+		_, err := discrete.NewDistributionNativeHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.NativeHistogramDataGeneratorOptions{
+				Schema:           9,
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution native histogram data generator configuration: schema must be in the range [-4,8]"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should encode the buckets as spans and deltas", func(t *testing.T) {
+		// With a spread of zero, every observation is equal to the mean. Given a schema of zero, the observations fall
+		// into the buckets ]1,2] (index 1), ]32,64] (index 6), [-4,-2[ (index 2) and the zero bucket.
+		mean := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{
+			{Value: 1.5}, {Value: 40}, {Value: -3}, {Value: 0.0005},
+		})
+
+		dataGenerator, err := discrete.NewDistributionNativeHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            mean,
+				Spread:          helperConstantDataGenerator(t, 0, 4),
+				ObservationRate: helperConstantDataGenerator(t, 30, 4),
+			},
+			discrete.NativeHistogramDataGeneratorOptions{
+				Schema:           0,
+				ZeroThreshold:    0.001,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             11,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataNativeHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, 4, len(results))
+
+		first := results[0]
+		assert.Equal(t, int32(0), first.Schema)
+		assert.Equal(t, 0.001, first.ZeroThreshold)
+		assert.Equal(t, []metrics.NativeHistogramBucketSpan{{Offset: 1, Length: 1}}, first.PositiveSpans)
+		assert.Equal(t, []int64{int64(first.Count)}, first.PositiveDeltas)
+
+		second := results[1]
+		secondObservations := int64(second.Count - first.Count)
+		assert.Equal(t, []metrics.NativeHistogramBucketSpan{{Offset: 1, Length: 1}, {Offset: 4, Length: 1}}, second.PositiveSpans)
+		assert.Equal(t, []int64{int64(first.Count), secondObservations - int64(first.Count)}, second.PositiveDeltas)
+
+		third := results[2]
+		assert.Equal(t, []metrics.NativeHistogramBucketSpan{{Offset: 2, Length: 1}}, third.NegativeSpans)
+		assert.Equal(t, []int64{int64(third.Count - second.Count)}, third.NegativeDeltas)
+
+		last := results[3]
+		assert.Equal(t, last.Count-third.Count, last.ZeroCount)
+		assert.InDelta(t, 1.5*float64(first.Count)+40*float64(secondObservations)-3*float64(third.Count-second.Count)+0.0005*float64(last.ZeroCount), last.Sum, 0.001)
+	})
+
+	t.Run("should bucket the observations exactly like the prometheus client library", func(t *testing.T) {
+		// With a spread of zero, every observation is equal to the mean, hence the observations of each scrape are known.
+		// Exact powers of two and values close to the bucket boundaries are included.
+		means := []float64{0.25, 0.3, 1, 1.0905, 1.0906, 2, 3.7, 16, 100.5, 0.01}
+
+		customValueSamples := make([]discrete.CustomValueSample, len(means))
+		for i, mean := range means {
+			customValueSamples[i] = discrete.CustomValueSample{Value: mean}
+		}
+
+		dataGenerator, err := discrete.NewDistributionNativeHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            discrete.NewCustomValuesDataGenerator(customValueSamples),
+				Spread:          helperConstantDataGenerator(t, 0, len(means)),
+				ObservationRate: helperConstantDataGenerator(t, 5, len(means)),
+			},
+			discrete.NativeHistogramDataGeneratorOptions{
+				Schema:           3,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             17,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataNativeHistogramIterator, dataGenerator.Iterator())
+		require.Equal(t, len(means), len(results))
+
+		histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:                        "test",
+			NativeHistogramBucketFactor: 1.1, // resolves to schema 3
+		})
+
+		var previousCount uint64
+		for i, result := range results {
+			for j := previousCount; j < result.Count; j++ {
+				histogram.Observe(means[i])
+			}
+			previousCount = result.Count
+		}
+
+		metric := &dto.Metric{}
+		err = histogram.Write(metric)
+		require.NoError(t, err)
+
+		last := results[len(results)-1]
+		assert.Equal(t, metric.GetHistogram().GetSchema(), last.Schema)
+		assert.Equal(t, metric.GetHistogram().GetSampleCount(), last.Count)
+		assert.Equal(t, metric.GetHistogram().GetPositiveDelta(), last.PositiveDeltas)
+
+		require.Equal(t, len(metric.GetHistogram().GetPositiveSpan()), len(last.PositiveSpans))
+		for i, span := range metric.GetHistogram().GetPositiveSpan() {
+			assert.Equal(t, span.GetOffset(), last.PositiveSpans[i].Offset)
+			assert.Equal(t, span.GetLength(), last.PositiveSpans[i].Length)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseSeriesNotation parses a series written in the promtool 'input_series' notation and returns the equivalent
+// DataGenerator.
+// The notation is a whitespace separated list of items, where each item is one of:
+//   - 'a' represents a single sample with the value a.
+//   - 'a+bxn' represents n+1 samples, starting at a and incrementing by b on each sample: a, a+b, a+2b, ..., a+nb.
+//   - 'a-bxn' represents n+1 samples, starting at a and decrementing by b on each sample: a, a-b, a-2b, ..., a-nb.
+//   - 'axn' represents n+1 samples with the value a. It's a shorthand for 'a+0xn'.
+//   - '_' represents a missing sample.
+//   - '_xn' represents n missing samples.
+//   - 'stale' represents a stale marker. Just like in promtool, it cannot be repeated nor incremented.
+//
+// Values are parsed as floats, which means values like '1e3', 'Inf' and 'NaN' are accepted.
+// Stale markers are represented as stale samples, so the time series can carry on afterwards.
+//
+// The returned DataGenerator is a JoinDataGenerator made up of LinearSegmentDataGenerators (ranges),
+// VoidSegmentDataGenerators (repeated missing samples) and CustomValuesSegmentDataGenerators (individual samples and
+// stale samples).
+//
+// Example:
+//
+//	dataGenerator, err := ParseSeriesNotation("0+10x100 _x5 stale")
+func ParseSeriesNotation(notation string) (DataGenerator, error) {
+	var dataGenerators []DataGenerator
+
+	// pendingValues accumulates consecutive individual samples so they can be grouped in a single segment.
+	var pendingValues []CustomValueSample
+
+	flushPendingValues := func() {
+		if len(pendingValues) == 0 {
+			return
+		}
+		dataGenerators = append(dataGenerators, NewCustomValuesDataGenerator(pendingValues))
+		pendingValues = nil
+	}
+
+	for _, item := range strings.Fields(notation) {
+		head, times, repeated, err := splitSeriesItem(item)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing series notation item %q: %w", item, err)
+		}
+
+		// missing samples
+		if head == "_" {
+			if !repeated {
+				pendingValues = append(pendingValues, CustomValueSample{Missing: true})
+				continue
+			}
+
+			flushPendingValues()
+			dataGenerators = append(dataGenerators, NewVoidSegmentDataGenerator(times))
+			continue
+		}
+
+		start, step, hasStep, err := parseSeriesExpression(head)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing series notation item %q: %w", item, err)
+		}
+
+		if hasStep && !repeated {
+			return nil, fmt.Errorf("error parsing series notation item %q: increments must be followed by a repetition", item)
+		}
+
+		// stale samples
+		if start.Stale {
+			if hasStep {
+				return nil, fmt.Errorf("error parsing series notation item %q: stale samples cannot be incremented", item)
+			}
+
+			if repeated {
+				return nil, fmt.Errorf("error parsing series notation item %q: stale samples cannot be repeated", item)
+			}
+
+			pendingValues = append(pendingValues, start)
+			continue
+		}
+
+		if !repeated {
+			pendingValues = append(pendingValues, start)
+			continue
+		}
+
+		flushPendingValues()
+		lsDataGenerator, err := NewLinearSegmentDataGenerator(LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      start.Value,
+			AmplitudeEnd:        start.Value + step*float64(times),
+			IterationCountLimit: times + 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error parsing series notation item %q: %w", item, err)
+		}
+		dataGenerators = append(dataGenerators, lsDataGenerator)
+	}
+
+	flushPendingValues()
+
+	return NewJoinDataGenerator(dataGenerators), nil
+}
+
+// splitSeriesItem splits an item into the expression and the repetition count, if there is one.
+func splitSeriesItem(item string) (head string, times int, repeated bool, err error) {
+	index := strings.LastIndex(item, "x")
+	if index == -1 {
+		return item, 0, false, nil
+	}
+
+	times, err = strconv.Atoi(item[index+1:])
+	if err != nil || times < 0 {
+		return "", 0, false, fmt.Errorf("repetition count must be a non-negative integer")
+	}
+
+	return item[:index], times, true, nil
+}
+
+// parseSeriesExpression parses expressions in the format 'a', 'a+b' or 'a-b'.
+// The start value is returned as a CustomValueSample so stale samples can be flagged as such.
+func parseSeriesExpression(expression string) (start CustomValueSample, step float64, hasStep bool, err error) {
+	// Look for the operator separating the start value from the step. The first character is skipped, since it may be
+	// the sign of the start value, and so are signs belonging to exponents (ex: 1e-3).
+	operatorIndex := -1
+	for i := 1; i < len(expression); i++ {
+		if (expression[i] == '+' || expression[i] == '-') && expression[i-1] != 'e' && expression[i-1] != 'E' {
+			operatorIndex = i
+			break
+		}
+	}
+
+	startExpression := expression
+	if operatorIndex != -1 {
+		startExpression = expression[:operatorIndex]
+	}
+
+	if startExpression == "stale" {
+		start = CustomValueSample{Stale: true}
+	} else {
+		value, err := strconv.ParseFloat(startExpression, 64)
+		if err != nil {
+			return CustomValueSample{}, 0, false, fmt.Errorf("invalid value %q", startExpression)
+		}
+		start = CustomValueSample{Value: value}
+	}
+
+	if operatorIndex == -1 {
+		return start, 0, false, nil
+	}
+
+	step, err = strconv.ParseFloat(expression[operatorIndex+1:], 64)
+	if err != nil || step < 0 {
+		return CustomValueSample{}, 0, false, fmt.Errorf("invalid increment %q", expression[operatorIndex+1:])
+	}
+
+	if expression[operatorIndex] == '-' {
+		step = -step
+	}
+
+	return start, step, true, nil
+}
+
+// FormatSeriesNotation renders a DataGenerator in the promtool 'input_series' notation.
+// Only the DataGenerators that can be expressed in the notation are supported, namely the
+// LinearSegmentDataGenerator, the VoidSegmentDataGenerator, the CustomValuesSegmentDataGenerator, the
+// JoinDataGenerator and the LoopDataGenerator (which is unrolled). An error is returned for any other DataGenerator.
+// Missing samples are rendered as '_' and stale samples as 'stale'.
+func FormatSeriesNotation(dataGenerator DataGenerator) (string, error) {
+	items, err := formatSeriesItems(dataGenerator, nil)
+	if err != nil {
+		return "", fmt.Errorf("error formatting series notation: %w", err)
+	}
+
+	return strings.Join(items, " "), nil
+}
+
+func formatSeriesItems(dataGenerator DataGenerator, items []string) ([]string, error) {
+	var err error
+
+	switch dg := dataGenerator.(type) {
+	case *JoinDataGenerator:
+		for _, child := range dg.dataGenerators {
+			items, err = formatSeriesItems(child, items)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *LoopDataGenerator:
+		for i := 0; i < dg.count; i++ {
+			items, err = formatSeriesItems(dg.dataGenerator, items)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case *LinearSegmentDataGenerator:
+		return append(items, formatLinearSegment(dg.options)), nil
+	case *VoidSegmentDataGenerator:
+		switch {
+		case dg.count == 1:
+			items = append(items, "_")
+		case dg.count > 1:
+			items = append(items, fmt.Sprintf("_x%d", dg.count))
+		}
+		return items, nil
+	case *CustomValuesSegmentDataGenerator:
+		for _, value := range dg.values {
+			if value.Missing {
+				items = append(items, "_")
+				continue
+			}
+			if value.Stale {
+				items = append(items, "stale")
+				continue
+			}
+			items = append(items, formatSeriesValue(value.Value))
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("data generator %q cannot be represented in series notation", dataGenerator.Describe().Name())
+	}
+}
+
+func formatLinearSegment(options LinearSegmentDataGeneratorOptions) string {
+	times := options.IterationCountLimit - 1
+	start := formatSeriesValue(options.AmplitudeStart)
+
+	if times == 0 {
+		return start
+	}
+
+	if options.AmplitudeStart == options.AmplitudeEnd {
+		return fmt.Sprintf("%sx%d", start, times)
+	}
+
+	step := (options.AmplitudeEnd - options.AmplitudeStart) / float64(times)
+	if step < 0 {
+		return fmt.Sprintf("%s-%sx%d", start, formatSeriesValue(-step), times)
+	}
+
+	return fmt.Sprintf("%s+%sx%d", start, formatSeriesValue(step), times)
+}
+
+func formatSeriesValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,162 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestParseSeriesNotation(t *testing.T) {
+	t.Run("should not return any sample given an empty notation", func(t *testing.T) {
+		dataGenerator, err := discrete.ParseSeriesNotation("")
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 0, len(results))
+	})
+
+	t.Run("should produce valid results given the notation", func(t *testing.T) {
+		dataGenerator, err := discrete.ParseSeriesNotation("1+2x3 _ 5x2 stale 10-5x2 _x2 1e1 -Inf")
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 16, len(results))
+
+		expectedValues := []float64{1, 3, 5, 7}
+		for i, value := range expectedValues {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+		assert.True(t, results[4].scrapeResult.Missing)
+		for i := 5; i < 8; i++ {
+			assert.InDelta(t, 5, results[i].scrapeResult.Value, 0.001)
+		}
+		assert.True(t, results[8].scrapeResult.Stale)
+		assert.False(t, results[8].scrapeResult.Missing)
+		expectedValues = []float64{10, 5, 0}
+		for i, value := range expectedValues {
+			assert.InDelta(t, value, results[9+i].scrapeResult.Value, 0.001)
+		}
+		assert.True(t, results[12].scrapeResult.Missing)
+		assert.True(t, results[13].scrapeResult.Missing)
+		assert.InDelta(t, 10, results[14].scrapeResult.Value, 0.001)
+		assert.True(t, math.IsInf(results[15].scrapeResult.Value, -1))
+	})
+
+	t.Run("should produce the title example", func(t *testing.T) {
+		dataGenerator, err := discrete.ParseSeriesNotation("0+10x100 _x5 stale")
+		require.NoError(t, err)
+
+		results := helperScraperCustom(
+			t,
+			time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			15*time.Second,
+			200,
+			dataGenerator.Iterator(),
+		)
+
+		require.Equal(t, 107, len(results))
+		assert.InDelta(t, 0, results[0].scrapeResult.Value, 0.001)
+		assert.InDelta(t, 1000, results[100].scrapeResult.Value, 0.001)
+		for i := 101; i < 106; i++ {
+			assert.True(t, results[i].scrapeResult.Missing)
+		}
+		assert.True(t, results[106].scrapeResult.Stale)
+	})
+
+	t.Run("should fail given invalid notations", func(t *testing.T) {
+		testCases := map[string]string{
+			"invalid value":         "abc",
+			"invalid repetition":    "1+1xa",
+			"negative repetition":   "1x-2",
+			"missing repetition":    "1+2",
+			"invalid increment":     "1+ax3",
+			"incremented stale":     "stale+1x2",
+			"repeated stale":        "stalex2",
+			"empty repetition base": "x3",
+		}
+
+		for name, notation := range testCases {
+			t.Run(name, func(t *testing.T) {
+				_, err := discrete.ParseSeriesNotation(notation)
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("should report the offending item in the error", func(t *testing.T) {
+		_, err := discrete.ParseSeriesNotation("1 2 3+1")
+		require.Error(t, err)
+		expectedErrorMessage := `error parsing series notation item "3+1": increments must be followed by a repetition`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should reject repeated stale samples, which promtool doesn't support", func(t *testing.T) {
+		_, err := discrete.ParseSeriesNotation("1 stalex2 _")
+		require.Error(t, err)
+		expectedErrorMessage := `error parsing series notation item "stalex2": stale samples cannot be repeated`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
+
+func TestFormatSeriesNotation(t *testing.T) {
+	t.Run("should round trip the notation", func(t *testing.T) {
+		notation := "1+2x3 _ 5x2 10-5x2 _x2 1 2 3 -Inf"
+
+		dataGenerator, err := discrete.ParseSeriesNotation(notation)
+		require.NoError(t, err)
+
+		result, err := discrete.FormatSeriesNotation(dataGenerator)
+		require.NoError(t, err)
+		assert.Equal(t, notation, result)
+	})
+
+	t.Run("should round trip stale samples", func(t *testing.T) {
+		notation := "1 stale 2"
+
+		dataGenerator, err := discrete.ParseSeriesNotation(notation)
+		require.NoError(t, err)
+
+		result, err := discrete.FormatSeriesNotation(dataGenerator)
+		require.NoError(t, err)
+		assert.Equal(t, notation, result)
+	})
+
+	t.Run("should unroll loops", func(t *testing.T) {
+		lsDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      0,
+			AmplitudeEnd:        30,
+			IterationCountLimit: 4,
+		})
+		require.NoError(t, err)
+
+		dataGenerator := discrete.NewLoopDataGenerator(discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			lsDataGenerator,
+			discrete.NewVoidSegmentDataGenerator(1),
+		}), 2)
+
+		result, err := discrete.FormatSeriesNotation(dataGenerator)
+		require.NoError(t, err)
+		assert.Equal(t, "0+10x3 _ 0+10x3 _", result)
+	})
+
+	t.Run("should fail given a data generator that cannot be represented", func(t *testing.T) {
+		randomDataGenerator, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        0,
+			AmplitudeMax:        10,
+			IterationCountLimit: 5,
+		})
+		require.NoError(t, err)
+
+		_, err = discrete.FormatSeriesNotation(discrete.NewJoinDataGenerator([]discrete.DataGenerator{randomDataGenerator}))
+		require.Error(t, err)
+		expectedErrorMessage := `error formatting series notation: data generator "Random" cannot be represented in series notation`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
@@ -0,0 +1,190 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+type WaveformType string
+
+const (
+	WaveformTypeSine     WaveformType = "waveform_type-sine"
+	WaveformTypeSquare   WaveformType = "waveform_type-square"
+	WaveformTypeTriangle WaveformType = "waveform_type-triangle"
+	WaveformTypeSawtooth WaveformType = "waveform_type-sawtooth"
+)
+
+// PeriodicSegmentDataGeneratorOptions contains the options for the PeriodicSegmentDataGenerator.
+// The value returned on each iteration is given by: Offset + Amplitude * waveform((iteration + Phase) / Period), where
+// waveform is a periodic function with a period of 1 that oscillates between -1 and 1.
+type PeriodicSegmentDataGeneratorOptions struct {
+	// Amplitude represents the peak deviation of the wave from the Offset.
+	Amplitude float64 `json:"amplitude" yaml:"amplitude"`
+
+	// Offset represents the value the wave oscillates around.
+	Offset float64 `json:"offset" yaml:"offset"`
+
+	// Period represents the number of iterations it takes for the wave to complete a full cycle.
+	Period int `json:"period" yaml:"period"`
+
+	// Phase shifts the wave by the given number of iterations.
+	// A positive phase moves the wave to the left, a negative phase moves the wave to the right.
+	Phase int `json:"phase" yaml:"phase"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+}
+
+func (o *PeriodicSegmentDataGeneratorOptions) validate() error {
+	if o.Period <= 0 {
+		return fmt.Errorf("period cannot be less than or equal to zero")
+	}
+
+	if o.IterationCountLimit <= 0 {
+		return fmt.Errorf("iteration count limit cannot be less than or equal to zero")
+	}
+
+	return nil
+}
+
+// Check at compile time whether PeriodicSegmentDataGenerator implements DataGenerator interface.
+var _ DataGenerator = (*PeriodicSegmentDataGenerator)(nil)
+
+// PeriodicSegmentDataGenerator returns a DataGenerator representing a periodic waveform (sine, square, triangle or
+// sawtooth).
+// Note that it's an error to use negative values with counters. It's the user responsibility to make sure negative
+// numbers only appear in gauges.
+// The zero value is not useful. Use one of the helper functions NewSineSegmentDataGenerator,
+// NewSquareSegmentDataGenerator, NewTriangleSegmentDataGenerator or NewSawtoothSegmentDataGenerator.
+type PeriodicSegmentDataGenerator struct {
+	options      PeriodicSegmentDataGeneratorOptions
+	waveformType WaveformType
+}
+
+// NewSineSegmentDataGenerator returns a new instance of PeriodicSegmentDataGenerator representing a sine wave.
+// The wave starts at the Offset and goes up to Offset+Amplitude in the first quarter of the period.
+func NewSineSegmentDataGenerator(options PeriodicSegmentDataGeneratorOptions) (*PeriodicSegmentDataGenerator, error) {
+	return newPeriodicSegmentDataGenerator(options, WaveformTypeSine)
+}
+
+// NewSquareSegmentDataGenerator returns a new instance of PeriodicSegmentDataGenerator representing a square wave.
+// The wave returns Offset+Amplitude during the first half of the period and Offset-Amplitude during the second half.
+func NewSquareSegmentDataGenerator(options PeriodicSegmentDataGeneratorOptions) (*PeriodicSegmentDataGenerator, error) {
+	return newPeriodicSegmentDataGenerator(options, WaveformTypeSquare)
+}
+
+// NewTriangleSegmentDataGenerator returns a new instance of PeriodicSegmentDataGenerator representing a triangle wave.
+// The wave is in phase with the sine wave, i.e., it starts at the Offset and reaches Offset+Amplitude in the first
+// quarter of the period.
+func NewTriangleSegmentDataGenerator(options PeriodicSegmentDataGeneratorOptions) (*PeriodicSegmentDataGenerator, error) {
+	return newPeriodicSegmentDataGenerator(options, WaveformTypeTriangle)
+}
+
+// NewSawtoothSegmentDataGenerator returns a new instance of PeriodicSegmentDataGenerator representing a sawtooth wave.
+// The wave ramps up from Offset-Amplitude towards Offset+Amplitude and then drops back down at the end of each period.
+func NewSawtoothSegmentDataGenerator(options PeriodicSegmentDataGeneratorOptions) (*PeriodicSegmentDataGenerator, error) {
+	return newPeriodicSegmentDataGenerator(options, WaveformTypeSawtooth)
+}
+
+func newPeriodicSegmentDataGenerator(options PeriodicSegmentDataGeneratorOptions, waveformType WaveformType) (*PeriodicSegmentDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &PeriodicSegmentDataGenerator{}, fmt.Errorf("error validating periodic segment data generator configuration: %w", err)
+	}
+
+	return &PeriodicSegmentDataGenerator{
+		options:      options,
+		waveformType: waveformType,
+	}, nil
+}
+
+// WaveformType reports the shape of the wave generated.
+func (dg *PeriodicSegmentDataGenerator) WaveformType() WaveformType {
+	return dg.waveformType
+}
+
+func (dg *PeriodicSegmentDataGenerator) Iterator() metrics.DataIterator {
+	return &PeriodicSegmentDataIterator{
+		periodicSegmentDataGenerator: *dg,
+	}
+}
+
+func (dg *PeriodicSegmentDataGenerator) Describe() DataSpec {
+	var dataGeneratorType DataGeneratorType
+	var name string
+
+	switch dg.waveformType {
+	case WaveformTypeSine:
+		dataGeneratorType = DataGeneratorTypeSineSegment
+		name = "Sine Segment"
+	case WaveformTypeSquare:
+		dataGeneratorType = DataGeneratorTypeSquareSegment
+		name = "Square Segment"
+	case WaveformTypeTriangle:
+		dataGeneratorType = DataGeneratorTypeTriangleSegment
+		name = "Triangle Segment"
+	case WaveformTypeSawtooth:
+		dataGeneratorType = DataGeneratorTypeSawtoothSegment
+		name = "Sawtooth Segment"
+	default:
+		name = "Periodic Segment"
+	}
+
+	return NewDataNodeDataSpec(dataGeneratorType, name, dg.options)
+}
+
+// Check at compile time whether PeriodicSegmentDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*PeriodicSegmentDataIterator)(nil)
+
+type PeriodicSegmentDataIterator struct {
+	// read-only access
+	periodicSegmentDataGenerator PeriodicSegmentDataGenerator
+
+	// iterIndex keeps track of the current iteration.
+	iterIndex int
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *PeriodicSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	// Have we reached the end?
+	if di.iterIndex >= di.periodicSegmentDataGenerator.options.IterationCountLimit {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	// Make sure to increment the iterator index before leaving the function
+	defer func() { di.iterIndex++ }()
+
+	options := di.periodicSegmentDataGenerator.options
+
+	// position represents how far along the current cycle we are, in the range [0,1[
+	position := math.Mod(float64(di.iterIndex+options.Phase)/float64(options.Period), 1)
+	if position < 0 {
+		position++
+	}
+
+	waveValue := waveform(di.periodicSegmentDataGenerator.waveformType, position)
+
+	return metrics.ScrapeResult{Value: options.Offset + options.Amplitude*waveValue}
+}
+
+// waveform computes the normalized value, in the range [-1,1], of the wave at the given position within the cycle.
+// The position must be in the range [0,1[.
+func waveform(waveformType WaveformType, position float64) float64 {
+	switch waveformType {
+	case WaveformTypeSine:
+		return math.Sin(2 * math.Pi * position)
+	case WaveformTypeSquare:
+		if position < 0.5 {
+			return 1
+		}
+		return -1
+	case WaveformTypeTriangle:
+		return 1 - 4*math.Abs(math.Mod(position+0.25, 1)-0.5)
+	case WaveformTypeSawtooth:
+		return 2*position - 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,154 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestPeriodicSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given that period is set to zero", func(t *testing.T) {
+		_, err := discrete.NewSineSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           10,
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating periodic segment data generator configuration: period cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given that iteration count limit is set to zero", func(t *testing.T) {
+		_, err := discrete.NewSquareSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude: 10,
+			Period:    4,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating periodic segment data generator configuration: iteration count limit cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should produce a sine wave for the given options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewSineSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           10,
+			Offset:              50,
+			Period:              4,
+			IterationCountLimit: 8,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 8, len(results))
+		expected := []float64{50, 60, 50, 40, 50, 60, 50, 40}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should produce a square wave for the given options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewSquareSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           5,
+			Offset:              10,
+			Period:              4,
+			IterationCountLimit: 6,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 6, len(results))
+		expected := []float64{15, 15, 5, 5, 15, 15}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should produce a triangle wave for the given options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewTriangleSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           10,
+			Offset:              0,
+			Period:              8,
+			IterationCountLimit: 9,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 9, len(results))
+		expected := []float64{0, 5, 10, 5, 0, -5, -10, -5, 0}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should produce a sawtooth wave for the given options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewSawtoothSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           4,
+			Offset:              4,
+			Period:              4,
+			IterationCountLimit: 6,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 6, len(results))
+		expected := []float64{0, 2, 4, 6, 0, 2}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should shift the wave given a positive and a negative phase", func(t *testing.T) {
+		dataGenerator, err := discrete.NewSawtoothSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           4,
+			Offset:              4,
+			Period:              4,
+			Phase:               1,
+			IterationCountLimit: 4,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 4, len(results))
+		expected := []float64{2, 4, 6, 0}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+
+		dataGenerator, err = discrete.NewSawtoothSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           4,
+			Offset:              4,
+			Period:              4,
+			Phase:               -1,
+			IterationCountLimit: 4,
+		})
+		require.NoError(t, err)
+
+		results = helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 4, len(results))
+		expected = []float64{6, 0, 2, 4}
+		for i, value := range expected {
+			assert.InDelta(t, value, results[i].scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should describe the waveform along with its options", func(t *testing.T) {
+		dataGenerator, err := discrete.NewTriangleSegmentDataGenerator(discrete.PeriodicSegmentDataGeneratorOptions{
+			Amplitude:           10,
+			Offset:              20,
+			Period:              8,
+			Phase:               2,
+			IterationCountLimit: 16,
+		})
+		require.NoError(t, err)
+
+		result := discrete.DescribeWithOptions(dataGenerator.Describe())
+		assert.Equal(t, "Triangle Segment {Amplitude:10 Offset:20 Period:8 Phase:2 IterationCountLimit:16}", result)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
 	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
 )
 
@@ -11,12 +12,15 @@ import (
 // The range for the random numbers is half-open [AmplitudeMin,AmplitudeMax[
 type RandomSegmentDataGeneratorOptions struct {
 	// AmplitudeMin represents the minimum value the data iterator will return.
-	AmplitudeMin float64
+	AmplitudeMin float64 `json:"amplitude_min" yaml:"amplitude_min"`
 	// AmplitudeMax represents the maximum value the data iterator will return (open-interval).
-	AmplitudeMax float64
+	AmplitudeMax float64 `json:"amplitude_max" yaml:"amplitude_max"`
 
 	// IterationCountLimit sets the number of iterations to be used by the segment.
-	IterationCountLimit int
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the values are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
 }
 
 func (o *RandomSegmentDataGeneratorOptions) validate() error {
@@ -27,8 +31,8 @@ func (o *RandomSegmentDataGeneratorOptions) validate() error {
 	return nil
 }
 
-// Check at compile time whether RandomSegmentDataGenerator implements DataGenerator interface.
-var _ DataGenerator = (*RandomSegmentDataGenerator)(nil)
+// Check at compile time whether RandomSegmentDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*RandomSegmentDataGenerator)(nil)
 
 // RandomSegmentDataGenerator returns a DataIterator representing a random sequence of samples.
 // Note that it's an error to use negative values with counters. It's the user responsibility to make sure negative
@@ -52,15 +56,23 @@ func NewRandomDataGenerator(options RandomSegmentDataGeneratorOptions) (*RandomS
 func (dg *RandomSegmentDataGenerator) Iterator() metrics.DataIterator {
 	return &RandomSegmentDataIterator{
 		randomSegmentDataGenerator: *dg,
+		rand:                       random.NewRand(dg.options.Seed),
 	}
 }
 
-func (dg *RandomSegmentDataGenerator) Describe() DataSpec {
-	return DataNodeDataSpec{
-		name: "Random",
+func (dg *RandomSegmentDataGenerator) WithSeed(seed int64) DataGenerator {
+	options := dg.options
+	options.Seed = seed
+
+	return &RandomSegmentDataGenerator{
+		options: options,
 	}
 }
 
+func (dg *RandomSegmentDataGenerator) Describe() DataSpec {
+	return NewDataNodeDataSpec(DataGeneratorTypeRandomSegment, "Random", dg.options)
+}
+
 // Check at compile time whether RandomSegmentDataIterator implements metrics.DataIterator interface.
 var _ metrics.DataIterator = (*RandomSegmentDataIterator)(nil)
 
@@ -68,6 +80,9 @@ type RandomSegmentDataIterator struct {
 	// read-only access
 	randomSegmentDataGenerator RandomSegmentDataGenerator
 
+	// rand is the source of randomness for this iterator.
+	rand *rand.Rand
+
 	// iterIndex keeps track of the current iteration.
 	iterIndex int
 }
@@ -84,7 +99,7 @@ func (di *RandomSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) met
 	defer func() { di.iterIndex++ }()
 
 	randomRange := di.randomSegmentDataGenerator.options.AmplitudeMax - di.randomSegmentDataGenerator.options.AmplitudeMin
-	randomValue := rand.Float64()*(randomRange) + di.randomSegmentDataGenerator.options.AmplitudeMin
+	randomValue := di.rand.Float64()*(randomRange) + di.randomSegmentDataGenerator.options.AmplitudeMin
 
 	return metrics.ScrapeResult{Value: randomValue}
 }
@@ -38,4 +38,41 @@ func TestRandomDataIterator(t *testing.T) {
 			assert.LessOrEqual(t, 11.0, result.scrapeResult.Value)
 		}
 	})
+	t.Run("should produce the same sequence for every iterator given the same seed", func(t *testing.T) {
+		dataGenerator, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        11,
+			AmplitudeMax:        20,
+			IterationCountLimit: 10,
+			Seed:                42,
+		})
+		require.NoError(t, err)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 10, len(results1))
+		require.Equal(t, 10, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+	})
+
+	t.Run("should produce different sequences given different seeds", func(t *testing.T) {
+		dataGenerator1, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        11,
+			AmplitudeMax:        20,
+			IterationCountLimit: 10,
+			Seed:                42,
+		})
+		require.NoError(t, err)
+
+		dataGenerator2 := dataGenerator1.WithSeed(43)
+
+		results1 := helperScraper(t, dataGenerator1.Iterator())
+		results2 := helperScraper(t, dataGenerator2.Iterator())
+
+		require.Equal(t, 10, len(results1))
+		require.Equal(t, 10, len(results2))
+		assert.NotEqual(t, results1[0].scrapeResult.Value, results2[0].scrapeResult.Value)
+	})
 }
@@ -0,0 +1,206 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+type BoundaryType string
+
+const (
+	// BoundaryTypeClamp replaces values that cross a bound with the bound itself.
+	BoundaryTypeClamp BoundaryType = "boundary_type-clamp"
+	// BoundaryTypeReflect mirrors values that cross a bound back into the interval, as if bouncing off a wall.
+	BoundaryTypeReflect BoundaryType = "boundary_type-reflect"
+)
+
+// WalkBounds restricts a walk to the closed interval [Min,Max].
+// BoundaryType controls what happens when the walk crosses one of the bounds. Defaults to BoundaryTypeClamp.
+type WalkBounds struct {
+	// Min represents the lower bound of the interval.
+	Min float64 `json:"min" yaml:"min"`
+
+	// Max represents the upper bound of the interval.
+	Max float64 `json:"max" yaml:"max"`
+
+	// BoundaryType controls how the walk behaves when it crosses one of the bounds.
+	BoundaryType BoundaryType `json:"boundary_type" yaml:"boundary_type"`
+}
+
+func (wb *WalkBounds) validate(startValue float64) error {
+	if wb.Min > wb.Max {
+		return fmt.Errorf("bounds min cannot be greater than bounds max")
+	}
+
+	if startValue < wb.Min || startValue > wb.Max {
+		return fmt.Errorf("start value must be within bounds")
+	}
+
+	switch wb.BoundaryType {
+	case "", BoundaryTypeClamp, BoundaryTypeReflect:
+	default:
+		return fmt.Errorf("unknown boundary type %q", wb.BoundaryType)
+	}
+
+	return nil
+}
+
+// String returns the interval in the format [Min,Max] followed by the boundary type.
+func (wb *WalkBounds) String() string {
+	if wb == nil {
+		return "<nil>"
+	}
+
+	boundaryType := "clamp"
+	if wb.BoundaryType == BoundaryTypeReflect {
+		boundaryType = "reflect"
+	}
+
+	return fmt.Sprintf("[%g,%g] %s", wb.Min, wb.Max, boundaryType)
+}
+
+// apply restricts the value to the bounds. A nil WalkBounds leaves the value unchanged.
+func (wb *WalkBounds) apply(value float64) float64 {
+	if wb == nil {
+		return value
+	}
+
+	if wb.BoundaryType != BoundaryTypeReflect || wb.Min == wb.Max {
+		return math.Min(math.Max(value, wb.Min), wb.Max)
+	}
+
+	// Reflect the value back into the interval. A big enough step may bounce off both walls several times.
+	width := wb.Max - wb.Min
+	position := math.Mod(value-wb.Min, 2*width)
+	if position < 0 {
+		position += 2 * width
+	}
+
+	if position > width {
+		position = 2*width - position
+	}
+
+	return wb.Min + position
+}
+
+// RandomWalkSegmentDataGeneratorOptions contains the options for the RandomWalkSegmentDataGenerator.
+type RandomWalkSegmentDataGeneratorOptions struct {
+	// StartValue represents the first value returned by the walk.
+	StartValue float64 `json:"start_value" yaml:"start_value"`
+
+	// StepSize represents the standard deviation of each step. Steps are normally distributed with a mean of zero.
+	StepSize float64 `json:"step_size" yaml:"step_size"`
+
+	// Bounds optionally restricts the walk to a given interval.
+	Bounds *WalkBounds `json:"bounds,omitempty" yaml:"bounds,omitempty"`
+
+	// IterationCountLimit sets the number of iterations to be used by the segment.
+	IterationCountLimit int `json:"iteration_count_limit" yaml:"iteration_count_limit"`
+
+	// Seed seeds the source of randomness the steps are drawn from, see SeedableDataGenerator.
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+func (o *RandomWalkSegmentDataGeneratorOptions) validate() error {
+	if o.StepSize < 0 {
+		return fmt.Errorf("step size cannot be less than zero")
+	}
+
+	if o.Bounds != nil {
+		if err := o.Bounds.validate(o.StartValue); err != nil {
+			return err
+		}
+	}
+
+	if o.IterationCountLimit <= 0 {
+		return fmt.Errorf("iteration count limit cannot be less than or equal to zero")
+	}
+
+	return nil
+}
+
+// Check at compile time whether RandomWalkSegmentDataGenerator implements SeedableDataGenerator interface.
+var _ SeedableDataGenerator = (*RandomWalkSegmentDataGenerator)(nil)
+
+// RandomWalkSegmentDataGenerator returns a DataGenerator representing a random walk.
+// Each sample is the previous sample plus a random step, which makes the data drift instead of jumping around.
+// Note that it's an error to use negative values with counters. It's the user responsibility to make sure negative
+// numbers only appear in gauges.
+// The zero value is not useful.
+type RandomWalkSegmentDataGenerator struct {
+	options RandomWalkSegmentDataGeneratorOptions
+}
+
+// NewRandomWalkSegmentDataGenerator returns a new instance of RandomWalkSegmentDataGenerator.
+func NewRandomWalkSegmentDataGenerator(options RandomWalkSegmentDataGeneratorOptions) (*RandomWalkSegmentDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &RandomWalkSegmentDataGenerator{}, fmt.Errorf("error validating random walk segment data generator configuration: %w", err)
+	}
+
+	return &RandomWalkSegmentDataGenerator{
+		options: options,
+	}, nil
+}
+
+func (dg *RandomWalkSegmentDataGenerator) Iterator() metrics.DataIterator {
+	return &RandomWalkSegmentDataIterator{
+		randomWalkSegmentDataGenerator: *dg,
+		rand:                           random.NewRand(dg.options.Seed),
+		value:                          dg.options.StartValue,
+	}
+}
+
+func (dg *RandomWalkSegmentDataGenerator) WithSeed(seed int64) DataGenerator {
+	options := dg.options
+	options.Seed = seed
+
+	return &RandomWalkSegmentDataGenerator{
+		options: options,
+	}
+}
+
+func (dg *RandomWalkSegmentDataGenerator) Describe() DataSpec {
+	return NewDataNodeDataSpec(DataGeneratorTypeRandomWalkSegment, "Random Walk Segment", dg.options)
+}
+
+// Check at compile time whether RandomWalkSegmentDataIterator implements metrics.DataIterator interface.
+var _ metrics.DataIterator = (*RandomWalkSegmentDataIterator)(nil)
+
+type RandomWalkSegmentDataIterator struct {
+	// read-only access
+	randomWalkSegmentDataGenerator RandomWalkSegmentDataGenerator
+
+	// rand is the source of randomness for this iterator.
+	rand *rand.Rand
+
+	// iterIndex keeps track of the current iteration.
+	iterIndex int
+
+	// value is the current position of the walk.
+	value float64
+}
+
+// Evaluate fulfills the metrics.DataIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *RandomWalkSegmentDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+	options := di.randomWalkSegmentDataGenerator.options
+
+	// Have we reached the end?
+	if di.iterIndex >= options.IterationCountLimit {
+		return metrics.ScrapeResult{Exhausted: true}
+	}
+
+	// Make sure to increment the iterator index before leaving the function
+	defer func() { di.iterIndex++ }()
+
+	// The first sample is always the start value
+	if di.iterIndex > 0 {
+		di.value = options.Bounds.apply(di.value + di.rand.NormFloat64()*options.StepSize)
+	}
+
+	return metrics.ScrapeResult{Value: di.value}
+}
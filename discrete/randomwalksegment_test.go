@@ -0,0 +1,94 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestRandomWalkSegmentDataIterator(t *testing.T) {
+	t.Run("should fail given that iteration count limit is set to zero", func(t *testing.T) {
+		_, err := discrete.NewRandomWalkSegmentDataGenerator(discrete.RandomWalkSegmentDataGeneratorOptions{
+			StartValue: 10,
+			StepSize:   1,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating random walk segment data generator configuration: iteration count limit cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given that start value is out of bounds", func(t *testing.T) {
+		_, err := discrete.NewRandomWalkSegmentDataGenerator(discrete.RandomWalkSegmentDataGeneratorOptions{
+			StartValue:          10,
+			StepSize:            1,
+			Bounds:              &discrete.WalkBounds{Min: 20, Max: 30},
+			IterationCountLimit: 10,
+		})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating random walk segment data generator configuration: start value must be within bounds"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should start at the start value and drift in steps", func(t *testing.T) {
+		dataGenerator, err := discrete.NewRandomWalkSegmentDataGenerator(discrete.RandomWalkSegmentDataGeneratorOptions{
+			StartValue:          50,
+			StepSize:            1,
+			IterationCountLimit: 20,
+			Seed:                3,
+		})
+		require.NoError(t, err)
+
+		results := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 20, len(results))
+		assert.InDelta(t, 50, results[0].scrapeResult.Value, 0.001)
+
+		// with a step size of 1, a single step larger than 6 is practically impossible.
+		for i := 1; i < len(results); i++ {
+			assert.Less(t, math.Abs(results[i].scrapeResult.Value-results[i-1].scrapeResult.Value), 6.0)
+		}
+	})
+
+	t.Run("should keep the walk within bounds for both boundary types", func(t *testing.T) {
+		for _, boundaryType := range []discrete.BoundaryType{discrete.BoundaryTypeClamp, discrete.BoundaryTypeReflect} {
+			dataGenerator, err := discrete.NewRandomWalkSegmentDataGenerator(discrete.RandomWalkSegmentDataGeneratorOptions{
+				StartValue:          5,
+				StepSize:            20,
+				Bounds:              &discrete.WalkBounds{Min: 0, Max: 10, BoundaryType: boundaryType},
+				IterationCountLimit: 100,
+			})
+			require.NoError(t, err)
+
+			results := helperScraper(t, dataGenerator.Iterator())
+
+			require.Equal(t, 100, len(results))
+			for _, result := range results {
+				assert.GreaterOrEqual(t, result.scrapeResult.Value, 0.0)
+				assert.LessOrEqual(t, result.scrapeResult.Value, 10.0)
+			}
+		}
+	})
+
+	t.Run("should produce the same sequence given the same seed", func(t *testing.T) {
+		dataGenerator, err := discrete.NewRandomWalkSegmentDataGenerator(discrete.RandomWalkSegmentDataGeneratorOptions{
+			StartValue:          0,
+			StepSize:            2,
+			IterationCountLimit: 10,
+			Seed:                5,
+		})
+		require.NoError(t, err)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 10, len(results1))
+		require.Equal(t, 10, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+	})
+}
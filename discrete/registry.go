@@ -0,0 +1,189 @@
+package discrete
+
+import (
+	"fmt"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// Registry builds DataGenerators back from their DataSpec.
+// The containers (Join, Loop, Arithmetic and Counter) are handled by the Registry itself, while data nodes are built
+// by the builders registered for their DataGeneratorType.
+// The Registry returned by NewRegistry knows how to build every data generator defined in this package. Data
+// generators defined outside this package can be added with RegisterDataNodeBuilder, and containers defined outside
+// this package with RegisterContainerBuilder. Since the Registry is a metrics.BuilderRegistry, packages that don't
+// depend on this one, such as the continuous package, register their builders with the functions in the metrics
+// package instead.
+// The zero value is not useful.
+type Registry struct {
+	dataNodeBuilders  map[DataGeneratorType]metrics.DataNodeBuilder
+	containerBuilders map[DataGeneratorType]metrics.ContainerBuilder
+}
+
+// Check at compile time whether Registry implements metrics.BuilderRegistry interface.
+var _ metrics.BuilderRegistry = (*Registry)(nil)
+
+// NewRegistry returns a new instance of Registry with all the data generators defined in this package registered.
+func NewRegistry() *Registry {
+	registry := &Registry{
+		dataNodeBuilders:  make(map[DataGeneratorType]metrics.DataNodeBuilder),
+		containerBuilders: make(map[DataGeneratorType]metrics.ContainerBuilder),
+	}
+
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeLinearSegment, adaptConstructor(NewLinearSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeCustomValuesSegment, func(values []CustomValueSample) (DataGenerator, error) {
+		return NewCustomValuesDataGenerator(values), nil
+	})
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeRandomSegment, adaptConstructor(NewRandomDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeVoidSegment, func(count int) (DataGenerator, error) {
+		return NewVoidSegmentDataGenerator(count), nil
+	})
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeSineSegment, adaptConstructor(NewSineSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeSquareSegment, adaptConstructor(NewSquareSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeTriangleSegment, adaptConstructor(NewTriangleSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeSawtoothSegment, adaptConstructor(NewSawtoothSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeNormalSegment, adaptConstructor(NewNormalSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeLogNormalSegment, adaptConstructor(NewLogNormalSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeExponentialSegment, adaptConstructor(NewExponentialSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypePoissonSegment, adaptConstructor(NewPoissonSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeParetoSegment, adaptConstructor(NewParetoSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeRandomWalkSegment, adaptConstructor(NewRandomWalkSegmentDataGenerator))
+	RegisterDataNodeBuilder(registry, DataGeneratorTypeMeanRevertingSegment, adaptConstructor(NewMeanRevertingSegmentDataGenerator))
+
+	return registry
+}
+
+// RegisterDataNodeBuilder registers the function used to build data nodes of the given DataGeneratorType.
+// See metrics.RegisterDataNodeBuilder.
+func RegisterDataNodeBuilder[T any](registry *Registry, dataGeneratorType DataGeneratorType, build func(options T) (DataGenerator, error)) {
+	metrics.RegisterDataNodeBuilder(registry, dataGeneratorType, build)
+}
+
+// RegisterContainerBuilder registers the function used to build containers of the given DataGeneratorType.
+// See metrics.RegisterContainerBuilder.
+func RegisterContainerBuilder[T any](registry *Registry, dataGeneratorType DataGeneratorType, build func(options T, children []DataGenerator) (DataGenerator, error)) {
+	metrics.RegisterContainerBuilder(registry, dataGeneratorType, build)
+}
+
+// SetDataNodeBuilder fulfills the metrics.BuilderRegistry interface.
+func (r *Registry) SetDataNodeBuilder(dataGeneratorType DataGeneratorType, builder metrics.DataNodeBuilder) {
+	r.dataNodeBuilders[dataGeneratorType] = builder
+}
+
+// SetContainerBuilder fulfills the metrics.BuilderRegistry interface.
+func (r *Registry) SetContainerBuilder(dataGeneratorType DataGeneratorType, builder metrics.ContainerBuilder) {
+	r.containerBuilders[dataGeneratorType] = builder
+}
+
+// adaptConstructor adapts the constructors returning concrete data generators to the signature expected by
+// RegisterDataNodeBuilder.
+func adaptConstructor[T any, D DataGenerator](constructor func(options T) (D, error)) func(options T) (DataGenerator, error) {
+	return func(options T) (DataGenerator, error) {
+		dataGenerator, err := constructor(options)
+		if err != nil {
+			return nil, err
+		}
+		return dataGenerator, nil
+	}
+}
+
+// Build builds the DataGenerator described by the DataSpec provided.
+func (r *Registry) Build(dataSpec DataSpec) (DataGenerator, error) {
+	dataGenerator, err := r.build(dataSpec)
+	if err != nil {
+		return nil, fmt.Errorf("error building data generator: %w", err)
+	}
+
+	return dataGenerator, nil
+}
+
+func (r *Registry) build(dataSpec DataSpec) (DataGenerator, error) {
+	switch dataSpecConcrete := dataSpec.(type) {
+	case DataNodeDataSpec:
+		builder, ok := r.dataNodeBuilders[dataSpecConcrete.DataGeneratorType()]
+		if !ok {
+			return nil, fmt.Errorf("data generator type %q is not registered", dataSpecConcrete.DataGeneratorType())
+		}
+		return builder.Build(dataSpecConcrete.Options())
+	case ContainerDataSpec:
+		builder, ok := r.containerBuilders[dataSpecConcrete.DataGeneratorType()]
+		if !ok {
+			return nil, fmt.Errorf("data generator type %q is not registered", dataSpecConcrete.DataGeneratorType())
+		}
+
+		dataGenerators, err := r.buildAll(dataSpecConcrete.Children())
+		if err != nil {
+			return nil, err
+		}
+		return builder.Build(dataSpecConcrete.Options(), dataGenerators)
+	case JoinDataSpec:
+		dataGenerators, err := r.buildAll(dataSpecConcrete.Children)
+		if err != nil {
+			return nil, err
+		}
+		return NewJoinDataGenerator(dataGenerators), nil
+	case LoopDataSpec:
+		if dataSpecConcrete.Func == nil {
+			return nil, fmt.Errorf("loop data spec is missing the data spec to loop over")
+		}
+
+		if dataSpecConcrete.Count < 0 {
+			return nil, fmt.Errorf("loop count cannot be less than zero")
+		}
+
+		dataGenerator, err := r.build(dataSpecConcrete.Func)
+		if err != nil {
+			return nil, err
+		}
+
+		loopDataGenerator := NewLoopDataGenerator(dataGenerator, dataSpecConcrete.Count)
+		if dataSpecConcrete.Seed != 0 {
+			return loopDataGenerator.WithSeed(dataSpecConcrete.Seed), nil
+		}
+		return loopDataGenerator, nil
+	case ArithmeticDataSpec:
+		dataGenerators, err := r.buildAll(dataSpecConcrete.Children)
+		if err != nil {
+			return nil, err
+		}
+
+		switch dataSpecConcrete.OperationType {
+		case ArithmeticOperationTypeAdd, ArithmeticOperationTypeSubtract, ArithmeticOperationTypeMultiply,
+			ArithmeticOperationTypeDivide, ArithmeticOperationTypeMin, ArithmeticOperationTypeMax:
+			return newArithmeticDataGenerator(dataGenerators, dataSpecConcrete.OperationType), nil
+		default:
+			return nil, fmt.Errorf("unknown arithmetic operation type %q", dataSpecConcrete.OperationType)
+		}
+	case CounterDataSpec:
+		if dataSpecConcrete.Increment == nil {
+			return nil, fmt.Errorf("counter data spec is missing the increment data spec")
+		}
+
+		incrementDataGenerator, err := r.build(dataSpecConcrete.Increment)
+		if err != nil {
+			return nil, err
+		}
+
+		counterDataGenerator, err := NewCounterDataGenerator(incrementDataGenerator, dataSpecConcrete.Options)
+		if err != nil {
+			return nil, err
+		}
+		return counterDataGenerator, nil
+	default:
+		return nil, fmt.Errorf("unknown data spec %T", dataSpec)
+	}
+}
+
+func (r *Registry) buildAll(dataSpecs []DataSpec) ([]DataGenerator, error) {
+	dataGenerators := make([]DataGenerator, 0, len(dataSpecs))
+
+	for _, dataSpec := range dataSpecs {
+		dataGenerator, err := r.build(dataSpec)
+		if err != nil {
+			return nil, err
+		}
+		dataGenerators = append(dataGenerators, dataGenerator)
+	}
+
+	return dataGenerators, nil
+}
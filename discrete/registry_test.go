@@ -0,0 +1,167 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+const dataGeneratorTypeConstant discrete.DataGeneratorType = "data_generator_type-constant"
+
+// constantDataGenerator is a data generator defined outside the discrete package.
+type constantDataGenerator struct {
+	options constantDataGeneratorOptions
+}
+
+type constantDataGeneratorOptions struct {
+	Value float64 `json:"value" yaml:"value"`
+	Count int     `json:"count" yaml:"count"`
+}
+
+func (dg *constantDataGenerator) Iterator() metrics.DataIterator {
+	values := make([]discrete.CustomValueSample, dg.options.Count)
+	for i := range values {
+		values[i].Value = dg.options.Value
+	}
+
+	return discrete.NewCustomValuesDataGenerator(values).Iterator()
+}
+
+func (dg *constantDataGenerator) Describe() discrete.DataSpec {
+	return discrete.NewDataNodeDataSpec(dataGeneratorTypeConstant, "Constant", dg.options)
+}
+
+const dataGeneratorTypeRepeat discrete.DataGeneratorType = "data_generator_type-repeat"
+
+// repeatDataGenerator is a container defined outside the discrete package, which goes through its children a number of
+// times.
+type repeatDataGenerator struct {
+	options  repeatDataGeneratorOptions
+	children []discrete.DataGenerator
+}
+
+type repeatDataGeneratorOptions struct {
+	Times int `json:"times" yaml:"times"`
+}
+
+func (dg *repeatDataGenerator) Iterator() metrics.DataIterator {
+	var dataGenerators []discrete.DataGenerator
+	for i := 0; i < dg.options.Times; i++ {
+		dataGenerators = append(dataGenerators, dg.children...)
+	}
+
+	return discrete.NewJoinDataGenerator(dataGenerators).Iterator()
+}
+
+func (dg *repeatDataGenerator) Describe() discrete.DataSpec {
+	var children []discrete.DataSpec
+	for _, child := range dg.children {
+		children = append(children, child.Describe())
+	}
+
+	return discrete.NewContainerDataSpec(dataGeneratorTypeRepeat, "Repeat", dg.options, children)
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("should build data generators registered outside the package", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+		discrete.RegisterDataNodeBuilder(registry, dataGeneratorTypeConstant, func(options constantDataGeneratorOptions) (discrete.DataGenerator, error) {
+			return &constantDataGenerator{options: options}, nil
+		})
+
+		dataGenerator := discrete.NewLoopDataGenerator(&constantDataGenerator{
+			options: constantDataGeneratorOptions{Value: 7, Count: 2},
+		}, 2)
+
+		data, err := discrete.MarshalDataSpecJSON(dataGenerator.Describe())
+		require.NoError(t, err)
+
+		dataSpec, err := registry.UnmarshalDataSpecJSON(data)
+		require.NoError(t, err)
+
+		rebuiltDataGenerator, err := registry.Build(dataSpec)
+		require.NoError(t, err)
+
+		results := helperScraper(t, rebuiltDataGenerator.Iterator())
+
+		require.Equal(t, 4, len(results))
+		for _, result := range results {
+			assert.InDelta(t, 7, result.scrapeResult.Value, 0.001)
+		}
+	})
+
+	t.Run("should build containers registered outside the package", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+		discrete.RegisterContainerBuilder(registry, dataGeneratorTypeRepeat, func(options repeatDataGeneratorOptions, children []discrete.DataGenerator) (discrete.DataGenerator, error) {
+			return &repeatDataGenerator{options: options, children: children}, nil
+		})
+
+		dataGenerator := &repeatDataGenerator{
+			options:  repeatDataGeneratorOptions{Times: 3},
+			children: []discrete.DataGenerator{discrete.NewVoidSegmentDataGenerator(1)},
+		}
+
+		data, err := discrete.MarshalDataSpecYAML(dataGenerator.Describe())
+		require.NoError(t, err)
+
+		dataSpec, err := registry.UnmarshalDataSpecYAML(data)
+		require.NoError(t, err)
+		assert.Equal(t, "Repeat {Times:3}\n  Void 1", discrete.DescribeWithOptions(dataSpec))
+
+		rebuiltDataGenerator, err := registry.Build(dataSpec)
+		require.NoError(t, err)
+
+		results := helperScraper(t, rebuiltDataGenerator.Iterator())
+		assert.Equal(t, 3, len(results))
+	})
+
+	t.Run("should fail given a container type that is not registered", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+
+		_, err := registry.Build(discrete.NewContainerDataSpec(dataGeneratorTypeRepeat, "Repeat", repeatDataGeneratorOptions{}, nil))
+		require.Error(t, err)
+		expectedErrorMessage := `error building data generator: data generator type "data_generator_type-repeat" is not registered`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given a data generator type that is not registered", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+
+		_, err := registry.Build(discrete.NewDataNodeDataSpec(dataGeneratorTypeConstant, "Constant", constantDataGeneratorOptions{}))
+		require.Error(t, err)
+		expectedErrorMessage := `error building data generator: data generator type "data_generator_type-constant" is not registered`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given options of the wrong type", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+
+		_, err := registry.Build(discrete.NewDataNodeDataSpec(discrete.DataGeneratorTypeVoidSegment, "Void", 2.5))
+		require.Error(t, err)
+		expectedErrorMessage := `error building data generator: options for data generator type ` +
+			`"data_generator_type-void_segment" must be of type int, got float64`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given a loop with a negative count", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+
+		_, err := registry.Build(discrete.LoopDataSpec{Count: -1, Func: discrete.NewVoidSegmentDataGenerator(2).Describe()})
+		require.Error(t, err)
+		expectedErrorMessage := "error building data generator: loop count cannot be less than zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given an unknown arithmetic operation", func(t *testing.T) {
+		registry := discrete.NewRegistry()
+
+		_, err := registry.Build(discrete.ArithmeticDataSpec{OperationType: "arithmetic_operation_type-power"})
+		require.Error(t, err)
+		expectedErrorMessage := `error building data generator: unknown arithmetic operation type "arithmetic_operation_type-power"`
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+}
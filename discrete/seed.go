@@ -0,0 +1,50 @@
+package discrete
+
+// SeedableDataGenerator is implemented by DataGenerators that rely on a source of randomness to generate data.
+// Such DataGenerators take a Seed in their options, which seeds the source of randomness of their iterators.
+// Seeding a DataGenerator makes every iterator it returns produce the exact same sequence of samples. A seed of zero
+// means the DataGenerator is not seeded, i.e., every iterator it returns produces a different sequence of samples.
+// Containers, like the JoinDataGenerator and the LoopDataGenerator, implement this interface by deriving a seed for
+// each of their children, which means a whole tree of DataGenerators can be made reproducible from a single root seed.
+// Seeding a container with a seed of zero leaves its children untouched, so children seeded on their own stay
+// reproducible.
+type SeedableDataGenerator interface {
+	DataGenerator
+
+	// WithSeed returns a copy of the DataGenerator that uses the seed provided.
+	// A seed of zero means the DataGenerator is not seeded, i.e., it will produce a different sequence every time.
+	WithSeed(seed int64) DataGenerator
+}
+
+// WithSeed seeds the DataGenerator provided if it implements the SeedableDataGenerator interface.
+// Otherwise, the DataGenerator is returned unchanged.
+func WithSeed(dataGenerator DataGenerator, seed int64) DataGenerator {
+	if seedableDataGenerator, ok := dataGenerator.(SeedableDataGenerator); ok {
+		return seedableDataGenerator.WithSeed(seed)
+	}
+
+	return dataGenerator
+}
+
+// DeriveSeed derives a seed for the child at the given index from the parent seed.
+// The same parent seed and index always derive the same seed.
+// Deriving a seed from a zero seed (unseeded) returns zero.
+func DeriveSeed(seed int64, index int) int64 {
+	if seed == 0 {
+		return 0
+	}
+
+	// splitmix64 finalizer
+	z := uint64(seed) + uint64(index+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+
+	derivedSeed := int64(z)
+	// zero is reserved to mean unseeded
+	if derivedSeed == 0 {
+		derivedSeed = 1
+	}
+
+	return derivedSeed
+}
@@ -0,0 +1,148 @@
+package discrete_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+)
+
+func TestDeriveSeed(t *testing.T) {
+	t.Run("should derive the same seed given the same parent seed and index", func(t *testing.T) {
+		assert.Equal(t, discrete.DeriveSeed(42, 3), discrete.DeriveSeed(42, 3))
+	})
+
+	t.Run("should derive different seeds for different indexes", func(t *testing.T) {
+		assert.NotEqual(t, discrete.DeriveSeed(42, 0), discrete.DeriveSeed(42, 1))
+		assert.NotEqual(t, discrete.DeriveSeed(42, 0), int64(42))
+	})
+
+	t.Run("should derive an unseeded seed from an unseeded parent", func(t *testing.T) {
+		assert.Equal(t, int64(0), discrete.DeriveSeed(0, 5))
+	})
+}
+
+func TestWithSeed(t *testing.T) {
+	newRandomDataGenerator := func(t *testing.T) discrete.DataGenerator {
+		t.Helper()
+
+		dataGenerator, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        0,
+			AmplitudeMax:        100,
+			IterationCountLimit: 3,
+		})
+		require.NoError(t, err)
+
+		return dataGenerator
+	}
+
+	t.Run("should leave data generators that are not seedable unchanged", func(t *testing.T) {
+		dataGenerator := discrete.NewVoidSegmentDataGenerator(3)
+		assert.Same(t, dataGenerator, discrete.WithSeed(dataGenerator, 42))
+	})
+
+	t.Run("should make a join tree reproducible from a single root seed", func(t *testing.T) {
+		joinDataGenerator := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			newRandomDataGenerator(t),
+			newRandomDataGenerator(t),
+		})
+
+		dataGenerator1 := discrete.WithSeed(joinDataGenerator, 42)
+		dataGenerator2 := discrete.WithSeed(joinDataGenerator, 42)
+
+		results1 := helperScraper(t, dataGenerator1.Iterator())
+		results2 := helperScraper(t, dataGenerator2.Iterator())
+
+		require.Equal(t, 6, len(results1))
+		require.Equal(t, 6, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+
+		// each child gets its own seed
+		assert.NotEqual(t, results1[0].scrapeResult.Value, results1[3].scrapeResult.Value)
+	})
+
+	t.Run("should leave the seeds of the children untouched given a seed of zero", func(t *testing.T) {
+		seededDataGenerator, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        0,
+			AmplitudeMax:        100,
+			IterationCountLimit: 3,
+			Seed:                42,
+		})
+		require.NoError(t, err)
+
+		joinDataGenerator := discrete.NewJoinDataGenerator([]discrete.DataGenerator{seededDataGenerator})
+		addDataGenerator := discrete.NewAddDataGenerator([]discrete.DataGenerator{seededDataGenerator})
+
+		assert.Same(t, joinDataGenerator, discrete.WithSeed(joinDataGenerator, 0))
+		assert.Same(t, addDataGenerator, discrete.WithSeed(addDataGenerator, 0))
+	})
+
+	t.Run("should keep a seeded increment reproducible given a counter seeded with zero", func(t *testing.T) {
+		incrementDataGenerator, err := discrete.NewRandomDataGenerator(discrete.RandomSegmentDataGeneratorOptions{
+			AmplitudeMin:        0,
+			AmplitudeMax:        100,
+			IterationCountLimit: 3,
+			Seed:                42,
+		})
+		require.NoError(t, err)
+
+		counterDataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{})
+		require.NoError(t, err)
+
+		dataGenerator := discrete.WithSeed(counterDataGenerator, 0)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 3, len(results1))
+		require.Equal(t, 3, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+	})
+
+	t.Run("should seed each loop repetition differently while remaining reproducible", func(t *testing.T) {
+		loopDataGenerator := discrete.NewLoopDataGenerator(newRandomDataGenerator(t), 2)
+
+		dataGenerator := discrete.WithSeed(loopDataGenerator, 7)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 6, len(results1))
+		require.Equal(t, 6, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+
+		assert.NotEqual(t, results1[0].scrapeResult.Value, results1[3].scrapeResult.Value)
+	})
+
+	t.Run("should make probabilistic counter resets reproducible", func(t *testing.T) {
+		incrementDataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      1,
+			AmplitudeEnd:        1,
+			IterationCountLimit: 50,
+		})
+		require.NoError(t, err)
+
+		dataGenerator, err := discrete.NewCounterDataGenerator(incrementDataGenerator, discrete.CounterDataGeneratorOptions{
+			ResetProbability: 0.2,
+			Seed:             1234,
+		})
+		require.NoError(t, err)
+
+		results1 := helperScraper(t, dataGenerator.Iterator())
+		results2 := helperScraper(t, dataGenerator.Iterator())
+
+		require.Equal(t, 50, len(results1))
+		require.Equal(t, 50, len(results2))
+		for i := range results1 {
+			assert.Equal(t, results1[i].scrapeResult.Value, results2[i].scrapeResult.Value)
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package discrete
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// defaultSummaryMaxAge is the default duration for which observations stay relevant to the quantiles.
+// It's the same default used by the prometheus client library.
+const defaultSummaryMaxAge = 10 * time.Minute
+
+// DataSummaryGenerator generates summary data according to the generator.
+// It's meant to be used by Summary metrics.
+type DataSummaryGenerator interface {
+	Iterator() metrics.DataSummaryIterator
+}
+
+// SummaryDataGeneratorOptions contains the options for the DistributionSummaryDataGenerator.
+type SummaryDataGeneratorOptions struct {
+	// Quantiles contains the quantiles to be computed, in increasing order. Each quantile must be in the range [0,1].
+	// A summary without quantiles only reports the Count and the Sum.
+	Quantiles []float64
+
+	// MaxAge defines the duration for which an observation stays relevant to the quantiles, i.e., the quantiles are
+	// computed from the observations recorded within MaxAge of the scrape.
+	// If zero, the default of 10 minutes is used.
+	MaxAge time.Duration
+
+	// DistributionType sets the distribution the observations are drawn from.
+	// Only the normal, log-normal and exponential distributions are supported.
+	DistributionType DistributionType
+
+	// Seed seeds the source of randomness used to draw the observations, see SeedableDataGenerator.
+	Seed int64
+}
+
+func (o *SummaryDataGeneratorOptions) validate() error {
+	for i, quantile := range o.Quantiles {
+		if quantile < 0 || quantile > 1 || math.IsNaN(quantile) {
+			return fmt.Errorf("quantiles must be in the range [0,1]")
+		}
+
+		if i > 0 && quantile <= o.Quantiles[i-1] {
+			return fmt.Errorf("quantiles must be in strictly increasing order")
+		}
+	}
+
+	if o.MaxAge < 0 {
+		return fmt.Errorf("max age cannot be negative")
+	}
+
+	switch o.DistributionType {
+	case DistributionTypeNormal, DistributionTypeLogNormal, DistributionTypeExponential:
+	default:
+		return fmt.Errorf("distribution type %q is not supported", o.DistributionType)
+	}
+
+	return nil
+}
+
+// Check at compile time whether DistributionSummaryDataGenerator implements DataSummaryGenerator interface.
+var _ DataSummaryGenerator = (*DistributionSummaryDataGenerator)(nil)
+
+// DistributionSummaryDataGenerator returns a DataSummaryGenerator representing a summary whose observations are drawn
+// from a statistical distribution.
+// The observations are drawn exactly like in the DistributionHistogramDataGenerator. The Count and Sum are cumulative,
+// while the quantiles are computed from the observations recorded within MaxAge of the scrape, just like a summary
+// exposed by a real application.
+// The quantiles are computed with the nearest-rank method over the same set of observations, hence they never cross
+// each other, i.e., a higher quantile is always greater than or equal to a lower quantile. When there are no
+// observations within MaxAge, the quantiles are NaN.
+// The zero value is not useful. Use NewDistributionSummaryDataGenerator function.
+type DistributionSummaryDataGenerator struct {
+	parameters HistogramDistributionParameters
+	options    SummaryDataGeneratorOptions
+}
+
+// NewDistributionSummaryDataGenerator returns a new instance of DistributionSummaryDataGenerator.
+func NewDistributionSummaryDataGenerator(parameters HistogramDistributionParameters, options SummaryDataGeneratorOptions) (*DistributionSummaryDataGenerator, error) {
+	if err := options.validate(); err != nil {
+		return &DistributionSummaryDataGenerator{}, fmt.Errorf("error validating distribution summary data generator configuration: %w", err)
+	}
+
+	if err := parameters.validate(options.DistributionType); err != nil {
+		return &DistributionSummaryDataGenerator{}, fmt.Errorf("error validating distribution summary data generator configuration: %w", err)
+	}
+
+	if options.MaxAge == 0 {
+		options.MaxAge = defaultSummaryMaxAge
+	}
+
+	return &DistributionSummaryDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}, nil
+}
+
+func (dg *DistributionSummaryDataGenerator) Iterator() metrics.DataSummaryIterator {
+	return &DistributionSummaryDataIterator{
+		distributionSummaryDataGenerator: *dg,
+		rand:                             random.NewRand(dg.options.Seed),
+	}
+}
+
+// WithSeed returns a copy of the DistributionSummaryDataGenerator seeded with the seed provided.
+// The parameters are seeded with seeds derived from the seed provided.
+// A seed of zero returns the DistributionSummaryDataGenerator unchanged, so the parameters keep their own seeds.
+func (dg *DistributionSummaryDataGenerator) WithSeed(seed int64) DataSummaryGenerator {
+	if seed == 0 {
+		return dg
+	}
+
+	options := dg.options
+	options.Seed = seed
+
+	parameters := HistogramDistributionParameters{
+		Mean:            WithSeed(dg.parameters.Mean, DeriveSeed(seed, 0)),
+		ObservationRate: WithSeed(dg.parameters.ObservationRate, DeriveSeed(seed, 2)),
+	}
+	if dg.parameters.Spread != nil {
+		parameters.Spread = WithSeed(dg.parameters.Spread, DeriveSeed(seed, 1))
+	}
+
+	return &DistributionSummaryDataGenerator{
+		parameters: parameters,
+		options:    options,
+	}
+}
+
+// Check at compile time whether DistributionSummaryDataIterator implements metrics.DataSummaryIterator interface.
+var _ metrics.DataSummaryIterator = (*DistributionSummaryDataIterator)(nil)
+
+type DistributionSummaryDataIterator struct {
+	// read-only access
+	distributionSummaryDataGenerator DistributionSummaryDataGenerator
+
+	// these variables keep track of the current state of the iterator
+	parametersIterator *histogramParametersIterator
+	rand               *rand.Rand
+
+	// observations contains the observations recorded within MaxAge of the last scrape, from oldest to newest.
+	observations []summaryObservation
+	count        float64
+	sum          float64
+}
+
+// summaryObservation is an observation along with the time it was recorded at.
+type summaryObservation struct {
+	time  time.Time
+	value float64
+}
+
+// Evaluate fulfills the metrics.DataSummaryIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *DistributionSummaryDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeSummaryResult {
+	if di.parametersIterator == nil {
+		di.parametersIterator = newHistogramParametersIterator(di.distributionSummaryDataGenerator.parameters)
+	}
+
+	parameters := di.parametersIterator.evaluate(scrapeInfo)
+
+	if parameters.exhausted {
+		return metrics.ScrapeSummaryResult{Exhausted: true}
+	}
+
+	if parameters.missing {
+		return metrics.ScrapeSummaryResult{Missing: true}
+	}
+
+	sampleObservations(di.rand, di.distributionSummaryDataGenerator.options.DistributionType, parameters, func(observation float64) {
+		di.observations = append(di.observations, summaryObservation{time: scrapeInfo.IterationTime, value: observation})
+		di.count++
+		di.sum += observation
+	})
+
+	di.expireObservations(scrapeInfo.IterationTime)
+
+	return di.result()
+}
+
+// expireObservations drops the observations older than MaxAge.
+func (di *DistributionSummaryDataIterator) expireObservations(now time.Time) {
+	cutoff := now.Add(-di.distributionSummaryDataGenerator.options.MaxAge)
+
+	expired := 0
+	for expired < len(di.observations) && !di.observations[expired].time.After(cutoff) {
+		expired++
+	}
+
+	di.observations = di.observations[expired:]
+}
+
+func (di *DistributionSummaryDataIterator) result() metrics.ScrapeSummaryResult {
+	values := make([]float64, len(di.observations))
+	for i, observation := range di.observations {
+		values[i] = observation.value
+	}
+	sort.Float64s(values)
+
+	quantiles := make([]metrics.SummaryQuantileScrape, len(di.distributionSummaryDataGenerator.options.Quantiles))
+	for i, quantile := range di.distributionSummaryDataGenerator.options.Quantiles {
+		quantiles[i] = metrics.SummaryQuantileScrape{
+			Quantile: quantile,
+			Value:    nearestRankQuantile(values, quantile),
+		}
+	}
+
+	return metrics.ScrapeSummaryResult{
+		Quantiles: quantiles,
+		Count:     di.count,
+		Sum:       di.sum,
+	}
+}
+
+// nearestRankQuantile returns the quantile of the sorted values using the nearest-rank method.
+// The result is non-decreasing in the quantile. It returns NaN if there are no values.
+func nearestRankQuantile(sortedValues []float64, quantile float64) float64 {
+	if len(sortedValues) == 0 {
+		return math.NaN()
+	}
+
+	rank := int(math.Ceil(quantile*float64(len(sortedValues)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	return sortedValues[rank]
+}
@@ -0,0 +1,71 @@
+package discrete
+
+import (
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+// Check at compile time whether SummaryMetricTimeSeries implements promadapter.SummaryMetricTimeSeriesObservable
+// interface.
+var _ promadapter.SummaryMetricTimeSeriesObservable = (*SummaryMetricTimeSeries)(nil)
+
+// SummaryMetricTimeSeries represents a summary metric time series.
+// When the time series iterator gets to the end of the DataSummaryGenerator provided it will evaluate the
+// metrics.EndStrategy to decide on what to do next.
+// Since the end strategy custom value is not a summary, the metrics.EndStrategyTypeSendCustomValue strategy only
+// honours the Missing and Exhausted fields of the custom value, otherwise it behaves like
+// metrics.EndStrategyTypeSendLastValue.
+// Looping starts a new iterator, which resets the summary, just like an application restart would.
+// The zero value of SummaryMetricTimeSeries is not useful. Use NewSummaryMetricTimeSeries function.
+type SummaryMetricTimeSeries struct {
+	labels map[string]string
+
+	dataSummaryGenerator DataSummaryGenerator
+	endStrategy          metrics.EndStrategy
+}
+
+// NewSummaryMetricTimeSeries creates a new instance of SummaryMetricTimeSeries.
+func NewSummaryMetricTimeSeries(labels map[string]string, data DataSummaryGenerator, endStrategy metrics.EndStrategy) *SummaryMetricTimeSeries {
+	return &SummaryMetricTimeSeries{
+		labels:               labels,
+		dataSummaryGenerator: data,
+		endStrategy:          endStrategy,
+	}
+}
+
+// Iterator returns a time series iterator that can be used to iterate over the data.
+func (ts *SummaryMetricTimeSeries) Iterator() metrics.DataSummaryIterator {
+	dataSummaryGenerator := ts.dataSummaryGenerator
+
+	return &SummaryMetricTimeSeriesDataIterator{
+		timeSeriesIterator: metrics.NewTimeSeriesIterator(func() metrics.Evaluator[metrics.ScrapeSummaryResult] {
+			return dataSummaryGenerator.Iterator()
+		}, ts.endStrategy),
+	}
+}
+
+// Labels returns the labels associated with the time series.
+func (ts *SummaryMetricTimeSeries) Labels() map[string]string {
+	return ts.labels
+}
+
+// IsInfinite reports whether this time series is infinite.
+// In other words, whether this time series will never stop generating samples.
+func (ts *SummaryMetricTimeSeries) IsInfinite() bool {
+	return ts.endStrategy.EndStrategyType != metrics.EndStrategyTypeRemoveTimeSeries
+}
+
+// Check at compile time whether SummaryMetricTimeSeriesDataIterator implements metrics.DataSummaryIterator interface.
+var _ metrics.DataSummaryIterator = (*SummaryMetricTimeSeriesDataIterator)(nil)
+
+// SummaryMetricTimeSeriesDataIterator iterates over the data of a SummaryMetricTimeSeries.
+// The end strategy is evaluated by metrics.TimeSeriesIterator.
+type SummaryMetricTimeSeriesDataIterator struct {
+	timeSeriesIterator *metrics.TimeSeriesIterator[metrics.ScrapeSummaryResult]
+}
+
+// Evaluate fulfills the metrics.DataSummaryIterator interface.
+// This function is responsible for returning the data points one at a time.
+func (di *SummaryMetricTimeSeriesDataIterator) Evaluate(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeSummaryResult {
+	return di.timeSeriesIterator.Evaluate(scrapeInfo)
+}
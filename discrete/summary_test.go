@@ -0,0 +1,197 @@
+package discrete_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestDistributionSummaryDataIterator(t *testing.T) {
+	quantiles := []float64{0.5, 0.9, 0.99}
+
+	t.Run("should fail given quantiles out of range", func(t *testing.T) {
+		_, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        []float64{0.5, 1.5},
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution summary data generator configuration: quantiles must be in the range [0,1]"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail given quantiles that are not in increasing order", func(t *testing.T) {
+		_, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 5),
+				ObservationRate: helperConstantDataGenerator(t, 1, 5),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        []float64{0.9, 0.5},
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "error validating distribution summary data generator configuration: quantiles must be in strictly increasing order"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should produce cumulative count and sum and quantiles that never cross", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 0.4, 20),
+				Spread:          helperConstantDataGenerator(t, 0.2, 20),
+				ObservationRate: helperConstantDataGenerator(t, 50, 20),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        quantiles,
+				DistributionType: discrete.DistributionTypeLogNormal,
+				Seed:             42,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, dataGenerator.Iterator())
+		require.Equal(t, 20, len(results))
+
+		for i, result := range results {
+			require.Equal(t, len(quantiles), len(result.Quantiles))
+
+			for j, quantile := range result.Quantiles {
+				assert.Equal(t, quantiles[j], quantile.Quantile)
+				if j > 0 {
+					assert.LessOrEqual(t, result.Quantiles[j-1].Value, quantile.Value)
+				}
+			}
+
+			if i > 0 {
+				assert.LessOrEqual(t, results[i-1].Count, result.Count)
+				assert.LessOrEqual(t, results[i-1].Sum, result.Sum)
+			}
+		}
+
+		last := results[len(results)-1]
+		assert.InDelta(t, 1000, last.Count, 150)
+		assert.InDelta(t, 0.4, last.Sum/last.Count, 0.05)
+		// The median of a log-normal distribution is lower than its mean.
+		assert.Less(t, last.Quantiles[0].Value, 0.4)
+	})
+
+	t.Run("should only compute the quantiles from the observations within max age", func(t *testing.T) {
+		// The mean goes from 1 to 100 halfway through. Given a max age of 2 scrapes, the quantiles only reflect the new
+		// mean shortly after the change, while the count and sum keep every observation.
+		mean := discrete.NewJoinDataGenerator([]discrete.DataGenerator{
+			helperConstantDataGenerator(t, 1, 10),
+			helperConstantDataGenerator(t, 100, 10),
+		})
+
+		dataGenerator, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            mean,
+				Spread:          helperConstantDataGenerator(t, 0, 20),
+				ObservationRate: helperConstantDataGenerator(t, 10, 20),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        []float64{0, 1},
+				MaxAge:           30 * time.Second,
+				DistributionType: discrete.DistributionTypeNormal,
+				Seed:             3,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, dataGenerator.Iterator())
+		require.Equal(t, 20, len(results))
+
+		assert.Equal(t, 1.0, results[9].Quantiles[1].Value)
+		assert.Equal(t, 1.0, results[10].Quantiles[0].Value)
+		assert.Equal(t, 100.0, results[10].Quantiles[1].Value)
+		assert.Equal(t, 100.0, results[12].Quantiles[0].Value)
+		assert.Less(t, results[12].Sum/results[12].Count, 100.0)
+	})
+
+	t.Run("should return NaN quantiles when there are no observations", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 1, 2),
+				ObservationRate: helperConstantDataGenerator(t, 0, 2),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        quantiles,
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.NoError(t, err)
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, dataGenerator.Iterator())
+		require.Equal(t, 2, len(results))
+		assert.Equal(t, 0.0, results[1].Count)
+		for _, quantile := range results[1].Quantiles {
+			assert.True(t, math.IsNaN(quantile.Value))
+		}
+	})
+
+	t.Run("should produce the same summaries given the same seed", func(t *testing.T) {
+		dataGenerator, err := discrete.NewDistributionSummaryDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            helperConstantDataGenerator(t, 0.3, 10),
+				ObservationRate: helperConstantDataGenerator(t, 20, 10),
+			},
+			discrete.SummaryDataGeneratorOptions{
+				Quantiles:        quantiles,
+				DistributionType: discrete.DistributionTypeExponential,
+			},
+		)
+		require.NoError(t, err)
+
+		seededDataGenerator := dataGenerator.WithSeed(7)
+
+		assert.Equal(t,
+			helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, seededDataGenerator.Iterator()),
+			helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, seededDataGenerator.Iterator()),
+		)
+	})
+}
+
+func TestSummaryMetricTimeSeries(t *testing.T) {
+	dataGenerator, err := discrete.NewDistributionSummaryDataGenerator(
+		discrete.HistogramDistributionParameters{
+			Mean:            helperConstantDataGenerator(t, 0.3, 3),
+			ObservationRate: helperConstantDataGenerator(t, 20, 3),
+		},
+		discrete.SummaryDataGeneratorOptions{
+			Quantiles:        []float64{0.5, 0.9},
+			DistributionType: discrete.DistributionTypeExponential,
+			Seed:             5,
+		},
+	)
+	require.NoError(t, err)
+
+	t.Run("should send the last summary forever given the send last value end strategy", func(t *testing.T) {
+		timeSeries := discrete.NewSummaryMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategySendLastValue())
+		assert.True(t, timeSeries.IsInfinite())
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, timeSeries.Iterator())
+		require.Equal(t, 100, len(results))
+		assert.Equal(t, results[2], results[99])
+	})
+
+	t.Run("should reset the summary given the loop end strategy", func(t *testing.T) {
+		timeSeries := discrete.NewSummaryMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyLoop())
+
+		results := helperResultScraper(t, (*metrics.Scraper).ScrapeDataSummaryIterator, timeSeries.Iterator())
+		require.Equal(t, 100, len(results))
+		assert.Equal(t, results[0].Count, results[3].Count)
+	})
+}
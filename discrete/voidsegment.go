@@ -27,9 +27,7 @@ func (dg *VoidSegmentDataGenerator) Iterator() metrics.DataIterator {
 }
 
 func (dg *VoidSegmentDataGenerator) Describe() DataSpec {
-	return DataNodeDataSpec{
-		name: "Void",
-	}
+	return NewDataNodeDataSpec(DataGeneratorTypeVoidSegment, "Void", dg.count)
 }
 
 // Check at compile time whether VoidDataIterator implements metrics.DataIterator interface.
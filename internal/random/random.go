@@ -0,0 +1,18 @@
+package random
+
+import (
+	"math/rand"
+)
+
+// NewRand returns a new source of randomness given the seed.
+// A seed of zero means the source of randomness is not seeded, i.e., it is seeded from the global source, so every call
+// returns a source producing a different sequence.
+// It's shared by everything that can be seeded, i.e., the metrics.Scraper and the data generators relying on
+// randomness.
+func NewRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+
+	return rand.New(rand.NewSource(seed))
+}
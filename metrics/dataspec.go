@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"fmt"
+)
+
+// DataSpec describes a DataGenerator.
+// It's necessary to type assert to the type returned by the DataGeneratorNodeType method.
+type DataSpec interface {
+	DataGeneratorNodeType() DataGeneratorNodeType
+	Name() string
+}
+
+type DataGeneratorNodeType string
+
+const (
+	DataGeneratorNodeTypeData      DataGeneratorNodeType = "data_generator_node_type-data"
+	DataGeneratorNodeTypeContainer DataGeneratorNodeType = "data_generator_node_type-container"
+)
+
+// DataGeneratorType identifies a concrete data generator.
+// It's used as the type discriminator when serializing a DataSpec.
+type DataGeneratorType string
+
+// Check at compile time whether DataNodeDataSpec implements DataSpec interface.
+var _ DataSpec = (*DataNodeDataSpec)(nil)
+
+// DataNodeDataSpec implements a generic DataSpec for data shapes.
+type DataNodeDataSpec struct {
+	dataGeneratorType DataGeneratorType
+	name              string
+
+	// options contains the arguments the data generator was created with, i.e., the arguments passed to its
+	// constructor.
+	options any
+}
+
+// NewDataNodeDataSpec returns a new instance of DataNodeDataSpec.
+// The options must hold the arguments the data generator is created with, so it can be serialized and built back by a
+// BuilderRegistry.
+func NewDataNodeDataSpec(dataGeneratorType DataGeneratorType, name string, options any) DataNodeDataSpec {
+	return DataNodeDataSpec{
+		dataGeneratorType: dataGeneratorType,
+		name:              name,
+		options:           options,
+	}
+}
+
+func (ds DataNodeDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
+	return DataGeneratorNodeTypeData
+}
+
+func (ds DataNodeDataSpec) DataGeneratorType() DataGeneratorType {
+	return ds.dataGeneratorType
+}
+
+func (ds DataNodeDataSpec) Name() string {
+	return ds.name
+}
+
+// Options returns the options the data generator was created with.
+// It returns nil if the data generator doesn't take any options.
+func (ds DataNodeDataSpec) Options() any {
+	return ds.options
+}
+
+// Check at compile time whether ContainerDataSpec implements DataSpec interface.
+var _ DataSpec = (*ContainerDataSpec)(nil)
+
+// ContainerDataSpec implements a generic DataSpec for containers, i.e., data generators made up of other data
+// generators, such as the continuous Join and Loop.
+type ContainerDataSpec struct {
+	dataGeneratorType DataGeneratorType
+	name              string
+
+	// options contains the arguments the container was created with, other than its children.
+	options any
+
+	children []DataSpec
+}
+
+// NewContainerDataSpec returns a new instance of ContainerDataSpec.
+// The options must hold the arguments the container is created with, other than its children, so it can be
+// serialized and built back by a BuilderRegistry. They can be nil if the container doesn't take any options.
+func NewContainerDataSpec(dataGeneratorType DataGeneratorType, name string, options any, children []DataSpec) ContainerDataSpec {
+	return ContainerDataSpec{
+		dataGeneratorType: dataGeneratorType,
+		name:              name,
+		options:           options,
+		children:          children,
+	}
+}
+
+func (ds ContainerDataSpec) DataGeneratorNodeType() DataGeneratorNodeType {
+	return DataGeneratorNodeTypeContainer
+}
+
+func (ds ContainerDataSpec) DataGeneratorType() DataGeneratorType {
+	return ds.dataGeneratorType
+}
+
+func (ds ContainerDataSpec) Name() string {
+	return ds.name
+}
+
+// Options returns the options the container was created with.
+// It returns nil if the container doesn't take any options.
+func (ds ContainerDataSpec) Options() any {
+	return ds.options
+}
+
+// Children returns the DataSpec of the data generators the container is made up of.
+func (ds ContainerDataSpec) Children() []DataSpec {
+	return ds.children
+}
+
+// BuilderRegistry is implemented by the registries that build DataGenerators back from their DataSpec, such as the
+// discrete.Registry.
+// It allows data generators to register how they're built without depending on the registry itself. Use
+// RegisterDataNodeBuilder and RegisterContainerBuilder rather than calling its methods directly.
+type BuilderRegistry interface {
+	SetDataNodeBuilder(dataGeneratorType DataGeneratorType, builder DataNodeBuilder)
+	SetContainerBuilder(dataGeneratorType DataGeneratorType, builder ContainerBuilder)
+}
+
+// DataNodeBuilder knows how to decode the options of a data node and how to build the data node from its options.
+type DataNodeBuilder struct {
+	// DecodeOptions decodes the options using the decode function provided, which unmarshals the serialized options
+	// into the target provided.
+	DecodeOptions func(decode func(target any) error) (any, error)
+
+	Build func(options any) (DataGenerator, error)
+}
+
+// ContainerBuilder knows how to decode the options of a container and how to build the container from its options
+// and its children.
+type ContainerBuilder struct {
+	// DecodeOptions decodes the options using the decode function provided, which unmarshals the serialized options
+	// into the target provided.
+	DecodeOptions func(decode func(target any) error) (any, error)
+
+	Build func(options any, children []DataGenerator) (DataGenerator, error)
+}
+
+// RegisterDataNodeBuilder registers the function used to build data nodes of the given DataGeneratorType.
+// The options type T must match the type of the options held by the DataNodeDataSpec returned by the data generator.
+// When unmarshalling, the options are decoded into a value of type T.
+// Registering a DataGeneratorType that is already registered replaces the previous builder.
+func RegisterDataNodeBuilder[T any](registry BuilderRegistry, dataGeneratorType DataGeneratorType, build func(options T) (DataGenerator, error)) {
+	registry.SetDataNodeBuilder(dataGeneratorType, DataNodeBuilder{
+		DecodeOptions: decodeOptions[T],
+		Build: func(options any) (DataGenerator, error) {
+			optionsConcrete, ok := options.(T)
+			if !ok {
+				var expected T
+				return nil, fmt.Errorf("options for data generator type %q must be of type %T, got %T", dataGeneratorType, expected, options)
+			}
+			return build(optionsConcrete)
+		},
+	})
+}
+
+// RegisterContainerBuilder registers the function used to build containers of the given DataGeneratorType.
+// The options type T must match the type of the options held by the ContainerDataSpec returned by the container. A
+// ContainerDataSpec without options is built with the zero value of T.
+// When unmarshalling, the options are decoded into a value of type T.
+// Registering a DataGeneratorType that is already registered replaces the previous builder.
+func RegisterContainerBuilder[T any](registry BuilderRegistry, dataGeneratorType DataGeneratorType, build func(options T, children []DataGenerator) (DataGenerator, error)) {
+	registry.SetContainerBuilder(dataGeneratorType, ContainerBuilder{
+		DecodeOptions: decodeOptions[T],
+		Build: func(options any, children []DataGenerator) (DataGenerator, error) {
+			if options == nil {
+				var zero T
+				return build(zero, children)
+			}
+
+			optionsConcrete, ok := options.(T)
+			if !ok {
+				var expected T
+				return nil, fmt.Errorf("options for data generator type %q must be of type %T, got %T", dataGeneratorType, expected, options)
+			}
+			return build(optionsConcrete, children)
+		},
+	})
+}
+
+func decodeOptions[T any](decode func(target any) error) (any, error) {
+	var options T
+	if err := decode(&options); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
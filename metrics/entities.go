@@ -68,8 +68,66 @@ func (f DataHistogramIteratorFunc) Evaluate(scrapeInfo ScrapeInfo) ScrapeHistogr
 // returned error and return it.
 // The field 'Exhausted' in the struct ScrapeResult will never be set, since if the scraper has exhausted the
 // DataIterator it will automatically stop.
+// The field 'Err' in the struct ScrapeHistogramResult reports an inconsistent histogram. Return it to stop the scrapping
+// on inconsistencies.
 type ScrapeHistogramHandler func(scrapeInfo ScrapeInfo, scrapeHistogramResult ScrapeHistogramResult) error
 
+// DataNativeHistogramIterator defines the interface iterators returned by native histogram DataGenerators need to
+// comply with.
+// The interface defines an iterator and therefore each time it's called, it returns the next value in the series.
+// The field Exhausted in the ScrapeNativeHistogramResult struct reports whether there is no more data to be returned by
+// the iterator.
+// Missing scrapes can be simulated by setting the Missing field to true.
+type DataNativeHistogramIterator interface {
+	Evaluate(scrapeInfo ScrapeInfo) ScrapeNativeHistogramResult
+}
+
+// The DataNativeHistogramIteratorFunc type is an adapter to allow the use of ordinary functions as
+// DataNativeHistogramIterator. If f is a function with the appropriate signature, DataNativeHistogramIteratorFunc(f) is
+// a DataNativeHistogramIterator that calls f.
+type DataNativeHistogramIteratorFunc func(scrapeInfo ScrapeInfo) ScrapeNativeHistogramResult
+
+// Evaluate calls f(scrapeInfo).
+func (f DataNativeHistogramIteratorFunc) Evaluate(scrapeInfo ScrapeInfo) ScrapeNativeHistogramResult {
+	return f(scrapeInfo)
+}
+
+// ScrapeNativeHistogramHandler defines the function type to be used when calling the ScrapeDataNativeHistogramIterator
+// method of the Scraper.
+// This function is specifically used with native histogram metrics.
+// Return an error to stop the scrapping from proceeding any further. The ScrapeDataNativeHistogramIterator method will
+// wrap the returned error and return it.
+// The field 'Exhausted' in the struct ScrapeNativeHistogramResult will never be set, since if the scraper has exhausted
+// the DataNativeHistogramIterator it will automatically stop.
+type ScrapeNativeHistogramHandler func(scrapeInfo ScrapeInfo, scrapeNativeHistogramResult ScrapeNativeHistogramResult) error
+
+// DataSummaryIterator defines the interface iterators returned by summary DataGenerators need to comply with.
+// The interface defines an iterator and therefore each time it's called, it returns the next value in the series.
+// The field Exhausted in the ScrapeSummaryResult struct reports whether there is no more data to be returned by the
+// iterator.
+// Missing scrapes can be simulated by setting the Missing field to true.
+type DataSummaryIterator interface {
+	Evaluate(scrapeInfo ScrapeInfo) ScrapeSummaryResult
+}
+
+// The DataSummaryIteratorFunc type is an adapter to allow the use of ordinary functions as DataSummaryIterator.
+// If f is a function with the appropriate signature, DataSummaryIteratorFunc(f) is a DataSummaryIterator that calls f.
+type DataSummaryIteratorFunc func(scrapeInfo ScrapeInfo) ScrapeSummaryResult
+
+// Evaluate calls f(scrapeInfo).
+func (f DataSummaryIteratorFunc) Evaluate(scrapeInfo ScrapeInfo) ScrapeSummaryResult {
+	return f(scrapeInfo)
+}
+
+// ScrapeSummaryHandler defines the function type to be used when calling the ScrapeDataSummaryIterator method of the
+// Scraper.
+// This function is specifically used with summary metrics.
+// Return an error to stop the scrapping from proceeding any further. The ScrapeDataSummaryIterator method will wrap the
+// returned error and return it.
+// The field 'Exhausted' in the struct ScrapeSummaryResult will never be set, since if the scraper has exhausted the
+// DataSummaryIterator it will automatically stop.
+type ScrapeSummaryHandler func(scrapeInfo ScrapeInfo, scrapeSummaryResult ScrapeSummaryResult) error
+
 // ScrapeInfo contains information about the scrape.
 // Namely, information of when the scrape is happening.
 type ScrapeInfo struct {
@@ -81,7 +139,22 @@ type ScrapeInfo struct {
 	IterationIndex int
 
 	// IterationTime specifies the time of this iteration.
+	// It's the actual time of the scrape, which includes the jitter and the drift of the scraper, if any.
 	IterationTime time.Time
+
+	// IterationTimeOffset is the difference between the actual time of the scrape and the time the scrape was
+	// scheduled at, i.e., the jitter and the drift accumulated so far.
+	IterationTimeOffset time.Duration
+
+	// Skipped indicates whether the scrape was skipped, i.e., the data iterators are evaluated, as the system being
+	// observed keeps going, but the sample is not collected.
+	Skipped bool
+}
+
+// NominalIterationTime returns the time the scrape was scheduled at, i.e., the time of the scrape without any jitter or
+// drift.
+func (si ScrapeInfo) NominalIterationTime() time.Time {
+	return si.IterationTime.Add(-si.IterationTimeOffset)
 }
 
 // ScrapeResult contains the scrape outcome.
@@ -94,6 +167,10 @@ type ScrapeResult struct {
 	// Used to simulate failed scrapes.
 	Missing bool
 
+	// Stale indicates whether the time series is stale at this scrape, i.e., a stale marker is sent instead of a sample.
+	// Unlike Exhausted, the time series may carry on returning samples afterwards.
+	Stale bool
+
 	// Exhausted indicates whether the data generator has no more data to return.
 	Exhausted bool
 }
@@ -114,6 +191,10 @@ type ScrapeHistogramResult struct {
 
 	// Exhausted indicates whether the data generator has no more data to return.
 	Exhausted bool
+
+	// Err reports why the histogram is inconsistent, for example because its buckets are not cumulative.
+	// It's set by the ValidatingHistogramIterator.
+	Err error
 }
 
 // HistogramBucketScrape represents a single scrape for a single histogram bucket.
@@ -124,3 +205,83 @@ type HistogramBucketScrape struct {
 	// Value is the value of the sample.
 	Value float64
 }
+
+// ScrapeSummaryResult contains the scrape outcome.
+// Used for summaries.
+type ScrapeSummaryResult struct {
+	// Quantiles contains the quantiles of the summary, in increasing order of quantile.
+	Quantiles []SummaryQuantileScrape
+	// Count is the number of occurances recorded by this summary.
+	Count float64
+	// Sum is the total sum of all the values in the summary.
+	Sum float64
+
+	// Missing indicates whether the scrape failed to retrieve a sample.
+	// Used to simulate failed scrapes.
+	Missing bool
+
+	// Exhausted indicates whether the data generator has no more data to return.
+	Exhausted bool
+}
+
+// SummaryQuantileScrape represents a single scrape for a single summary quantile.
+type SummaryQuantileScrape struct {
+	// Quantile represents the quantile, in the range [0,1].
+	Quantile float64
+
+	// Value is the value of the sample.
+	// It's NaN when there are no observations to compute the quantile from.
+	Value float64
+}
+
+// ScrapeNativeHistogramResult contains the scrape outcome.
+// Used for native (also known as sparse or exponential) histograms.
+// The buckets are encoded the same way as in the Prometheus exposition and remote write protobuf formats, i.e., as a
+// list of spans of consecutive buckets and the deltas between the counts of consecutive buckets.
+// Ref: https://prometheus.io/docs/specs/native_histograms/
+type ScrapeNativeHistogramResult struct {
+	// Schema defines the resolution of the buckets. Valid values go from -4 to 8.
+	// Each power of two is divided into 2^Schema buckets, i.e., the upper bound of the bucket with index i is
+	// 2^(i*2^-Schema).
+	Schema int32
+
+	// ZeroThreshold is the width of the zero bucket. Observations in the interval [-ZeroThreshold,ZeroThreshold] are
+	// counted in the zero bucket.
+	ZeroThreshold float64
+	// ZeroCount is the number of observations counted in the zero bucket.
+	ZeroCount uint64
+
+	// Count is the number of occurances recorded by this histogram.
+	Count uint64
+	// Sum is the total sum of all the values in the histogram.
+	Sum float64
+
+	// PositiveSpans contains the spans of the buckets for positive observations.
+	PositiveSpans []NativeHistogramBucketSpan
+	// PositiveDeltas contains the count of the first bucket followed by the delta of the count of each bucket to the
+	// previous one, for positive observations.
+	PositiveDeltas []int64
+
+	// NegativeSpans contains the spans of the buckets for negative observations.
+	NegativeSpans []NativeHistogramBucketSpan
+	// NegativeDeltas contains the count of the first bucket followed by the delta of the count of each bucket to the
+	// previous one, for negative observations.
+	NegativeDeltas []int64
+
+	// Missing indicates whether the scrape failed to retrieve a sample.
+	// Used to simulate failed scrapes.
+	Missing bool
+
+	// Exhausted indicates whether the data generator has no more data to return.
+	Exhausted bool
+}
+
+// NativeHistogramBucketSpan represents a span of consecutive buckets of a native histogram.
+type NativeHistogramBucketSpan struct {
+	// Offset is the gap between the index of the first bucket of this span and the index of the last bucket of the
+	// previous span. For the first span, it's the index of its first bucket.
+	Offset int32
+
+	// Length is the number of consecutive buckets in the span.
+	Length uint32
+}
@@ -0,0 +1,13 @@
+package metrics
+
+// DataGenerator is the abstraction shared by every data generator, regardless of the model it follows.
+// Discrete data generators compute a sample on every scrape and are bounded by a number of iterations, while continuous
+// data generators compute the sample given the time of the scrape and are bounded by a duration. Either way, they hand
+// out DataIterators, which is all the Scraper, the time series and the containers (e.g., Join) need, and describe
+// themselves with a DataSpec. This allows both models to be mixed in the same tree, using whichever model fits each
+// phase of a scenario, and the tree to be serialized and built back.
+// Every call to Iterator must return a new DataIterator, starting from the beginning of the data.
+type DataGenerator interface {
+	Iterator() DataIterator
+	Describe() DataSpec
+}
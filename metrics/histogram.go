@@ -0,0 +1,254 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// LinearBuckets returns count buckets, each width wide, where the lowest bucket has an upper bound of start.
+// The +Inf bucket is implicit and is not included.
+func LinearBuckets(start float64, width float64, count int) ([]float64, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("error creating linear buckets: count must be greater than zero")
+	}
+
+	if width <= 0 || math.IsInf(width, 0) || math.IsNaN(width) {
+		return nil, fmt.Errorf("error creating linear buckets: width must be a finite number greater than zero")
+	}
+
+	if math.IsInf(start, 0) || math.IsNaN(start) {
+		return nil, fmt.Errorf("error creating linear buckets: start must be a finite number")
+	}
+
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start + float64(i)*width
+	}
+
+	return buckets, nil
+}
+
+// ExponentialBuckets returns count buckets, where the lowest bucket has an upper bound of start and the upper bound of
+// each following bucket is factor times the upper bound of the previous bucket.
+// The +Inf bucket is implicit and is not included.
+func ExponentialBuckets(start float64, factor float64, count int) ([]float64, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("error creating exponential buckets: count must be greater than zero")
+	}
+
+	if start <= 0 || math.IsInf(start, 0) || math.IsNaN(start) {
+		return nil, fmt.Errorf("error creating exponential buckets: start must be a finite number greater than zero")
+	}
+
+	if factor <= 1 || math.IsInf(factor, 0) || math.IsNaN(factor) {
+		return nil, fmt.Errorf("error creating exponential buckets: factor must be a finite number greater than one")
+	}
+
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start * math.Pow(factor, float64(i))
+	}
+
+	return buckets, nil
+}
+
+// ExplicitBuckets returns the buckets with the upper bounds provided, sorted in increasing order.
+// A +Inf upper bound is dropped, since the +Inf bucket is implicit. Duplicated upper bounds are not allowed.
+func ExplicitBuckets(upperBounds ...float64) ([]float64, error) {
+	buckets := make([]float64, 0, len(upperBounds))
+	for _, upperBound := range upperBounds {
+		if math.IsInf(upperBound, 1) {
+			continue
+		}
+
+		if math.IsInf(upperBound, -1) || math.IsNaN(upperBound) {
+			return nil, fmt.Errorf("error creating explicit buckets: upper bounds must be finite numbers")
+		}
+
+		buckets = append(buckets, upperBound)
+	}
+
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("error creating explicit buckets: at least one finite upper bound must be provided")
+	}
+
+	sort.Float64s(buckets)
+
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] == buckets[i-1] {
+			return nil, fmt.Errorf("error creating explicit buckets: duplicated upper bound %g", buckets[i])
+		}
+	}
+
+	return buckets, nil
+}
+
+// ValidatingHistogramIteratorOptions contains the options for the ValidatingHistogramIterator.
+type ValidatingHistogramIteratorOptions struct {
+	// AutoCorrect fixes the inconsistencies found, instead of reporting them.
+	AutoCorrect bool
+}
+
+// Check at compile time whether ValidatingHistogramIterator implements DataHistogramIterator interface.
+var _ DataHistogramIterator = (*ValidatingHistogramIterator)(nil)
+
+// ValidatingHistogramIterator wraps a DataHistogramIterator and checks that every histogram it returns is consistent.
+// This is particularly useful when the histograms are written by hand, for example with DataHistogramIteratorFunc.
+//
+// A histogram is consistent when:
+//   - The buckets are sorted by upper bound, with no duplicated or NaN upper bounds.
+//   - The buckets are cumulative, i.e., the count of each bucket is non-negative and greater than or equal to the count
+//     of the previous bucket.
+//   - The Count is greater than or equal to the count of every bucket. If the +Inf bucket is present, its count is
+//     equal to the Count.
+//   - The Count and the count of every bucket never decrease between scrapes. The only exception is a histogram reset,
+//     i.e., the Count is lower than the Count of the previous scrape, just like an application restart.
+//
+// By default, inconsistent histograms are returned with the Err field set, which is how the violations are reported to
+// the ScrapeHistogramHandler. If auto correction is enabled, the histograms are fixed instead: the buckets are sorted,
+// NaN upper bounds are dropped, duplicated upper bounds are merged and the counts are raised to the lowest values that
+// make the histogram consistent.
+// Missing and exhausted scrapes are passed through untouched.
+// The zero value is not useful. Use NewValidatingHistogramIterator function.
+type ValidatingHistogramIterator struct {
+	dataHistogramIterator DataHistogramIterator
+	options               ValidatingHistogramIteratorOptions
+
+	// previous contains the last consistent histogram returned, used to check that the counts never decrease.
+	previous *ScrapeHistogramResult
+}
+
+// NewValidatingHistogramIterator returns a new instance of ValidatingHistogramIterator.
+func NewValidatingHistogramIterator(dataHistogramIterator DataHistogramIterator, options ValidatingHistogramIteratorOptions) *ValidatingHistogramIterator {
+	return &ValidatingHistogramIterator{
+		dataHistogramIterator: dataHistogramIterator,
+		options:               options,
+	}
+}
+
+// Evaluate fulfills the DataHistogramIterator interface.
+func (vi *ValidatingHistogramIterator) Evaluate(scrapeInfo ScrapeInfo) ScrapeHistogramResult {
+	result := vi.dataHistogramIterator.Evaluate(scrapeInfo)
+	if result.Missing || result.Exhausted {
+		return result
+	}
+
+	// A lower count means the histogram has been reset, so there is nothing to compare with.
+	previous := vi.previous
+	if previous != nil && result.Count < previous.Count {
+		previous = nil
+	}
+
+	if vi.options.AutoCorrect {
+		result = correctHistogram(result, previous)
+	} else if err := validateHistogram(result, previous); err != nil {
+		result.Err = fmt.Errorf("inconsistent histogram: %w", err)
+	}
+
+	// An inconsistent histogram must not become the baseline, otherwise the valid histograms following it would be
+	// reported as inconsistent as well.
+	if result.Err == nil {
+		vi.previous = &result
+	}
+
+	return result
+}
+
+// validateHistogram returns the first inconsistency found in the histogram, given the previous histogram, if any.
+func validateHistogram(result ScrapeHistogramResult, previous *ScrapeHistogramResult) error {
+	for i, bucket := range result.Buckets {
+		if math.IsNaN(bucket.LE) {
+			return fmt.Errorf("bucket %d has a NaN upper bound", i)
+		}
+
+		if bucket.Value < 0 {
+			return fmt.Errorf("bucket with upper bound %g has a negative count", bucket.LE)
+		}
+
+		if i > 0 && bucket.LE <= result.Buckets[i-1].LE {
+			return fmt.Errorf("buckets are not sorted by upper bound")
+		}
+
+		if i > 0 && bucket.Value < result.Buckets[i-1].Value {
+			return fmt.Errorf("buckets are not cumulative: bucket with upper bound %g has a lower count than the previous one", bucket.LE)
+		}
+
+		if bucket.Value > result.Count {
+			return fmt.Errorf("count %g is lower than the count of the bucket with upper bound %g", result.Count, bucket.LE)
+		}
+
+		if math.IsInf(bucket.LE, 1) && bucket.Value != result.Count {
+			return fmt.Errorf("+Inf bucket count %g does not match count %g", bucket.Value, result.Count)
+		}
+	}
+
+	if previous == nil {
+		return nil
+	}
+
+	previousBuckets := bucketCountsByUpperBound(previous.Buckets)
+	for _, bucket := range result.Buckets {
+		if previousValue, ok := previousBuckets[bucket.LE]; ok && bucket.Value < previousValue {
+			return fmt.Errorf("count of the bucket with upper bound %g decreased from %g to %g", bucket.LE, previousValue, bucket.Value)
+		}
+	}
+
+	return nil
+}
+
+// correctHistogram returns a consistent copy of the histogram, given the previous histogram, if any.
+func correctHistogram(result ScrapeHistogramResult, previous *ScrapeHistogramResult) ScrapeHistogramResult {
+	// Merge the duplicated upper bounds and drop the NaN upper bounds.
+	bucketCounts := make(map[float64]float64, len(result.Buckets))
+	for _, bucket := range result.Buckets {
+		if math.IsNaN(bucket.LE) {
+			continue
+		}
+
+		if value, ok := bucketCounts[bucket.LE]; !ok || bucket.Value > value {
+			bucketCounts[bucket.LE] = bucket.Value
+		}
+	}
+
+	// Counts can't go lower than on the previous scrape.
+	if previous != nil {
+		for le, previousValue := range bucketCountsByUpperBound(previous.Buckets) {
+			if value, ok := bucketCounts[le]; ok && value < previousValue {
+				bucketCounts[le] = previousValue
+			}
+		}
+	}
+
+	buckets := make([]HistogramBucketScrape, 0, len(bucketCounts))
+	for le, value := range bucketCounts {
+		buckets = append(buckets, HistogramBucketScrape{LE: le, Value: value})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].LE < buckets[j].LE })
+
+	// Make the buckets cumulative.
+	runningCount := 0.0
+	for i := range buckets {
+		runningCount = math.Max(runningCount, buckets[i].Value)
+		buckets[i].Value = runningCount
+	}
+
+	result.Buckets = buckets
+	result.Count = math.Max(result.Count, runningCount)
+
+	if len(buckets) > 0 && math.IsInf(buckets[len(buckets)-1].LE, 1) {
+		buckets[len(buckets)-1].Value = result.Count
+	}
+
+	return result
+}
+
+// bucketCountsByUpperBound indexes the bucket counts by upper bound.
+func bucketCountsByUpperBound(buckets []HistogramBucketScrape) map[float64]float64 {
+	bucketCounts := make(map[float64]float64, len(buckets))
+	for _, bucket := range buckets {
+		bucketCounts[bucket.LE] = bucket.Value
+	}
+
+	return bucketCounts
+}
@@ -0,0 +1,177 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestBuckets(t *testing.T) {
+	t.Run("should return linear buckets", func(t *testing.T) {
+		buckets, err := metrics.LinearBuckets(0.5, 0.25, 4)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0.5, 0.75, 1, 1.25}, buckets)
+	})
+
+	t.Run("should fail given linear buckets with a width of zero", func(t *testing.T) {
+		_, err := metrics.LinearBuckets(0, 0, 4)
+		require.Error(t, err)
+		assert.Equal(t, "error creating linear buckets: width must be a finite number greater than zero", err.Error())
+	})
+
+	t.Run("should return exponential buckets", func(t *testing.T) {
+		buckets, err := metrics.ExponentialBuckets(0.01, 10, 4)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(buckets))
+		for i, expected := range []float64{0.01, 0.1, 1, 10} {
+			assert.InDelta(t, expected, buckets[i], 1e-9)
+		}
+	})
+
+	t.Run("should fail given exponential buckets with a factor of one", func(t *testing.T) {
+		_, err := metrics.ExponentialBuckets(1, 1, 4)
+		require.Error(t, err)
+		assert.Equal(t, "error creating exponential buckets: factor must be a finite number greater than one", err.Error())
+	})
+
+	t.Run("should return explicit buckets sorted and without the +Inf bucket", func(t *testing.T) {
+		buckets, err := metrics.ExplicitBuckets(1, math.Inf(1), 0.1, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0.1, 0.5, 1}, buckets)
+	})
+
+	t.Run("should fail given duplicated explicit buckets", func(t *testing.T) {
+		_, err := metrics.ExplicitBuckets(1, 0.5, 1)
+		require.Error(t, err)
+		assert.Equal(t, "error creating explicit buckets: duplicated upper bound 1", err.Error())
+	})
+}
+
+func TestValidatingHistogramIterator(t *testing.T) {
+	// helperScrape scrapes the histograms provided, one per scrape, through a ValidatingHistogramIterator.
+	helperScrape := func(t *testing.T, options metrics.ValidatingHistogramIteratorOptions, histograms ...metrics.ScrapeHistogramResult) ([]metrics.ScrapeHistogramResult, error) {
+		t.Helper()
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(len(histograms)),
+		)
+		require.NoError(t, err)
+
+		iterator := metrics.NewValidatingHistogramIterator(
+			metrics.DataHistogramIteratorFunc(func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+				return histograms[scrapeInfo.IterationIndex]
+			}),
+			options,
+		)
+
+		var results []metrics.ScrapeHistogramResult
+		err = scraper.ScrapeDataHistogramIterator(iterator, func(_ metrics.ScrapeInfo, scrapeHistogramResult metrics.ScrapeHistogramResult) error {
+			results = append(results, scrapeHistogramResult)
+			return scrapeHistogramResult.Err
+		})
+
+		return results, err
+	}
+
+	t.Run("should pass consistent histograms through, including resets", func(t *testing.T) {
+		histograms := []metrics.ScrapeHistogramResult{
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 1}, {LE: 1, Value: 2}}, Count: 3, Sum: 3},
+			{Missing: true},
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 4}, {LE: math.Inf(1), Value: 6}}, Count: 6, Sum: 6},
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 0}, {LE: 1, Value: 1}}, Count: 1, Sum: 1},
+		}
+
+		results, err := helperScrape(t, metrics.ValidatingHistogramIteratorOptions{}, histograms...)
+		require.NoError(t, err)
+		assert.Equal(t, histograms, results)
+	})
+
+	t.Run("should report buckets that are not cumulative", func(t *testing.T) {
+		_, err := helperScrape(t, metrics.ValidatingHistogramIteratorOptions{},
+			metrics.ScrapeHistogramResult{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 1}}, Count: 3},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "failed while calling scrape handler: inconsistent histogram: buckets are not cumulative: bucket with upper bound 1 has a lower count than the previous one"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should report a +Inf bucket that does not match the count", func(t *testing.T) {
+		_, err := helperScrape(t, metrics.ValidatingHistogramIteratorOptions{},
+			metrics.ScrapeHistogramResult{Buckets: []metrics.HistogramBucketScrape{{LE: 1, Value: 1}, {LE: math.Inf(1), Value: 2}}, Count: 3},
+		)
+		require.Error(t, err)
+		expectedErrorMessage := "failed while calling scrape handler: inconsistent histogram: +Inf bucket count 2 does not match count 3"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should report bucket counts that decrease between scrapes", func(t *testing.T) {
+		results, err := helperScrape(t, metrics.ValidatingHistogramIteratorOptions{},
+			metrics.ScrapeHistogramResult{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 3}}, Count: 3},
+			metrics.ScrapeHistogramResult{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 1}, {LE: 1, Value: 4}}, Count: 4},
+		)
+		require.Error(t, err)
+		require.Equal(t, 2, len(results))
+		assert.NoError(t, results[0].Err)
+		expectedErrorMessage := "inconsistent histogram: count of the bucket with upper bound 0.5 decreased from 2 to 1"
+		assert.Equal(t, expectedErrorMessage, results[1].Err.Error())
+	})
+
+	t.Run("should compare with the last consistent histogram given an inconsistent one in between", func(t *testing.T) {
+		histograms := []metrics.ScrapeHistogramResult{
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 1}, {LE: 1, Value: 2}}, Count: 2},
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 5}, {LE: 1, Value: 10}}, Count: 5},
+			{Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 3}}, Count: 6},
+		}
+
+		iterator := metrics.NewValidatingHistogramIterator(
+			metrics.DataHistogramIteratorFunc(func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+				return histograms[scrapeInfo.IterationIndex]
+			}),
+			metrics.ValidatingHistogramIteratorOptions{},
+		)
+
+		assert.NoError(t, iterator.Evaluate(metrics.ScrapeInfo{IterationIndex: 0}).Err)
+		assert.Error(t, iterator.Evaluate(metrics.ScrapeInfo{IterationIndex: 1}).Err)
+		assert.NoError(t, iterator.Evaluate(metrics.ScrapeInfo{IterationIndex: 2}).Err)
+	})
+
+	t.Run("should auto-correct inconsistent histograms", func(t *testing.T) {
+		results, err := helperScrape(t, metrics.ValidatingHistogramIteratorOptions{AutoCorrect: true},
+			metrics.ScrapeHistogramResult{
+				Buckets: []metrics.HistogramBucketScrape{{LE: 1, Value: 1}, {LE: 0.5, Value: 2}, {LE: math.NaN(), Value: 5}, {LE: math.Inf(1), Value: 2}},
+				Count:   1,
+				Sum:     1,
+			},
+			metrics.ScrapeHistogramResult{
+				Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 1}, {LE: 1, Value: 3}, {LE: 1, Value: 4}},
+				Count:   4,
+				Sum:     2,
+			},
+		)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(results))
+
+		expectedFirst := metrics.ScrapeHistogramResult{
+			Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 2}, {LE: math.Inf(1), Value: 2}},
+			Count:   2,
+			Sum:     1,
+		}
+		assert.Equal(t, expectedFirst, results[0])
+
+		expectedSecond := metrics.ScrapeHistogramResult{
+			Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 4}},
+			Count:   4,
+			Sum:     2,
+		}
+		assert.Equal(t, expectedSecond, results[1])
+	})
+}
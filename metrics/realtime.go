@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TickHandler represents the function called by the RealTimeScraper when the time of a scrape comes.
+type TickHandler func(scrapeInfo ScrapeInfo) error
+
+// RealTimeScraper hands out the scrapes generated by a Scraper as their time comes, i.e., it blocks until the wall
+// clock reaches the time of each scrape. This allows samples to be generated live, e.g., to push them with the remote
+// writer as they are generated.
+// The start time is aligned to the next scrape interval boundary, like prometheus does, so the scrapes happen at round
+// times. If the start time is not set, the scraper starts at the next boundary after it starts running.
+// Scrapes whose time has already gone by, i.e., when the start time is in the past, are handed out right away, which
+// allows the past to be backfilled before going live.
+// The zero value of RealTimeScraper is not useful. Use NewRealTimeScraper to create a new instance.
+type RealTimeScraper struct {
+	cfg  ScraperConfig
+	opts []ScraperOption
+
+	// infiniteGenerator indicates whether the scraper runs forever or not.
+	infiniteGenerator bool
+
+	// now and newTimer tell the time and wait for it to pass. They are only replaced in tests, so the scraper can be
+	// tested without waiting for the wall clock.
+	now      func() time.Time
+	newTimer func(d time.Duration) (c <-chan time.Time, stop func() bool)
+}
+
+// NewRealTimeScraper returns a new instance of RealTimeScraper.
+// It takes the same configuration and options as the Scraper.
+func NewRealTimeScraper(cfg ScraperConfig, opts ...ScraperOption) (*RealTimeScraper, error) {
+	scraper, err := NewScraper(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RealTimeScraper{
+		cfg:               cfg,
+		opts:              opts,
+		infiniteGenerator: scraper.IsInfinite(),
+		now:               time.Now,
+		newTimer:          newTimer,
+	}, nil
+}
+
+// IsInfinite reports whether the scraper will run forever or not.
+func (rs *RealTimeScraper) IsInfinite() bool {
+	return rs.infiniteGenerator
+}
+
+// ScrapeInterval reports the scrape interval for the scraper.
+func (rs *RealTimeScraper) ScrapeInterval() time.Duration {
+	return rs.cfg.ScrapeInterval
+}
+
+// Now returns the current time as told by the clock of the scraper, i.e., the clock the scrapes are timed against.
+func (rs *RealTimeScraper) Now() time.Time {
+	return rs.now()
+}
+
+// Run calls the TickHandler provided with each scrape, as soon as its time comes.
+// This function terminates when there are no more scrapes to be generated, or the TickHandler returns an error, or the
+// context is cancelled, in which case the error of the context is returned.
+func (rs *RealTimeScraper) Run(ctx context.Context, tickHandler TickHandler) error {
+	cfg := rs.cfg
+	if cfg.StartTime.IsZero() {
+		cfg.StartTime = rs.now()
+	}
+	cfg.StartTime = alignToScrapeInterval(cfg.StartTime, cfg.ScrapeInterval)
+
+	scraper, err := NewScraper(cfg, rs.opts...)
+	if err != nil {
+		return err
+	}
+
+	iter := scraper.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		if err := rs.sleepUntil(ctx, scrapeInfo.IterationTime); err != nil {
+			return err
+		}
+
+		if err := tickHandler(scrapeInfo); err != nil {
+			return fmt.Errorf("failed while calling tick handler: %w", err)
+		}
+	}
+
+	// exhausted scraper
+	return nil
+}
+
+// Ticks returns a channel on which the scrapes are sent as soon as their time comes.
+// The channel is closed when there are no more scrapes to be generated or the context is cancelled.
+// The channel is not buffered, so a slow receiver delays the scrapes that follow.
+func (rs *RealTimeScraper) Ticks(ctx context.Context) <-chan ScrapeInfo {
+	ticks := make(chan ScrapeInfo)
+
+	go func() {
+		defer close(ticks)
+
+		// The error can only come from the context being cancelled, which the receiver already knows about.
+		_ = rs.Run(ctx, func(scrapeInfo ScrapeInfo) error {
+			select {
+			case ticks <- scrapeInfo:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return ticks
+}
+
+// alignToScrapeInterval returns the first scrape interval boundary at or after the time provided.
+func alignToScrapeInterval(t time.Time, scrapeInterval time.Duration) time.Time {
+	aligned := t.Truncate(scrapeInterval)
+	if aligned.Before(t) {
+		aligned = aligned.Add(scrapeInterval)
+	}
+
+	return aligned
+}
+
+// sleepUntil blocks until the time provided, or until the context is cancelled, in which case the error of the context
+// is returned.
+func (rs *RealTimeScraper) sleepUntil(ctx context.Context, t time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	wait := t.Sub(rs.now())
+	if wait <= 0 {
+		return nil
+	}
+
+	timerC, stop := rs.newTimer(wait)
+	defer stop()
+
+	select {
+	case <-timerC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newTimer returns the channel and the stop function of a time.Timer that fires after the duration provided.
+func newTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	timer := time.NewTimer(d)
+	return timer.C, timer.Stop
+}
@@ -0,0 +1,9 @@
+package metrics
+
+import "time"
+
+// SetClock replaces the unexported fields 'now' and 'newTimer'.
+func (rs *RealTimeScraper) SetClock(now func() time.Time, newTimer func(d time.Duration) (<-chan time.Time, func() bool)) {
+	rs.now = now
+	rs.newTimer = newTimer
+}
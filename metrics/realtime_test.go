@@ -0,0 +1,183 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+func TestRealTimeScraper(t *testing.T) {
+	t.Run("should fail given an invalid configuration", func(t *testing.T) {
+		_, err := metrics.NewRealTimeScraper(metrics.ScraperConfig{})
+		require.Error(t, err)
+		expectedErrorMessage := "error validating scraper configuration: scrape interval cannot be less than or equal to zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should align the start time and hand out past scrapes right away", func(t *testing.T) {
+		realTimeScraper, err := metrics.NewRealTimeScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 7, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(3),
+		)
+		require.NoError(t, err)
+		assert.False(t, realTimeScraper.IsInfinite())
+
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)}
+		realTimeScraper.SetClock(clock.Now, clock.NewTimer)
+
+		var iterationTimes []time.Time
+		err = realTimeScraper.Run(context.Background(), func(scrapeInfo metrics.ScrapeInfo) error {
+			iterationTimes = append(iterationTimes, scrapeInfo.IterationTime)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2023, 1, 1, 10, 30, 15, 0, time.UTC),
+			time.Date(2023, 1, 1, 10, 30, 30, 0, time.UTC),
+			time.Date(2023, 1, 1, 10, 30, 45, 0, time.UTC),
+		}, iterationTimes)
+		assert.Empty(t, clock.waits)
+	})
+
+	t.Run("should block until the time of each scrape", func(t *testing.T) {
+		realTimeScraper, err := metrics.NewRealTimeScraper(
+			metrics.ScraperConfig{ScrapeInterval: 15 * time.Second},
+			metrics.WithScraperIterationCountLimit(3),
+		)
+		require.NoError(t, err)
+
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 10, 30, 7, 0, time.UTC)}
+		realTimeScraper.SetClock(clock.Now, clock.NewTimer)
+
+		var iterationTimes []time.Time
+		err = realTimeScraper.Run(context.Background(), func(scrapeInfo metrics.ScrapeInfo) error {
+			assert.Equal(t, clock.now, scrapeInfo.IterationTime)
+			assert.Equal(t, clock.now, realTimeScraper.Now())
+			iterationTimes = append(iterationTimes, scrapeInfo.IterationTime)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// The scraper starts at the next scrape interval boundary after the time it starts running.
+		assert.Equal(t, []time.Time{
+			time.Date(2023, 1, 1, 10, 30, 15, 0, time.UTC),
+			time.Date(2023, 1, 1, 10, 30, 30, 0, time.UTC),
+			time.Date(2023, 1, 1, 10, 30, 45, 0, time.UTC),
+		}, iterationTimes)
+		assert.Equal(t, []time.Duration{8 * time.Second, 15 * time.Second, 15 * time.Second}, clock.waits)
+	})
+
+	t.Run("should stop once the context is cancelled", func(t *testing.T) {
+		realTimeScraper, err := metrics.NewRealTimeScraper(metrics.ScraperConfig{ScrapeInterval: 15 * time.Second})
+		require.NoError(t, err)
+		assert.True(t, realTimeScraper.IsInfinite())
+
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)}
+		realTimeScraper.SetClock(clock.Now, clock.NewTimer)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ticks := 0
+		err = realTimeScraper.Run(ctx, func(scrapeInfo metrics.ScrapeInfo) error {
+			ticks++
+			if ticks == 2 {
+				cancel()
+			}
+			return nil
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 2, ticks)
+	})
+
+	t.Run("should stop once the context is cancelled while waiting for a scrape", func(t *testing.T) {
+		realTimeScraper, err := metrics.NewRealTimeScraper(metrics.ScraperConfig{ScrapeInterval: 15 * time.Second})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// The timer never fires, and the context is cancelled while waiting for it.
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 10, 30, 7, 0, time.UTC), blockAndCancel: cancel}
+		realTimeScraper.SetClock(clock.Now, clock.NewTimer)
+
+		err = realTimeScraper.Run(ctx, func(scrapeInfo metrics.ScrapeInfo) error {
+			t.Fatal("the tick handler should not be called")
+			return nil
+		})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.True(t, clock.stopped)
+	})
+
+	t.Run("should send the scrapes on the channel and close it once the scraper is exhausted", func(t *testing.T) {
+		realTimeScraper, err := metrics.NewRealTimeScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(4),
+		)
+		require.NoError(t, err)
+
+		clock := &fakeClock{now: time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)}
+		realTimeScraper.SetClock(clock.Now, clock.NewTimer)
+
+		var indexes []int
+		for scrapeInfo := range realTimeScraper.Ticks(context.Background()) {
+			indexes = append(indexes, scrapeInfo.IterationIndex)
+		}
+
+		assert.Equal(t, []int{0, 1, 2, 3}, indexes)
+		assert.Equal(t, []time.Duration{15 * time.Second, 15 * time.Second, 15 * time.Second}, clock.waits)
+	})
+}
+
+// fakeClock is a clock whose timers fire straight away, moving the time forward by the duration of the timer.
+// It's not safe for concurrent use.
+type fakeClock struct {
+	now time.Time
+
+	// waits records the duration of every timer created.
+	waits []time.Duration
+
+	// blockAndCancel, if set, makes the timers never fire and is called when a timer is created.
+	blockAndCancel func()
+
+	// stopped reports whether the last timer created was stopped.
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.waits = append(c.waits, d)
+	c.stopped = false
+	stop := func() bool {
+		c.stopped = true
+		return false
+	}
+
+	timerC := make(chan time.Time, 1)
+	if c.blockAndCancel != nil {
+		c.blockAndCancel()
+		return timerC, stop
+	}
+
+	c.now = c.now.Add(d)
+	timerC <- c.now
+	return timerC, stop
+}
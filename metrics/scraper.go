@@ -2,13 +2,18 @@ package metrics
 
 import (
 	"fmt"
+	"math/rand"
 	"time"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/internal/random"
 )
 
 // Scraper generates scrapes that are then passed into a DataIterator to generate the time series values.
 // This can be used to generate metrics that can then be pushed to prometheus using the remote writer.
 // It can also be useful for testing purposes.
-// The generated scrapes are precise and do not include any jitter.
+// By default, the generated scrapes are precise and do not include any jitter.
+// Jitter, clock drift and skipped scrapes can be simulated with the WithScraperJitter, WithScraperDrift and
+// WithScraperSkipProbability options. These are random, unless the scraper is seeded with WithScraperSeed.
 // The zero value of Scraper is not useful. Use NewScraper to create a new instance.
 type Scraper struct {
 	cfg ScraperConfig
@@ -30,7 +35,10 @@ func NewScraper(cfg ScraperConfig, opts ...ScraperOption) (*Scraper, error) {
 	}
 
 	if cfg.endTime.IsZero() && cfg.iterationCountLimit == 0 {
-		scraper.infiniteGenerator = true
+		// A schedule only runs forever if its last phase does.
+		if len(cfg.schedule) == 0 || cfg.schedule[len(cfg.schedule)-1].isUnbounded() {
+			scraper.infiniteGenerator = true
+		}
 	}
 
 	return scraper, nil
@@ -45,11 +53,14 @@ func (s *Scraper) IsInfinite() bool {
 // Any number of iterators can be retrieved from a single scraper.
 func (s *Scraper) Iterator() ScraperIterator {
 	return ScraperIterator{
-		scraper: *s,
+		scraper:        *s,
+		rand:           random.NewRand(s.cfg.seed),
+		phaseStartTime: s.cfg.StartTime,
 	}
 }
 
 // ScrapeInterval reports the scrape interval for the scraper.
+// When a schedule is set, the phases might use different scrape intervals.
 func (s *Scraper) ScrapeInterval() time.Duration {
 	return s.cfg.ScrapeInterval
 }
@@ -69,6 +80,11 @@ func (s *Scraper) ScrapeDataIterator(dataIterator DataIterator, scrapeHandler Sc
 			return nil
 		}
 
+		// The data iterator has been evaluated, but the sample is never collected.
+		if scrapeInfo.Skipped {
+			continue
+		}
+
 		err := scrapeHandler(scrapeInfo, scrapeResult)
 		if err != nil {
 			return fmt.Errorf("failed while calling scrape handler: %w", err)
@@ -94,6 +110,11 @@ func (s *Scraper) ScrapeDataHistogramIterator(dataHistogramIterator DataHistogra
 			return nil
 		}
 
+		// The data iterator has been evaluated, but the sample is never collected.
+		if scrapeInfo.Skipped {
+			continue
+		}
+
 		err := scrapeHistogramHandler(scrapeInfo, scrapeResult)
 		if err != nil {
 			return fmt.Errorf("failed while calling scrape handler: %w", err)
@@ -104,38 +125,89 @@ func (s *Scraper) ScrapeDataHistogramIterator(dataHistogramIterator DataHistogra
 	return nil
 }
 
+// ScrapeDataNativeHistogramIterator scrapes the DataNativeHistogramIterator according to the settings of the Scraper.
+// This function can be used as an alternative to creating an iterator and manually iterate over the scrapes.
+// For each generated scrape, this function will call the ScrapeNativeHistogramHandler provided.
+// This function terminates when the DataNativeHistogramIterator has no more data left, or there are no more scrapes to
+// be generated, or the ScrapeNativeHistogramHandler returns an error.
+func (s *Scraper) ScrapeDataNativeHistogramIterator(dataNativeHistogramIterator DataNativeHistogramIterator, scrapeNativeHistogramHandler ScrapeNativeHistogramHandler) error {
+	iter := s.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		scrapeResult := dataNativeHistogramIterator.Evaluate(scrapeInfo)
+		if scrapeResult.Exhausted {
+			// exhausted time series
+			return nil
+		}
+
+		// The data iterator has been evaluated, but the sample is never collected.
+		if scrapeInfo.Skipped {
+			continue
+		}
+
+		err := scrapeNativeHistogramHandler(scrapeInfo, scrapeResult)
+		if err != nil {
+			return fmt.Errorf("failed while calling scrape handler: %w", err)
+		}
+	}
+
+	// exhausted scraper
+	return nil
+}
+
+// ScrapeDataSummaryIterator scrapes the DataSummaryIterator according to the settings of the Scraper.
+// This function can be used as an alternative to creating an iterator and manually iterate over the scrapes.
+// For each generated scrape, this function will call the ScrapeSummaryHandler provided.
+// This function terminates when the DataSummaryIterator has no more data left, or there are no more scrapes to be
+// generated, or the ScrapeSummaryHandler returns an error.
+func (s *Scraper) ScrapeDataSummaryIterator(dataSummaryIterator DataSummaryIterator, scrapeSummaryHandler ScrapeSummaryHandler) error {
+	iter := s.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		scrapeResult := dataSummaryIterator.Evaluate(scrapeInfo)
+		if scrapeResult.Exhausted {
+			// exhausted time series
+			return nil
+		}
+
+		// The data iterator has been evaluated, but the sample is never collected.
+		if scrapeInfo.Skipped {
+			continue
+		}
+
+		err := scrapeSummaryHandler(scrapeInfo, scrapeResult)
+		if err != nil {
+			return fmt.Errorf("failed while calling scrape handler: %w", err)
+		}
+	}
+
+	// exhausted scraper
+	return nil
+}
+
 // ScraperIterator iterates over the scraper.
 type ScraperIterator struct {
 	// scraper is a copy of the scraper this iterator was created from.
 	scraper Scraper
 	// currentIterationIndex keeps track of the current iteration.
 	currentIterationIndex int
-	// lastTimeStamp contains the timestamp of the last scrape. Used for calculations purposes.
-	lastTimeStamp time.Time
+	// firstIterationTime contains the actual time of the first scrape.
+	firstIterationTime time.Time
+	// rand is the source of randomness for the jitter and the skipped scrapes.
+	rand *rand.Rand
+	// pending contains the next scrape, once computed.
+	// The next scrape is computed ahead of time by HasNext, so that Next returns the very same scrape.
+	pending *ScrapeInfo
+
+	// these variables keep track of the current phase of the schedule
+	phaseIndex          int
+	phaseIterationIndex int
+	// phaseStartTime contains the nominal time at which the current phase starts, i.e., before its gap.
+	phaseStartTime time.Time
 }
 
 // HasNext reports whether there are more scrapes to be generated or whether the iterator has been exhausted.
 func (si *ScraperIterator) HasNext() bool {
-	// Check if we are past the iteration count limit
-	if si.scraper.cfg.iterationCountLimit > 0 && si.currentIterationIndex >= si.scraper.cfg.iterationCountLimit {
-		return false
-	}
-
-	var nextScrapeTime time.Time
-
-	// compute next scrape time
-	if si.currentIterationIndex == 0 {
-		nextScrapeTime = si.scraper.cfg.StartTime
-	} else {
-		nextScrapeTime = si.lastTimeStamp.Add(si.scraper.cfg.ScrapeInterval)
-	}
-
-	// Check if we are past the endTime
-	if !si.scraper.cfg.endTime.IsZero() && nextScrapeTime.After(si.scraper.cfg.endTime) {
-		return false
-	}
-
-	return true
+	_, ok := si.peek()
+	return ok
 }
 
 // Next returns the next generated scrape.
@@ -149,40 +221,119 @@ func (si *ScraperIterator) HasNext() bool {
 //		// do stuff with the scrapeInfo
 //	}
 func (si *ScraperIterator) Next() (scrapeInfo ScrapeInfo, ok bool) {
-	// Check if we are past the iteration count limit
-	if si.scraper.cfg.iterationCountLimit > 0 && si.currentIterationIndex >= si.scraper.cfg.iterationCountLimit {
+	scrapeInfo, ok = si.peek()
+	if !ok {
 		return scrapeInfo, false
 	}
 
-	var nextScrapeTime time.Time
-
-	// compute next scrape time
 	if si.currentIterationIndex == 0 {
-		nextScrapeTime = si.scraper.cfg.StartTime
-	} else {
-		nextScrapeTime = si.lastTimeStamp.Add(si.scraper.cfg.ScrapeInterval)
-	}
-
-	// Check if we are past the endTime
-	if !si.scraper.cfg.endTime.IsZero() && nextScrapeTime.After(si.scraper.cfg.endTime) {
-		return scrapeInfo, false
-	}
-
-	scrapeInfo = ScrapeInfo{
-		FirstIterationTime: si.scraper.cfg.StartTime,
-		IterationIndex:     si.currentIterationIndex,
-		IterationTime:      nextScrapeTime,
+		si.firstIterationTime = scrapeInfo.IterationTime
 	}
 
 	si.currentIterationIndex++
-	si.lastTimeStamp = nextScrapeTime
+	si.phaseIterationIndex++
+	si.pending = nil
 
 	return scrapeInfo, true
 }
 
 // Reset resets the iterator, meaning the iterator will start from the beginning.
 // This function allows for the possibility of reusing an iterator after it's been used.
+// A seeded iterator generates the same scrapes again.
 func (si *ScraperIterator) Reset() {
 	si.currentIterationIndex = 0
-	si.lastTimeStamp = time.Time{}
+	si.firstIterationTime = time.Time{}
+	si.rand = random.NewRand(si.scraper.cfg.seed)
+	si.pending = nil
+	si.phaseIndex = 0
+	si.phaseIterationIndex = 0
+	si.phaseStartTime = si.scraper.cfg.StartTime
+}
+
+// peek returns the next scrape, without moving the iterator forward.
+func (si *ScraperIterator) peek() (ScrapeInfo, bool) {
+	cfg := si.scraper.cfg
+
+	// Check if we are past the iteration count limit
+	if cfg.iterationCountLimit > 0 && si.currentIterationIndex >= cfg.iterationCountLimit {
+		return ScrapeInfo{}, false
+	}
+
+	if si.pending == nil {
+		nominalTime, ok := si.nextNominalTime()
+		if !ok {
+			// the schedule is over
+			return ScrapeInfo{}, false
+		}
+
+		scrapeInfo := si.computeScrape(nominalTime)
+		si.pending = &scrapeInfo
+	}
+
+	// Check if we are past the endTime
+	if !cfg.endTime.IsZero() && si.pending.IterationTime.After(cfg.endTime) {
+		return ScrapeInfo{}, false
+	}
+
+	return *si.pending, true
+}
+
+// nextNominalTime returns the time the next scrape is scheduled at, moving on to the next phases of the schedule as
+// needed.
+// It returns false if the schedule is over.
+func (si *ScraperIterator) nextNominalTime() (time.Time, bool) {
+	cfg := si.scraper.cfg
+
+	if len(cfg.schedule) == 0 {
+		return cfg.StartTime.Add(time.Duration(si.currentIterationIndex) * cfg.ScrapeInterval), true
+	}
+
+	for ; si.phaseIndex < len(cfg.schedule); si.phaseIndex++ {
+		phase := cfg.schedule[si.phaseIndex]
+		scrapeInterval := phase.scrapeInterval(cfg.ScrapeInterval)
+		iterationCount := phase.iterationCount(cfg.ScrapeInterval)
+		firstScrapeTime := si.phaseStartTime.Add(phase.Gap)
+
+		if phase.isUnbounded() || si.phaseIterationIndex < iterationCount {
+			return firstScrapeTime.Add(time.Duration(si.phaseIterationIndex) * scrapeInterval), true
+		}
+
+		// The phase is over, the next phase starts one scrape interval after the last scrape.
+		si.phaseStartTime = firstScrapeTime.Add(time.Duration(iterationCount) * scrapeInterval)
+		si.phaseIterationIndex = 0
+	}
+
+	return time.Time{}, false
+}
+
+// computeScrape computes the scrape for the current iteration, given the time the scrape is scheduled at, drawing the
+// jitter and whether the scrape is skipped.
+func (si *ScraperIterator) computeScrape(nominalTime time.Time) ScrapeInfo {
+	cfg := si.scraper.cfg
+	index := si.currentIterationIndex
+
+	offset := time.Duration(index) * cfg.drift
+	if cfg.jitter > 0 {
+		offset += time.Duration(si.rand.Int63n(2*int64(cfg.jitter)+1)) - cfg.jitter
+	}
+
+	skipped := false
+	if cfg.skipProbability > 0 {
+		skipped = si.rand.Float64() < cfg.skipProbability
+	}
+
+	iterationTime := nominalTime.Add(offset)
+
+	firstIterationTime := si.firstIterationTime
+	if index == 0 {
+		firstIterationTime = iterationTime
+	}
+
+	return ScrapeInfo{
+		FirstIterationTime:  firstIterationTime,
+		IterationIndex:      index,
+		IterationTime:       iterationTime,
+		IterationTimeOffset: offset,
+		Skipped:             skipped,
+	}
 }
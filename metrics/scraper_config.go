@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -13,6 +14,7 @@ type ScraperConfig struct {
 	// StartTime defines the initial timestamp the scraper will use.
 	StartTime time.Time
 	// ScrapeInterval represents the scrape interval.
+	// When a schedule is set, it's the scrape interval of the phases that don't set their own.
 	ScrapeInterval time.Duration
 
 	// -------------------------------------------------
@@ -25,6 +27,101 @@ type ScraperConfig struct {
 
 	// iterationCountLimit specifies how many iteration cycles the scraper should go through before stopping.
 	iterationCountLimit int
+
+	// jitter is the maximum random offset applied to each scrape, in either direction.
+	jitter time.Duration
+
+	// drift is the offset accumulated by the scraper clock on every scrape interval.
+	drift time.Duration
+
+	// skipProbability is the probability of each scrape being skipped.
+	skipProbability float64
+
+	// seed seeds the source of randomness used for the jitter and the skipped scrapes.
+	// A seed of zero means the scraper is not seeded.
+	seed int64
+
+	// schedule contains the phases the scraper goes through, one after the other.
+	// If empty, the scraper scrapes at the ScrapeInterval forever.
+	schedule []ScrapePhase
+}
+
+// ScrapePhase represents a phase of the scrape schedule.
+// A phase is bounded either by a duration or by a number of scrapes. Only the last phase of the schedule can be left
+// unbounded, in which case it runs forever.
+type ScrapePhase struct {
+	// ScrapeInterval represents the scrape interval during the phase.
+	// If zero, the ScrapeInterval of the ScraperConfig is used.
+	ScrapeInterval time.Duration
+
+	// Duration sets how long the phase lasts, not including the gap.
+	// The phase ends at the first scrape slot at or past the duration, which is where the next phase starts. This means
+	// the phase scrapes at every interval within [0,Duration[ and two consecutive scrapes are never closer than the
+	// scrape interval.
+	Duration time.Duration
+
+	// IterationCount sets the number of scrapes in the phase.
+	// The phase ends one scrape interval after its last scrape, which is where the next phase starts.
+	IterationCount int
+
+	// Gap represents a period without any scrapes before the phase starts, e.g., the target being down or a prometheus
+	// restart.
+	Gap time.Duration
+}
+
+// validate validates the phase.
+// Only the last phase of the schedule is allowed to run forever.
+func (sp *ScrapePhase) validate(last bool) error {
+	if sp.ScrapeInterval < 0 {
+		return fmt.Errorf("scrape interval cannot be less than zero")
+	}
+
+	if sp.Duration < 0 {
+		return fmt.Errorf("duration cannot be less than zero")
+	}
+
+	if sp.IterationCount < 0 {
+		return fmt.Errorf("iteration count cannot be less than zero")
+	}
+
+	if sp.Gap < 0 {
+		return fmt.Errorf("gap cannot be less than zero")
+	}
+
+	if sp.Duration > 0 && sp.IterationCount > 0 {
+		return fmt.Errorf("duration and iteration count cannot be both set")
+	}
+
+	if !last && sp.isUnbounded() {
+		return fmt.Errorf("only the last phase can run forever, either the duration or the iteration count must be set")
+	}
+
+	return nil
+}
+
+// isUnbounded reports whether the phase runs forever.
+func (sp *ScrapePhase) isUnbounded() bool {
+	return sp.Duration == 0 && sp.IterationCount == 0
+}
+
+// scrapeInterval returns the scrape interval of the phase, given the default scrape interval.
+func (sp *ScrapePhase) scrapeInterval(defaultScrapeInterval time.Duration) time.Duration {
+	if sp.ScrapeInterval == 0 {
+		return defaultScrapeInterval
+	}
+
+	return sp.ScrapeInterval
+}
+
+// iterationCount returns the number of scrapes in the phase, given the default scrape interval.
+// It returns zero if the phase is unbounded.
+func (sp *ScrapePhase) iterationCount(defaultScrapeInterval time.Duration) int {
+	if sp.IterationCount > 0 {
+		return sp.IterationCount
+	}
+
+	scrapeInterval := sp.scrapeInterval(defaultScrapeInterval)
+	return int((sp.Duration + scrapeInterval - 1) / scrapeInterval)
 }
 
 // validate validates the configuration.
@@ -46,9 +143,45 @@ func (sc *ScraperConfig) validate() error {
 		return fmt.Errorf("iteration count limit cannot be less than zero")
 	}
 
+	for i := range sc.schedule {
+		if err := sc.schedule[i].validate(i == len(sc.schedule)-1); err != nil {
+			return fmt.Errorf("invalid schedule phase %d: %w", i, err)
+		}
+	}
+
+	if sc.jitter < 0 {
+		return fmt.Errorf("jitter cannot be less than zero")
+	}
+
+	// The scrapes must remain in order, even when two consecutive scrapes get the largest jitter possible in opposite
+	// directions.
+	if sc.minScrapeInterval()+sc.drift-2*sc.jitter <= 0 {
+		return fmt.Errorf("scrape interval plus drift minus twice the jitter must be greater than zero")
+	}
+
+	if sc.skipProbability < 0 || sc.skipProbability >= 1 || math.IsNaN(sc.skipProbability) {
+		return fmt.Errorf("skip probability must be in the range [0,1[")
+	}
+
 	return nil
 }
 
+// minScrapeInterval returns the shortest scrape interval the scraper goes through.
+func (sc *ScraperConfig) minScrapeInterval() time.Duration {
+	if len(sc.schedule) == 0 {
+		return sc.ScrapeInterval
+	}
+
+	minScrapeInterval := sc.schedule[0].scrapeInterval(sc.ScrapeInterval)
+	for _, phase := range sc.schedule[1:] {
+		if scrapeInterval := phase.scrapeInterval(sc.ScrapeInterval); scrapeInterval < minScrapeInterval {
+			minScrapeInterval = scrapeInterval
+		}
+	}
+
+	return minScrapeInterval
+}
+
 // applyFunctionalOptions applies the set of ScraperOption onto the ScraperConfig.
 func (sc *ScraperConfig) applyFunctionalOptions(opts ...ScraperOption) {
 	for _, opt := range opts {
@@ -77,3 +210,54 @@ func WithScraperIterationCountLimit(n int) ScraperOption {
 		sc.iterationCountLimit = n
 	}
 }
+
+// WithScraperJitter adds a random offset to each scrape, uniformly distributed in the interval [-jitter,jitter].
+// The offset of each scrape is independent of the offsets of the other scrapes, just like the timestamps of a real
+// prometheus server wobble around the scrape interval.
+// Negative numbers are not allowed. By default, there is no jitter.
+func WithScraperJitter(jitter time.Duration) ScraperOption {
+	return func(sc *ScraperConfig) {
+		sc.jitter = jitter
+	}
+}
+
+// WithScraperDrift makes the scraper clock drift by the given duration on every scrape interval.
+// Unlike the jitter, the drift accumulates, i.e., the nth scrape is off by n times the drift. A negative drift makes
+// the scraper clock run fast.
+// By default, there is no drift.
+func WithScraperDrift(drift time.Duration) ScraperOption {
+	return func(sc *ScraperConfig) {
+		sc.drift = drift
+	}
+}
+
+// WithScraperSkipProbability sets the probability of each scrape being skipped, i.e., the sample not being collected.
+// Skipped scrapes are still generated, with the Skipped field of the ScrapeInfo set, which allows the data iterators to
+// keep going, as the system being observed doesn't stop just because a scrape failed.
+// The probability must be in the interval [0,1[. By default, no scrapes are skipped.
+func WithScraperSkipProbability(probability float64) ScraperOption {
+	return func(sc *ScraperConfig) {
+		sc.skipProbability = probability
+	}
+}
+
+// WithScraperSeed seeds the source of randomness used for the jitter and the skipped scrapes, which makes the scrapes
+// reproducible. Every iterator of the scraper generates the same scrapes.
+// By default, the scraper is not seeded.
+func WithScraperSeed(seed int64) ScraperOption {
+	return func(sc *ScraperConfig) {
+		sc.seed = seed
+	}
+}
+
+// WithScraperSchedule makes the scraper go through the phases provided, one after the other, starting at the StartTime.
+// Each phase can have its own scrape interval and can be preceded by a gap without any scrapes, which allows to
+// reproduce a scrape interval change, a target being down or prometheus restarts.
+// The iteration index keeps counting across phases. The scraper stops after the last phase, unless the last phase
+// runs forever. The end time and the iteration count limit still apply.
+// By default, there is no schedule, and the scraper scrapes at the ScrapeInterval.
+func WithScraperSchedule(phases ...ScrapePhase) ScraperOption {
+	return func(sc *ScraperConfig) {
+		sc.schedule = phases
+	}
+}
@@ -81,6 +81,92 @@ func TestScraperConfig(t *testing.T) {
 		assert.Equal(t, expectedErrorMessage, err.Error())
 	})
 
+	t.Run("should fail validation check when provided with a negative jitter", func(t *testing.T) {
+		scraperConfig := metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		}
+
+		scraperConfig.ApplyFunctionalOptions(
+			metrics.WithScraperJitter(-1 * time.Second),
+		)
+
+		err := scraperConfig.Validate()
+		require.Error(t, err)
+		expectedErrorMessage := "jitter cannot be less than zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail validation check when provided with a jitter and drift that would reorder the scrapes", func(t *testing.T) {
+		scraperConfig := metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		}
+
+		scraperConfig.ApplyFunctionalOptions(
+			metrics.WithScraperJitter(5*time.Second),
+			metrics.WithScraperDrift(-5*time.Second),
+		)
+
+		err := scraperConfig.Validate()
+		require.Error(t, err)
+		expectedErrorMessage := "scrape interval plus drift minus twice the jitter must be greater than zero"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail validation check when provided with a skip probability of one", func(t *testing.T) {
+		scraperConfig := metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		}
+
+		scraperConfig.ApplyFunctionalOptions(
+			metrics.WithScraperSkipProbability(1),
+		)
+
+		err := scraperConfig.Validate()
+		require.Error(t, err)
+		expectedErrorMessage := "skip probability must be in the range [0,1["
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail validation check when provided with a schedule phase, other than the last one, running forever", func(t *testing.T) {
+		scraperConfig := metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		}
+
+		scraperConfig.ApplyFunctionalOptions(
+			metrics.WithScraperSchedule(
+				metrics.ScrapePhase{ScrapeInterval: 30 * time.Second},
+				metrics.ScrapePhase{IterationCount: 10},
+			),
+		)
+
+		err := scraperConfig.Validate()
+		require.Error(t, err)
+		expectedErrorMessage := "invalid schedule phase 0: only the last phase can run forever, either the duration or the iteration count must be set"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
+	t.Run("should fail validation check when provided with a schedule phase bounded by both a duration and a count", func(t *testing.T) {
+		scraperConfig := metrics.ScraperConfig{
+			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+			ScrapeInterval: 15 * time.Second,
+		}
+
+		scraperConfig.ApplyFunctionalOptions(
+			metrics.WithScraperSchedule(
+				metrics.ScrapePhase{Duration: time.Minute, IterationCount: 10},
+			),
+		)
+
+		err := scraperConfig.Validate()
+		require.Error(t, err)
+		expectedErrorMessage := "invalid schedule phase 0: duration and iteration count cannot be both set"
+		assert.Equal(t, expectedErrorMessage, err.Error())
+	})
+
 	t.Run("should pass validation check when provided sane values", func(t *testing.T) {
 		scraperConfig := metrics.ScraperConfig{
 			StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
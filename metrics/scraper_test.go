@@ -357,4 +357,209 @@ func TestScraper(t *testing.T) {
 			IterationTime:      time.Date(2023, 1, 1, 10, 30, 45, 0, time.UTC),
 		}, scrapeInfoArr[3])
 	})
+
+	t.Run("should accumulate the drift on every scrape and report the nominal time", func(t *testing.T) {
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      startTime,
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(4),
+			metrics.WithScraperDrift(time.Second),
+		)
+		require.NoError(t, err)
+
+		var scrapeInfoArr []metrics.ScrapeInfo
+		iter := scraper.Iterator()
+		for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+			scrapeInfoArr = append(scrapeInfoArr, scrapeInfo)
+		}
+
+		require.Equal(t, 4, len(scrapeInfoArr))
+		for i, scrapeInfo := range scrapeInfoArr {
+			assert.Equal(t, time.Duration(i)*time.Second, scrapeInfo.IterationTimeOffset)
+			assert.Equal(t, startTime.Add(time.Duration(i)*16*time.Second), scrapeInfo.IterationTime)
+			assert.Equal(t, startTime.Add(time.Duration(i)*15*time.Second), scrapeInfo.NominalIterationTime())
+		}
+	})
+
+	t.Run("should keep the jitter within bounds and reproduce the scrapes given a seed", func(t *testing.T) {
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(100),
+			metrics.WithScraperJitter(2*time.Second),
+			metrics.WithScraperSeed(42),
+		)
+		require.NoError(t, err)
+
+		collect := func(iter metrics.ScraperIterator) []metrics.ScrapeInfo {
+			var scrapeInfoArr []metrics.ScrapeInfo
+			for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+				scrapeInfoArr = append(scrapeInfoArr, scrapeInfo)
+			}
+			return scrapeInfoArr
+		}
+
+		scrapeInfoArr := collect(scraper.Iterator())
+		require.Equal(t, 100, len(scrapeInfoArr))
+
+		jittered := 0
+		for i, scrapeInfo := range scrapeInfoArr {
+			assert.GreaterOrEqual(t, scrapeInfo.IterationTimeOffset, -2*time.Second)
+			assert.LessOrEqual(t, scrapeInfo.IterationTimeOffset, 2*time.Second)
+			assert.Equal(t, scrapeInfoArr[0].IterationTime, scrapeInfo.FirstIterationTime)
+			if i > 0 {
+				assert.True(t, scrapeInfo.IterationTime.After(scrapeInfoArr[i-1].IterationTime))
+			}
+			if scrapeInfo.IterationTimeOffset != 0 {
+				jittered++
+			}
+		}
+		assert.Greater(t, jittered, 90)
+
+		assert.Equal(t, scrapeInfoArr, collect(scraper.Iterator()))
+	})
+
+	t.Run("should skip scrapes while still evaluating the data iterator", func(t *testing.T) {
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(100),
+			metrics.WithScraperSkipProbability(0.5),
+			metrics.WithScraperSeed(7),
+		)
+		require.NoError(t, err)
+
+		evaluations := 0
+		dataIteratorFunc := func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeResult {
+			evaluations++
+			return metrics.ScrapeResult{Value: float64(evaluations)}
+		}
+
+		var scrapeResults []metrics.ScrapeResult
+		scrapeHandler := func(scrapeInfo metrics.ScrapeInfo, scrapeResult metrics.ScrapeResult) error {
+			assert.False(t, scrapeInfo.Skipped)
+			scrapeResults = append(scrapeResults, scrapeResult)
+			return nil
+		}
+
+		err = scraper.ScrapeDataIterator(metrics.DataIteratorFunc(dataIteratorFunc), scrapeHandler)
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, evaluations)
+		assert.Greater(t, len(scrapeResults), 25)
+		assert.Less(t, len(scrapeResults), 75)
+	})
+
+	t.Run("should go through the phases of the schedule, keeping the iteration index continuous", func(t *testing.T) {
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      startTime,
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperSchedule(
+				metrics.ScrapePhase{IterationCount: 3},
+				// The target is down for an hour, and comes back with a longer scrape interval.
+				metrics.ScrapePhase{ScrapeInterval: 30 * time.Second, Duration: time.Minute, Gap: time.Hour},
+				metrics.ScrapePhase{ScrapeInterval: 10 * time.Second, IterationCount: 2},
+			),
+		)
+		require.NoError(t, err)
+		assert.False(t, scraper.IsInfinite())
+
+		var scrapeInfoArr []metrics.ScrapeInfo
+		iter := scraper.Iterator()
+		for iter.HasNext() {
+			scrapeInfo, ok := iter.Next()
+			require.True(t, ok)
+			scrapeInfoArr = append(scrapeInfoArr, scrapeInfo)
+		}
+
+		expectedTimes := []time.Time{
+			startTime,
+			startTime.Add(15 * time.Second),
+			startTime.Add(30 * time.Second),
+			startTime.Add(time.Hour + 45*time.Second),
+			startTime.Add(time.Hour + 75*time.Second),
+			startTime.Add(time.Hour + 105*time.Second),
+			startTime.Add(time.Hour + 115*time.Second),
+		}
+
+		require.Equal(t, len(expectedTimes), len(scrapeInfoArr))
+		for i, scrapeInfo := range scrapeInfoArr {
+			assert.Equal(t, i, scrapeInfo.IterationIndex)
+			assert.Equal(t, expectedTimes[i], scrapeInfo.IterationTime)
+			assert.Equal(t, startTime, scrapeInfo.FirstIterationTime)
+		}
+
+		_, ok := iter.Next()
+		assert.False(t, ok)
+	})
+
+	t.Run("should run forever given the last phase of the schedule is unbounded", func(t *testing.T) {
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      startTime,
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperSchedule(
+				metrics.ScrapePhase{Duration: time.Minute},
+				metrics.ScrapePhase{ScrapeInterval: time.Minute},
+			),
+		)
+		require.NoError(t, err)
+		assert.True(t, scraper.IsInfinite())
+
+		iter := scraper.Iterator()
+		var scrapeInfo metrics.ScrapeInfo
+		for i := 0; i < 1000; i++ {
+			require.True(t, iter.HasNext())
+			scrapeInfo, _ = iter.Next()
+		}
+
+		// 4 scrapes in the first minute, followed by 996 scrapes a minute apart.
+		assert.Equal(t, 999, scrapeInfo.IterationIndex)
+		assert.Equal(t, startTime.Add(time.Minute+995*time.Minute), scrapeInfo.IterationTime)
+	})
+
+	t.Run("should stop at the end time given a schedule", func(t *testing.T) {
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      startTime,
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperSchedule(
+				metrics.ScrapePhase{IterationCount: 2},
+				metrics.ScrapePhase{Gap: time.Hour},
+			),
+			metrics.WithScraperEndTime(startTime.Add(time.Hour+time.Minute)),
+		)
+		require.NoError(t, err)
+		assert.False(t, scraper.IsInfinite())
+
+		count := 0
+		err = scraper.ScrapeDataIterator(metrics.DataIteratorFunc(func(metrics.ScrapeInfo) metrics.ScrapeResult {
+			return metrics.ScrapeResult{}
+		}), func(metrics.ScrapeInfo, metrics.ScrapeResult) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+
+		// 2 scrapes before the gap, followed by the scrapes at 1h0m30s, 1h0m45s and 1h1m0s.
+		assert.Equal(t, 5, count)
+	})
 }
@@ -0,0 +1,155 @@
+package metrics
+
+// ScrapeOutcome is the set of results returned by the data iterators of the different metric types.
+type ScrapeOutcome interface {
+	ScrapeResult | ScrapeHistogramResult | ScrapeNativeHistogramResult | ScrapeSummaryResult
+
+	// exhausted reports whether there is no more data to be returned by the iterator.
+	exhausted() bool
+
+	// remembered reports whether the result is remembered as the last value, to be sent by the send last value end
+	// strategy.
+	remembered() bool
+}
+
+// Counters and gauges repeat the last result as is, even if it's missing or stale.
+func (r ScrapeResult) exhausted() bool  { return r.Exhausted }
+func (r ScrapeResult) remembered() bool { return true }
+
+// Histograms and summaries repeat the last valid sample, as a missing or invalid sample carries no data to repeat.
+func (r ScrapeHistogramResult) exhausted() bool  { return r.Exhausted }
+func (r ScrapeHistogramResult) remembered() bool { return !r.Missing && r.Err == nil }
+
+func (r ScrapeNativeHistogramResult) exhausted() bool  { return r.Exhausted }
+func (r ScrapeNativeHistogramResult) remembered() bool { return !r.Missing }
+
+func (r ScrapeSummaryResult) exhausted() bool  { return r.Exhausted }
+func (r ScrapeSummaryResult) remembered() bool { return !r.Missing }
+
+// Evaluator is implemented by the data iterators of every metric type, i.e., DataIterator, DataHistogramIterator,
+// DataNativeHistogramIterator and DataSummaryIterator.
+type Evaluator[R ScrapeOutcome] interface {
+	Evaluate(scrapeInfo ScrapeInfo) R
+}
+
+// Check at compile time whether TimeSeriesIterator implements DataIterator interface.
+var _ DataIterator = (*TimeSeriesIterator[ScrapeResult])(nil)
+
+// TimeSeriesIterator iterates over the data of a metric time series and evaluates the EndStrategy once the data is
+// exhausted. It holds the end strategy logic shared by the time series of every metric type.
+//   - Loop starts a new iterator, which for histograms and summaries resets them, just like an application restart
+//     would. If the new iterator is exhausted straight away, the time series is removed instead of looping forever.
+//   - Send last value sends the last result returned by the iterator. Counters and gauges repeat the last result as
+//     is, whereas histograms and summaries repeat the last sample that was neither missing nor invalid. If there was
+//     no such result, the zero value is sent.
+//   - Send custom value sends the custom value. Since the custom value is not a histogram nor a summary, those only
+//     honour the Missing and Exhausted fields of the custom value, otherwise they send the last value.
+//   - Remove time series, or an end strategy that hasn't been set, marks the results as exhausted.
+//
+// The zero value of TimeSeriesIterator is not useful. Use NewTimeSeriesIterator function.
+type TimeSeriesIterator[R ScrapeOutcome] struct {
+	newIterator func() Evaluator[R]
+	endStrategy EndStrategy
+
+	// currentIterator contains the iterator for the current run (in case we use a loop over strategy)
+	currentIterator Evaluator[R]
+
+	// currentIteratorEvaluated reports whether the current iterator has returned any result before being exhausted.
+	currentIteratorEvaluated bool
+
+	// Reports whether we are evaluating data or we are in the end strategy stage
+	state TimeSeriesIteratorState
+
+	// lastValue represents the last result returned by the iterator, see ScrapeOutcome.remembered
+	lastValue R
+}
+
+// NewTimeSeriesIterator creates a new instance of TimeSeriesIterator.
+// The newIterator function is called to get the iterator for every run over the data.
+func NewTimeSeriesIterator[R ScrapeOutcome](newIterator func() Evaluator[R], endStrategy EndStrategy) *TimeSeriesIterator[R] {
+	return &TimeSeriesIterator[R]{
+		newIterator: newIterator,
+		endStrategy: endStrategy,
+		state:       TimeSeriesIteratorStateRunning,
+	}
+}
+
+// Evaluate returns the data points one at a time.
+func (it *TimeSeriesIterator[R]) Evaluate(scrapeInfo ScrapeInfo) R {
+	// Need the loop as when we reach the end of the iterator, regardless of what the end strategy is, we need to
+	// evaluate the logic again, after setting the iterator state.
+	for {
+		if it.state == TimeSeriesIteratorStateEndStrategy {
+			return it.evaluateEndStrategy()
+		}
+
+		// if we don't have an iterator, get one
+		if it.currentIterator == nil {
+			it.currentIterator = it.newIterator()
+			it.currentIteratorEvaluated = false
+		}
+
+		result := it.currentIterator.Evaluate(scrapeInfo)
+
+		// We reached the end of the iterator
+		if result.exhausted() {
+			if it.endStrategy.EndStrategyType == EndStrategyTypeLoop && it.currentIteratorEvaluated {
+				it.currentIterator = nil
+				continue
+			}
+
+			it.state = TimeSeriesIteratorStateEndStrategy
+			continue
+		}
+
+		it.currentIteratorEvaluated = true
+
+		if result.remembered() {
+			it.lastValue = result
+		}
+		return result
+	}
+}
+
+func (it *TimeSeriesIterator[R]) evaluateEndStrategy() R {
+	switch it.endStrategy.EndStrategyType {
+	case EndStrategyTypeSendLastValue:
+		return it.lastValue
+	case EndStrategyTypeSendCustomValue:
+		customValue := it.endStrategy.CustomValue()
+		if result, ok := any(customValue).(R); ok {
+			return result
+		}
+
+		switch {
+		case customValue.Exhausted:
+			return newScrapeOutcome[R](false, true)
+		case customValue.Missing:
+			return newScrapeOutcome[R](true, false)
+		default:
+			return it.lastValue
+		}
+	default:
+		// The loop end strategy only gets here when the data is empty. If the end strategy hasn't been set somehow,
+		// default to removing time series.
+		return newScrapeOutcome[R](false, true)
+	}
+}
+
+// newScrapeOutcome returns a result of type R, which holds no sample, with the Missing and Exhausted fields set.
+func newScrapeOutcome[R ScrapeOutcome](missing bool, exhausted bool) R {
+	var result R
+
+	switch resultConcrete := any(&result).(type) {
+	case *ScrapeResult:
+		*resultConcrete = ScrapeResult{Missing: missing, Exhausted: exhausted}
+	case *ScrapeHistogramResult:
+		*resultConcrete = ScrapeHistogramResult{Missing: missing, Exhausted: exhausted}
+	case *ScrapeNativeHistogramResult:
+		*resultConcrete = ScrapeNativeHistogramResult{Missing: missing, Exhausted: exhausted}
+	case *ScrapeSummaryResult:
+		*resultConcrete = ScrapeSummaryResult{Missing: missing, Exhausted: exhausted}
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package metrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// newSliceIterator returns a function that creates iterators going through the results provided.
+func newSliceIterator[R metrics.ScrapeOutcome](results []R, exhausted R) func() metrics.Evaluator[R] {
+	return func() metrics.Evaluator[R] {
+		index := 0
+		return evaluatorFunc[R](func(scrapeInfo metrics.ScrapeInfo) R {
+			if index >= len(results) {
+				return exhausted
+			}
+			index++
+			return results[index-1]
+		})
+	}
+}
+
+type evaluatorFunc[R metrics.ScrapeOutcome] func(scrapeInfo metrics.ScrapeInfo) R
+
+func (f evaluatorFunc[R]) Evaluate(scrapeInfo metrics.ScrapeInfo) R {
+	return f(scrapeInfo)
+}
+
+func evaluateN[R metrics.ScrapeOutcome](iterator metrics.Evaluator[R], count int) []R {
+	var results []R
+	for i := 0; i < count; i++ {
+		results = append(results, iterator.Evaluate(metrics.ScrapeInfo{IterationIndex: i}))
+	}
+	return results
+}
+
+func TestTimeSeriesIterator(t *testing.T) {
+	t.Run("should loop back to the beginning of the data", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{{Value: 1}, {Value: 2}}, metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategyLoop(),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeResult{{Value: 1}, {Value: 2}, {Value: 1}, {Value: 2}, {Value: 1}},
+			evaluateN[metrics.ScrapeResult](iterator, 5))
+	})
+
+	t.Run("should remove the time series when looping over empty data", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{}, metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategyLoop(),
+		)
+
+		assert.Equal(t, metrics.ScrapeResult{Exhausted: true}, iterator.Evaluate(metrics.ScrapeInfo{}))
+	})
+
+	t.Run("should send the last result as is, even if it is missing", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{{Value: 1}, {Value: 2}, {Missing: true}},
+				metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategySendLastValue(),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeResult{{Value: 1}, {Value: 2}, {Missing: true}, {Missing: true}, {Missing: true}},
+			evaluateN[metrics.ScrapeResult](iterator, 5))
+	})
+
+	t.Run("should send the zero value as the last value when there was no result", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{}, metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategySendLastValue(),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeResult{{}, {}},
+			evaluateN[metrics.ScrapeResult](iterator, 2))
+	})
+
+	t.Run("should send the custom value", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{{Value: 1}}, metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategySendCustomValue(metrics.ScrapeResult{Value: 7}),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeResult{{Value: 1}, {Value: 7}, {Value: 7}},
+			evaluateN[metrics.ScrapeResult](iterator, 3))
+	})
+
+	t.Run("should remove the time series", func(t *testing.T) {
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeResult{{Value: 1}}, metrics.ScrapeResult{Exhausted: true}),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeResult{{Value: 1}, {Exhausted: true}, {Exhausted: true}},
+			evaluateN[metrics.ScrapeResult](iterator, 3))
+	})
+
+	t.Run("should only honour the missing and exhausted fields of the custom value for histograms", func(t *testing.T) {
+		histogram := metrics.ScrapeHistogramResult{Count: 3, Sum: 1.5}
+		newIterator := newSliceIterator([]metrics.ScrapeHistogramResult{histogram}, metrics.ScrapeHistogramResult{Exhausted: true})
+
+		testCases := map[string]struct {
+			customValue    metrics.ScrapeResult
+			expectedResult metrics.ScrapeHistogramResult
+		}{
+			"value":     {customValue: metrics.ScrapeResult{Value: 7}, expectedResult: histogram},
+			"missing":   {customValue: metrics.ScrapeResult{Missing: true}, expectedResult: metrics.ScrapeHistogramResult{Missing: true}},
+			"exhausted": {customValue: metrics.ScrapeResult{Exhausted: true}, expectedResult: metrics.ScrapeHistogramResult{Exhausted: true}},
+		}
+
+		for name, testCase := range testCases {
+			iterator := metrics.NewTimeSeriesIterator(newIterator, metrics.NewEndStrategySendCustomValue(testCase.customValue))
+
+			results := evaluateN[metrics.ScrapeHistogramResult](iterator, 2)
+			assert.Equal(t, histogram, results[0], name)
+			assert.Equal(t, testCase.expectedResult, results[1], name)
+		}
+	})
+
+	t.Run("should not remember invalid histograms as the last value", func(t *testing.T) {
+		histogram := metrics.ScrapeHistogramResult{Count: 3, Sum: 1.5}
+		invalidHistogram := metrics.ScrapeHistogramResult{Count: 1, Err: assert.AnError}
+
+		iterator := metrics.NewTimeSeriesIterator(
+			newSliceIterator([]metrics.ScrapeHistogramResult{histogram, invalidHistogram},
+				metrics.ScrapeHistogramResult{Exhausted: true}),
+			metrics.NewEndStrategySendLastValue(),
+		)
+
+		assert.Equal(t,
+			[]metrics.ScrapeHistogramResult{histogram, invalidHistogram, histogram},
+			evaluateN[metrics.ScrapeHistogramResult](iterator, 3))
+	})
+}
@@ -71,6 +71,51 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 		metricResults := metricObservable.Evaluate(scrapeInfo)
 
 		for _, metricResult := range metricResults {
+			// There are no stale markers in the exposition format, the time series simply stops being exposed.
+			if metricResult.StaleMarker {
+				continue
+			}
+
+			// Create array of label values in the same order the label names were specified!
+			var labelValues []string
+
+			for _, labelName := range metricObservable.Desc().LabelsNames {
+				labelValues = append(labelValues, metricResult.LabelsSet[labelName])
+			}
+
+			if metricResult.Desc.MetricType == MetricTypeNativeHistogram {
+				metric, err := newConstNativeHistogram(metricResult, labelValues)
+				if err != nil {
+					// The error is reported when the metric is gathered.
+					metric = prometheus.NewInvalidMetric(metricResult.PromDesc, err)
+				}
+
+				ch <- metric
+				continue
+			}
+
+			if metricResult.Desc.MetricType == MetricTypeSummary {
+				metric, err := newConstSummary(metricResult, labelValues)
+				if err != nil {
+					// The error is reported when the metric is gathered.
+					metric = prometheus.NewInvalidMetric(metricResult.PromDesc, err)
+				}
+
+				ch <- metric
+				continue
+			}
+
+			if metricResult.Desc.MetricType == MetricTypeHistogram {
+				metric, err := newConstHistogram(metricResult, labelValues)
+				if err != nil {
+					// The error is reported when the metric is gathered.
+					metric = prometheus.NewInvalidMetric(metricResult.PromDesc, err)
+				}
+
+				ch <- metric
+				continue
+			}
+
 			var metricType prometheus.ValueType
 			switch metricResult.Desc.MetricType {
 			case MetricTypeCounter:
@@ -82,13 +127,6 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 				continue
 			}
 
-			// Create array of label values in the same order the label names were specified!
-			var labelValues []string
-
-			for _, labelName := range metricObservable.Desc().LabelsNames {
-				labelValues = append(labelValues, metricResult.LabelsSet[labelName])
-			}
-
 			metric, err := prometheus.NewConstMetric(
 				metricResult.PromDesc,
 				metricType,
@@ -104,3 +142,40 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 }
+
+// newConstHistogram creates a prometheus.Metric from the histogram result.
+// An inconsistent histogram is reported with its error.
+func newConstHistogram(metricResult MetricResult, labelValues []string) (prometheus.Metric, error) {
+	if metricResult.Histogram.Err != nil {
+		return nil, metricResult.Histogram.Err
+	}
+
+	buckets := make(map[float64]uint64, len(metricResult.Histogram.Buckets))
+	for _, bucket := range metricResult.Histogram.Buckets {
+		buckets[bucket.LE] = uint64(bucket.Value)
+	}
+
+	return prometheus.NewConstHistogram(
+		metricResult.PromDesc,
+		uint64(metricResult.Histogram.Count),
+		metricResult.Histogram.Sum,
+		buckets,
+		labelValues...,
+	)
+}
+
+// newConstSummary creates a prometheus.Metric from the summary result.
+func newConstSummary(metricResult MetricResult, labelValues []string) (prometheus.Metric, error) {
+	quantiles := make(map[float64]float64, len(metricResult.Summary.Quantiles))
+	for _, quantile := range metricResult.Summary.Quantiles {
+		quantiles[quantile.Quantile] = quantile.Value
+	}
+
+	return prometheus.NewConstSummary(
+		metricResult.PromDesc,
+		uint64(metricResult.Summary.Count),
+		metricResult.Summary.Sum,
+		quantiles,
+		labelValues...,
+	)
+}
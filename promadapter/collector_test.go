@@ -0,0 +1,260 @@
+package promadapter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("should expose histograms", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeHistogram, []string{"code"})
+
+		err := metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"code": "200"},
+			helperHistogramGenerator(5),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		expected := `
+# HELP request_duration_seconds Duration of the requests
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{code="200",le="0.5"} 1
+request_duration_seconds_bucket{code="200",le="1"} 2
+request_duration_seconds_bucket{code="200",le="+Inf"} 3
+request_duration_seconds_sum{code="200"} 3
+request_duration_seconds_count{code="200"} 3
+`
+
+		err = testutil.GatherAndCompare(reg, strings.NewReader(expected), "request_duration_seconds")
+		require.NoError(t, err)
+	})
+
+	t.Run("should report an error given a histogram that cannot be exposed", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeHistogram, []string{"code"})
+
+		err := metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"code": "\xff"},
+			helperHistogramGenerator(5),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		_, err = reg.Gather()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not valid UTF-8")
+	})
+
+	t.Run("should report an error given an inconsistent histogram", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeHistogram, []string{"code"})
+
+		err := metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"code": "200"},
+			inconsistentDataHistogramGenerator{},
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		_, err = reg.Gather()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inconsistent histogram: buckets are not cumulative")
+	})
+
+	t.Run("should expose native histograms", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeNativeHistogram, []string{"code"})
+
+		nativeHistogram := metrics.ScrapeNativeHistogramResult{
+			Schema:         3,
+			ZeroThreshold:  0.001,
+			ZeroCount:      1,
+			Count:          6,
+			Sum:            2.5,
+			PositiveSpans:  []metrics.NativeHistogramBucketSpan{{Offset: -2, Length: 2}},
+			PositiveDeltas: []int64{2, 1},
+		}
+
+		err := metric.AddNativeHistogramTimeSeries(discrete.NewNativeHistogramMetricTimeSeries(
+			map[string]string{"code": "200"},
+			staticDataNativeHistogramGenerator{result: nativeHistogram},
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		metricFamilies, err := reg.Gather()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(metricFamilies))
+		assert.Equal(t, dto.MetricType_HISTOGRAM, metricFamilies[0].GetType())
+
+		require.Equal(t, 1, len(metricFamilies[0].GetMetric()))
+		histogram := metricFamilies[0].GetMetric()[0].GetHistogram()
+		assert.Equal(t, int32(3), histogram.GetSchema())
+		assert.Equal(t, 0.001, histogram.GetZeroThreshold())
+		assert.Equal(t, uint64(1), histogram.GetZeroCount())
+		assert.Equal(t, uint64(6), histogram.GetSampleCount())
+		assert.Equal(t, 2.5, histogram.GetSampleSum())
+		require.Equal(t, 1, len(histogram.GetPositiveSpan()))
+		assert.Equal(t, int32(-2), histogram.GetPositiveSpan()[0].GetOffset())
+		assert.Equal(t, uint32(2), histogram.GetPositiveSpan()[0].GetLength())
+		assert.Equal(t, []int64{2, 1}, histogram.GetPositiveDelta())
+	})
+
+	t.Run("should report an error given a native histogram that cannot be exposed", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeNativeHistogram, []string{"code"})
+
+		nativeHistogram := metrics.ScrapeNativeHistogramResult{
+			Schema:         3,
+			Count:          3,
+			Sum:            2.5,
+			PositiveSpans:  []metrics.NativeHistogramBucketSpan{{Offset: -2, Length: 3}},
+			PositiveDeltas: []int64{2, 1},
+		}
+
+		err := metric.AddNativeHistogramTimeSeries(discrete.NewNativeHistogramMetricTimeSeries(
+			map[string]string{"code": "200"},
+			staticDataNativeHistogramGenerator{result: nativeHistogram},
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		_, err = reg.Gather()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid positive buckets: spans cover 3 buckets, but there are 2 deltas")
+	})
+
+	t.Run("should expose summaries", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeSummary, []string{"code"})
+
+		err := metric.AddSummaryTimeSeries(discrete.NewSummaryMetricTimeSeries(
+			map[string]string{"code": "200"},
+			helperSummaryGenerator(5),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		expected := `
+# HELP request_duration_seconds Duration of the requests
+# TYPE request_duration_seconds summary
+request_duration_seconds{code="200",quantile="0.5"} 1
+request_duration_seconds{code="200",quantile="0.9"} 2
+request_duration_seconds_sum{code="200"} 4.5
+request_duration_seconds_count{code="200"} 3
+`
+
+		err = testutil.GatherAndCompare(reg, strings.NewReader(expected), "request_duration_seconds")
+		require.NoError(t, err)
+	})
+
+	t.Run("should report an error given a summary that cannot be exposed", func(t *testing.T) {
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeSummary, []string{"code"})
+
+		err := metric.AddSummaryTimeSeries(discrete.NewSummaryMetricTimeSeries(
+			map[string]string{"code": "\xff"},
+			helperSummaryGenerator(5),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		_, err = reg.Gather()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not valid UTF-8")
+	})
+
+	t.Run("should not expose stale samples of gauges", func(t *testing.T) {
+		metric := promadapter.NewMetric("temperature_celsius", "Temperature in celsius", promadapter.MetricTypeGauge, []string{"room"})
+
+		err := metric.AddTimeSeries(discrete.NewMetricTimeSeries(
+			map[string]string{"room": "kitchen"},
+			discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 21}, {Stale: true}, {Value: 23}}),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+
+		reg := prometheus.NewPedanticRegistry()
+		err = reg.Register(promadapter.NewCollector([]promadapter.MetricObservable{metric}))
+		require.NoError(t, err)
+
+		expected := `
+# HELP temperature_celsius Temperature in celsius
+# TYPE temperature_celsius gauge
+temperature_celsius{room="kitchen"} 21
+`
+		err = testutil.GatherAndCompare(reg, strings.NewReader(expected), "temperature_celsius")
+		require.NoError(t, err)
+
+		err = testutil.GatherAndCompare(reg, strings.NewReader(""), "temperature_celsius")
+		require.NoError(t, err)
+
+		expected = `
+# HELP temperature_celsius Temperature in celsius
+# TYPE temperature_celsius gauge
+temperature_celsius{room="kitchen"} 23
+`
+		err = testutil.GatherAndCompare(reg, strings.NewReader(expected), "temperature_celsius")
+		require.NoError(t, err)
+	})
+}
+
+// inconsistentDataHistogramGenerator returns histograms whose buckets are not cumulative, validated by the
+// metrics.ValidatingHistogramIterator.
+type inconsistentDataHistogramGenerator struct{}
+
+func (dg inconsistentDataHistogramGenerator) Iterator() metrics.DataHistogramIterator {
+	return metrics.NewValidatingHistogramIterator(
+		metrics.DataHistogramIteratorFunc(func(_ metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+			return metrics.ScrapeHistogramResult{
+				Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 1}},
+				Count:   3,
+				Sum:     3,
+			}
+		}),
+		metrics.ValidatingHistogramIteratorOptions{},
+	)
+}
+
+type staticDataNativeHistogramGenerator struct {
+	result metrics.ScrapeNativeHistogramResult
+}
+
+func (dg staticDataNativeHistogramGenerator) Iterator() metrics.DataNativeHistogramIterator {
+	return metrics.DataNativeHistogramIteratorFunc(func(_ metrics.ScrapeInfo) metrics.ScrapeNativeHistogramResult {
+		return dg.result
+	})
+}
@@ -10,7 +10,6 @@ import (
 )
 
 // MetricObservable defines the interface metrics should implement.
-// This only applies to Counter and Gauge metrics.
 type MetricObservable interface {
 	Desc() Desc
 	PromDesc() *prometheus.Desc
@@ -27,6 +26,31 @@ type MetricTimeSeriesObservable interface {
 	IsInfinite() bool
 }
 
+// HistogramMetricTimeSeriesObservable is the interface implemented by any histogram time series wanting to be scraped.
+// This is only valid for Histogram metrics.
+type HistogramMetricTimeSeriesObservable interface {
+	Iterator() metrics.DataHistogramIterator
+	Labels() map[string]string
+	IsInfinite() bool
+}
+
+// NativeHistogramMetricTimeSeriesObservable is the interface implemented by any native histogram time series wanting to
+// be scraped.
+// This is only valid for Native Histogram metrics.
+type NativeHistogramMetricTimeSeriesObservable interface {
+	Iterator() metrics.DataNativeHistogramIterator
+	Labels() map[string]string
+	IsInfinite() bool
+}
+
+// SummaryMetricTimeSeriesObservable is the interface implemented by any summary time series wanting to be scraped.
+// This is only valid for Summary metrics.
+type SummaryMetricTimeSeriesObservable interface {
+	Iterator() metrics.DataSummaryIterator
+	Labels() map[string]string
+	IsInfinite() bool
+}
+
 // Desc represents the description of the metric.
 type Desc struct {
 	// MetricFamily represents the name of the metric (also known as Metric Family).
@@ -35,7 +59,7 @@ type Desc struct {
 	// Help represent the Help string of the metric.
 	Help string
 
-	// MetricType represents the type of the metric (counter or gauge).
+	// MetricType represents the type of the metric (counter, gauge, histogram, native histogram or summary).
 	MetricType MetricType
 
 	// LabelsNames contains the names of the labels to be use by the time series attached to this metric
@@ -48,13 +72,25 @@ const (
 	MetricTypeCounter   MetricType = "time_series_type-counter"
 	MetricTypeGauge     MetricType = "time_series_type-gauge"
 	MetricTypeHistogram MetricType = "time_series_type-histogram"
+
+	// MetricTypeNativeHistogram represents a native (also known as sparse or exponential) histogram.
+	// Native histograms are exposed as histograms, but they can only be scraped using the protobuf exposition format.
+	MetricTypeNativeHistogram MetricType = "time_series_type-native_histogram"
+
+	// MetricTypeSummary represents a summary, exposed with a sample for each quantile, along with the count and sum.
+	MetricTypeSummary MetricType = "time_series_type-summary"
 )
 
+// summaryQuantileLabel is the label used by summaries to expose the quantiles.
+const summaryQuantileLabel = "quantile"
+
 // Check at compile time whether Metric implements MetricObservable interface.
 var _ MetricObservable = (*Metric)(nil)
 
 // Metric represents a metric.
-// It's only meant to be used by metrics that are Counters or Gauges.
+// Counters and Gauges hold time series added with AddTimeSeries, Histograms hold time series added with
+// AddHistogramTimeSeries, Native Histograms hold time series added with AddNativeHistogramTimeSeries and Summaries hold
+// time series added with AddSummaryTimeSeries.
 // The zero value is not useful. Use the NewMetric function instead.
 type Metric struct {
 	// desc represents the descriptor that describes this metric.
@@ -69,7 +105,34 @@ type Metric struct {
 	// timeSeriesIterators contains the iterators for all time series.
 	timeSeriesIterators []metrics.DataIterator
 
+	// histogramTimeSeries contains all the histogram time series attached to this metric.
+	histogramTimeSeries []HistogramMetricTimeSeriesObservable
+
+	// histogramTimeSeriesIterators contains the iterators for all histogram time series.
+	histogramTimeSeriesIterators []metrics.DataHistogramIterator
+
+	// histogramTimeSeriesLastBuckets contains the buckets last returned by each histogram time series, so the stale
+	// markers can be sent for every bucket.
+	histogramTimeSeriesLastBuckets [][]metrics.HistogramBucketScrape
+
+	// nativeHistogramTimeSeries contains all the native histogram time series attached to this metric.
+	nativeHistogramTimeSeries []NativeHistogramMetricTimeSeriesObservable
+
+	// nativeHistogramTimeSeriesIterators contains the iterators for all native histogram time series.
+	nativeHistogramTimeSeriesIterators []metrics.DataNativeHistogramIterator
+
+	// summaryTimeSeries contains all the summary time series attached to this metric.
+	summaryTimeSeries []SummaryMetricTimeSeriesObservable
+
+	// summaryTimeSeriesIterators contains the iterators for all summary time series.
+	summaryTimeSeriesIterators []metrics.DataSummaryIterator
+
+	// summaryTimeSeriesLastQuantiles contains the quantiles last returned by each summary time series, so the stale
+	// markers can be sent for every quantile.
+	summaryTimeSeriesLastQuantiles [][]metrics.SummaryQuantileScrape
+
 	// timeSeriesStaleMarkers contains the state for stale markers for all time series.
+	// A metric only holds one kind of time series, so this field is shared by all of them.
 	timeSeriesStaleMarkers []bool
 
 	// timeSeriesCount is the number of time series contained in this metric.
@@ -77,7 +140,6 @@ type Metric struct {
 }
 
 // NewMetric creates a new instance of Metric.
-// It's only meant to be used by metrics that are Counters or Gauges.
 func NewMetric(metricFamily string, help string, metricType MetricType, labelsNames []string) *Metric {
 	desc := Desc{
 		MetricFamily: metricFamily,
@@ -95,15 +157,98 @@ func NewMetric(metricFamily string, help string, metricType MetricType, labelsNa
 
 // AddTimeSeries adds a time series (counter or gauge) to the metric.
 func (m *Metric) AddTimeSeries(metricTimeSeries MetricTimeSeriesObservable) error {
-	// validate labels match
+	switch m.desc.MetricType {
+	case MetricTypeHistogram:
+		return fmt.Errorf("metric type mismatch: histogram metrics only accept histogram time series")
+	case MetricTypeNativeHistogram:
+		return fmt.Errorf("metric type mismatch: native histogram metrics only accept native histogram time series")
+	case MetricTypeSummary:
+		return fmt.Errorf("metric type mismatch: summary metrics only accept summary time series")
+	}
+
+	if err := m.validateLabels(metricTimeSeries.Labels()); err != nil {
+		return err
+	}
+
+	m.timeSeries = append(m.timeSeries, metricTimeSeries)
+	m.timeSeriesIterators = append(m.timeSeriesIterators, metricTimeSeries.Iterator())
+	m.timeSeriesStaleMarkers = append(m.timeSeriesStaleMarkers, false)
+	m.timeSeriesCount++
+
+	return nil
+}
+
+// AddHistogramTimeSeries adds a histogram time series to the metric.
+func (m *Metric) AddHistogramTimeSeries(histogramMetricTimeSeries HistogramMetricTimeSeriesObservable) error {
+	if m.desc.MetricType != MetricTypeHistogram {
+		return fmt.Errorf("metric type mismatch: only histogram metrics accept histogram time series")
+	}
+
+	if err := m.validateLabels(histogramMetricTimeSeries.Labels()); err != nil {
+		return err
+	}
+
+	m.histogramTimeSeries = append(m.histogramTimeSeries, histogramMetricTimeSeries)
+	m.histogramTimeSeriesIterators = append(m.histogramTimeSeriesIterators, histogramMetricTimeSeries.Iterator())
+	m.histogramTimeSeriesLastBuckets = append(m.histogramTimeSeriesLastBuckets, nil)
+	m.timeSeriesStaleMarkers = append(m.timeSeriesStaleMarkers, false)
+	m.timeSeriesCount++
+
+	return nil
+}
+
+// AddNativeHistogramTimeSeries adds a native histogram time series to the metric.
+func (m *Metric) AddNativeHistogramTimeSeries(nativeHistogramMetricTimeSeries NativeHistogramMetricTimeSeriesObservable) error {
+	if m.desc.MetricType != MetricTypeNativeHistogram {
+		return fmt.Errorf("metric type mismatch: only native histogram metrics accept native histogram time series")
+	}
+
+	if err := m.validateLabels(nativeHistogramMetricTimeSeries.Labels()); err != nil {
+		return err
+	}
+
+	m.nativeHistogramTimeSeries = append(m.nativeHistogramTimeSeries, nativeHistogramMetricTimeSeries)
+	m.nativeHistogramTimeSeriesIterators = append(m.nativeHistogramTimeSeriesIterators, nativeHistogramMetricTimeSeries.Iterator())
+	m.timeSeriesStaleMarkers = append(m.timeSeriesStaleMarkers, false)
+	m.timeSeriesCount++
+
+	return nil
+}
+
+// AddSummaryTimeSeries adds a summary time series to the metric.
+// The quantile label is reserved for the quantiles, so it cannot be one of the label names of the metric.
+func (m *Metric) AddSummaryTimeSeries(summaryMetricTimeSeries SummaryMetricTimeSeriesObservable) error {
+	if m.desc.MetricType != MetricTypeSummary {
+		return fmt.Errorf("metric type mismatch: only summary metrics accept summary time series")
+	}
+
+	for _, labelName := range m.desc.LabelsNames {
+		if labelName == summaryQuantileLabel {
+			return fmt.Errorf("label mismatch: %q label is reserved for the quantiles", summaryQuantileLabel)
+		}
+	}
+
+	if err := m.validateLabels(summaryMetricTimeSeries.Labels()); err != nil {
+		return err
+	}
+
+	m.summaryTimeSeries = append(m.summaryTimeSeries, summaryMetricTimeSeries)
+	m.summaryTimeSeriesIterators = append(m.summaryTimeSeriesIterators, summaryMetricTimeSeries.Iterator())
+	m.summaryTimeSeriesLastQuantiles = append(m.summaryTimeSeriesLastQuantiles, nil)
+	m.timeSeriesStaleMarkers = append(m.timeSeriesStaleMarkers, false)
+	m.timeSeriesCount++
+
+	return nil
+}
+
+// validateLabels checks whether the labels of a time series match the label names of the metric.
+func (m *Metric) validateLabels(labels map[string]string) error {
 	labelsNamesMap := make(map[string]struct{})
 	for _, labelName := range m.desc.LabelsNames {
 		labelsNamesMap[labelName] = struct{}{}
 	}
 
-	metricsLabels := metricTimeSeries.Labels()
-
-	for k := range metricsLabels {
+	for k := range labels {
 		// time series includes an unexpected label
 		if _, ok := labelsNamesMap[k]; !ok {
 			return fmt.Errorf("label mismatch: unexpected label in time series")
@@ -116,11 +261,6 @@ func (m *Metric) AddTimeSeries(metricTimeSeries MetricTimeSeriesObservable) erro
 		return fmt.Errorf("label mismatch: missing expected label in time series")
 	}
 
-	m.timeSeries = append(m.timeSeries, metricTimeSeries)
-	m.timeSeriesIterators = append(m.timeSeriesIterators, metricTimeSeries.Iterator())
-	m.timeSeriesStaleMarkers = append(m.timeSeriesStaleMarkers, false)
-	m.timeSeriesCount++
-
 	return nil
 }
 
@@ -144,6 +284,24 @@ func (m *Metric) HasInfiniteTimeSeries() bool {
 		}
 	}
 
+	for _, singleTimeseries := range m.histogramTimeSeries {
+		if singleTimeseries.IsInfinite() {
+			return true
+		}
+	}
+
+	for _, singleTimeseries := range m.nativeHistogramTimeSeries {
+		if singleTimeseries.IsInfinite() {
+			return true
+		}
+	}
+
+	for _, singleTimeseries := range m.summaryTimeSeries {
+		if singleTimeseries.IsInfinite() {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -151,7 +309,23 @@ func (m *Metric) HasInfiniteTimeSeries() bool {
 // It returns an array as the Metric may have multiple time series attached.
 // If the sample for a given time series is missing or the time series itself has been exhausted, then the result
 // won't be included in the returned array.
+// If the sample for a given time series is stale, a stale marker is returned, but the time series carries on.
+// If the scrape has been skipped, the time series are evaluated, but no results are returned.
 func (m *Metric) Evaluate(scrapeInfo metrics.ScrapeInfo) []MetricResult {
+	if scrapeInfo.Skipped {
+		m.skip(scrapeInfo)
+		return nil
+	}
+
+	switch m.desc.MetricType {
+	case MetricTypeHistogram:
+		return m.evaluateHistogram(scrapeInfo)
+	case MetricTypeNativeHistogram:
+		return m.evaluateNativeHistogram(scrapeInfo)
+	case MetricTypeSummary:
+		return m.evaluateSummary(scrapeInfo)
+	}
+
 	var results []MetricResult
 
 	// loop over iterators, get result and decide what to do
@@ -179,6 +353,176 @@ func (m *Metric) Evaluate(scrapeInfo metrics.ScrapeInfo) []MetricResult {
 			LabelsSet:   m.timeSeries[i].Labels(),
 			Timestamp:   scrapeInfo.IterationTime,
 			Value:       scrapeResult.Value,
+			StaleMarker: m.timeSeriesStaleMarkers[i] || scrapeResult.Stale,
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// skip evaluates all time series, discarding the results, as the system being observed keeps going even when a
+// scrape is skipped.
+// The stale markers are left untouched, so the stale marker of a time series exhausted on a skipped scrape is sent on
+// the next scrape.
+func (m *Metric) skip(scrapeInfo metrics.ScrapeInfo) {
+	for _, timeSeriesIterator := range m.timeSeriesIterators {
+		timeSeriesIterator.Evaluate(scrapeInfo)
+	}
+
+	for _, histogramTimeSeriesIterator := range m.histogramTimeSeriesIterators {
+		histogramTimeSeriesIterator.Evaluate(scrapeInfo)
+	}
+
+	for _, nativeHistogramTimeSeriesIterator := range m.nativeHistogramTimeSeriesIterators {
+		nativeHistogramTimeSeriesIterator.Evaluate(scrapeInfo)
+	}
+
+	for _, summaryTimeSeriesIterator := range m.summaryTimeSeriesIterators {
+		summaryTimeSeriesIterator.Evaluate(scrapeInfo)
+	}
+}
+
+// evaluateHistogram is the equivalent of Evaluate for histogram metrics.
+// The stale marker of a histogram carries the buckets last returned by the time series, so a stale marker can be sent
+// for each one of them.
+func (m *Metric) evaluateHistogram(scrapeInfo metrics.ScrapeInfo) []MetricResult {
+	var results []MetricResult
+
+	for i, histogramTimeSeriesIterator := range m.histogramTimeSeriesIterators {
+		scrapeHistogramResult := histogramTimeSeriesIterator.Evaluate(scrapeInfo)
+
+		if scrapeHistogramResult.Missing {
+			continue
+		}
+
+		// Inconsistent histograms are passed on with their error, so the inconsistency is reported by the consumer of
+		// the results. They never become the buckets sent with the stale marker.
+		if scrapeHistogramResult.Err != nil {
+			results = append(results, MetricResult{
+				Desc:      m.desc,
+				PromDesc:  m.promDesc,
+				LabelsSet: m.histogramTimeSeries[i].Labels(),
+				Timestamp: scrapeInfo.IterationTime,
+				Histogram: HistogramValue{
+					Buckets: scrapeHistogramResult.Buckets,
+					Count:   scrapeHistogramResult.Count,
+					Sum:     scrapeHistogramResult.Sum,
+					Err:     scrapeHistogramResult.Err,
+				},
+			})
+			continue
+		}
+
+		if scrapeHistogramResult.Exhausted {
+			if m.timeSeriesStaleMarkers[i] {
+				continue
+			}
+
+			m.timeSeriesStaleMarkers[i] = true
+			scrapeHistogramResult.Buckets = m.histogramTimeSeriesLastBuckets[i]
+		} else {
+			m.histogramTimeSeriesLastBuckets[i] = scrapeHistogramResult.Buckets
+		}
+
+		result := MetricResult{
+			Desc:      m.desc,
+			PromDesc:  m.promDesc,
+			LabelsSet: m.histogramTimeSeries[i].Labels(),
+			Timestamp: scrapeInfo.IterationTime,
+			Histogram: HistogramValue{
+				Buckets: scrapeHistogramResult.Buckets,
+				Count:   scrapeHistogramResult.Count,
+				Sum:     scrapeHistogramResult.Sum,
+			},
+			StaleMarker: m.timeSeriesStaleMarkers[i],
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// evaluateNativeHistogram is the equivalent of Evaluate for native histogram metrics.
+func (m *Metric) evaluateNativeHistogram(scrapeInfo metrics.ScrapeInfo) []MetricResult {
+	var results []MetricResult
+
+	for i, nativeHistogramTimeSeriesIterator := range m.nativeHistogramTimeSeriesIterators {
+		scrapeNativeHistogramResult := nativeHistogramTimeSeriesIterator.Evaluate(scrapeInfo)
+
+		if scrapeNativeHistogramResult.Missing {
+			continue
+		}
+
+		if scrapeNativeHistogramResult.Exhausted {
+			if m.timeSeriesStaleMarkers[i] {
+				continue
+			}
+
+			m.timeSeriesStaleMarkers[i] = true
+		}
+
+		result := MetricResult{
+			Desc:      m.desc,
+			PromDesc:  m.promDesc,
+			LabelsSet: m.nativeHistogramTimeSeries[i].Labels(),
+			Timestamp: scrapeInfo.IterationTime,
+			NativeHistogram: NativeHistogramValue{
+				Schema:         scrapeNativeHistogramResult.Schema,
+				ZeroThreshold:  scrapeNativeHistogramResult.ZeroThreshold,
+				ZeroCount:      scrapeNativeHistogramResult.ZeroCount,
+				Count:          scrapeNativeHistogramResult.Count,
+				Sum:            scrapeNativeHistogramResult.Sum,
+				PositiveSpans:  scrapeNativeHistogramResult.PositiveSpans,
+				PositiveDeltas: scrapeNativeHistogramResult.PositiveDeltas,
+				NegativeSpans:  scrapeNativeHistogramResult.NegativeSpans,
+				NegativeDeltas: scrapeNativeHistogramResult.NegativeDeltas,
+			},
+			StaleMarker: m.timeSeriesStaleMarkers[i],
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// evaluateSummary is the equivalent of Evaluate for summary metrics.
+// The stale marker of a summary carries the quantiles last returned by the time series, so a stale marker can be sent
+// for each one of them.
+func (m *Metric) evaluateSummary(scrapeInfo metrics.ScrapeInfo) []MetricResult {
+	var results []MetricResult
+
+	for i, summaryTimeSeriesIterator := range m.summaryTimeSeriesIterators {
+		scrapeSummaryResult := summaryTimeSeriesIterator.Evaluate(scrapeInfo)
+
+		if scrapeSummaryResult.Missing {
+			continue
+		}
+
+		if scrapeSummaryResult.Exhausted {
+			if m.timeSeriesStaleMarkers[i] {
+				continue
+			}
+
+			m.timeSeriesStaleMarkers[i] = true
+			scrapeSummaryResult.Quantiles = m.summaryTimeSeriesLastQuantiles[i]
+		} else {
+			m.summaryTimeSeriesLastQuantiles[i] = scrapeSummaryResult.Quantiles
+		}
+
+		result := MetricResult{
+			Desc:      m.desc,
+			PromDesc:  m.promDesc,
+			LabelsSet: m.summaryTimeSeries[i].Labels(),
+			Timestamp: scrapeInfo.IterationTime,
+			Summary: SummaryValue{
+				Quantiles: scrapeSummaryResult.Quantiles,
+				Count:     scrapeSummaryResult.Count,
+				Sum:       scrapeSummaryResult.Sum,
+			},
 			StaleMarker: m.timeSeriesStaleMarkers[i],
 		}
 
@@ -189,7 +533,8 @@ func (m *Metric) Evaluate(scrapeInfo metrics.ScrapeInfo) []MetricResult {
 }
 
 // MetricResult represents the result of a Metric.
-// It's only meant to be used by a Counter or a Gauge.
+// Counters and Gauges set the Value field, Histograms set the Histogram field, Native Histograms set the
+// NativeHistogram field and Summaries set the Summary field.
 type MetricResult struct {
 	Desc     Desc
 	PromDesc *prometheus.Desc
@@ -203,9 +548,62 @@ type MetricResult struct {
 	// Value represents the value of the sample.
 	Value float64
 
-	// StaleMarker represents whether this time series has come to an end.
+	// Histogram represents the value of the sample of a histogram.
+	Histogram HistogramValue
+
+	// NativeHistogram represents the value of the sample of a native histogram.
+	NativeHistogram NativeHistogramValue
+
+	// Summary represents the value of the sample of a summary.
+	Summary SummaryValue
+
+	// StaleMarker represents whether this time series has come to an end, or is stale at this scrape.
 	// Spec Ref:
 	//	Prometheus remote write compatible senders MUST send stale markers when a time series will no longer be appended
 	//  to.
 	StaleMarker bool
 }
+
+// HistogramValue represents the value of a histogram sample.
+type HistogramValue struct {
+	// Buckets contains the cumulative count of each bucket, not including the +Inf bucket.
+	Buckets []metrics.HistogramBucketScrape
+
+	// Count is the number of observations recorded by the histogram, which is also the count of the +Inf bucket.
+	Count float64
+
+	// Sum is the total sum of all the observations recorded by the histogram.
+	Sum float64
+
+	// Err reports why the histogram is inconsistent, e.g., as found by the metrics.ValidatingHistogramIterator.
+	// Inconsistent histograms cannot be exposed nor sent through remote write.
+	Err error
+}
+
+// NativeHistogramValue represents the value of a native histogram sample.
+// Refer to metrics.ScrapeNativeHistogramResult for the meaning of each field.
+type NativeHistogramValue struct {
+	Schema        int32
+	ZeroThreshold float64
+	ZeroCount     uint64
+
+	Count uint64
+	Sum   float64
+
+	PositiveSpans  []metrics.NativeHistogramBucketSpan
+	PositiveDeltas []int64
+	NegativeSpans  []metrics.NativeHistogramBucketSpan
+	NegativeDeltas []int64
+}
+
+// SummaryValue represents the value of a summary sample.
+type SummaryValue struct {
+	// Quantiles contains the value of each quantile, in increasing order of quantile.
+	Quantiles []metrics.SummaryQuantileScrape
+
+	// Count is the number of observations recorded by the summary.
+	Count float64
+
+	// Sum is the total sum of all the observations recorded by the summary.
+	Sum float64
+}
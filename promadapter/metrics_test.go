@@ -148,6 +148,281 @@ func TestMetric(t *testing.T) {
 		assert.Equal(t, 0, len(results[3].metricResults))
 		assert.Equal(t, 0, len(results[4].metricResults))
 	})
+
+	t.Run("should advance the time series without returning results given a skipped scrape", func(t *testing.T) {
+		dataGenerator := discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 1}, {Value: 2}, {Value: 3}})
+
+		timeSeries := discrete.NewMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyRemoveTimeSeries())
+
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeGauge, nil)
+		err := metric.AddTimeSeries(timeSeries)
+		require.NoError(t, err)
+
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+		scrapeInfos := []metrics.ScrapeInfo{
+			{FirstIterationTime: startTime, IterationIndex: 0, IterationTime: startTime},
+			{FirstIterationTime: startTime, IterationIndex: 1, IterationTime: startTime.Add(15 * time.Second), Skipped: true},
+			{FirstIterationTime: startTime, IterationIndex: 2, IterationTime: startTime.Add(30 * time.Second)},
+			{FirstIterationTime: startTime, IterationIndex: 3, IterationTime: startTime.Add(45 * time.Second), Skipped: true},
+			{FirstIterationTime: startTime, IterationIndex: 4, IterationTime: startTime.Add(60 * time.Second)},
+		}
+
+		var results [][]promadapter.MetricResult
+		for _, scrapeInfo := range scrapeInfos {
+			results = append(results, metric.Evaluate(scrapeInfo))
+		}
+
+		require.Equal(t, 1, len(results[0]))
+		assert.Equal(t, 1.0, results[0][0].Value)
+		assert.Equal(t, 0, len(results[1]))
+		require.Equal(t, 1, len(results[2]))
+		assert.Equal(t, 3.0, results[2][0].Value)
+		assert.Equal(t, 0, len(results[3]))
+		// The time series got exhausted on the skipped scrape, hence the stale marker is sent on the next one.
+		require.Equal(t, 1, len(results[4]))
+		assert.True(t, results[4][0].StaleMarker)
+	})
+
+	t.Run("should send a stale marker given a stale sample and carry on afterwards", func(t *testing.T) {
+		dataGenerator, err := discrete.ParseSeriesNotation("1 stale 2")
+		require.NoError(t, err)
+
+		timeSeries := discrete.NewMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyRemoveTimeSeries())
+
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeGauge, nil)
+		err = metric.AddTimeSeries(timeSeries)
+		require.NoError(t, err)
+
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+		var results [][]promadapter.MetricResult
+		for i := 0; i < 5; i++ {
+			scrapeInfo := metrics.ScrapeInfo{
+				FirstIterationTime: startTime,
+				IterationIndex:     i,
+				IterationTime:      startTime.Add(time.Duration(i) * 15 * time.Second),
+			}
+			results = append(results, metric.Evaluate(scrapeInfo))
+		}
+
+		require.Equal(t, 1, len(results[0]))
+		assert.False(t, results[0][0].StaleMarker)
+		require.Equal(t, 1, len(results[1]))
+		assert.True(t, results[1][0].StaleMarker)
+		require.Equal(t, 1, len(results[2]))
+		assert.False(t, results[2][0].StaleMarker)
+		assert.Equal(t, 2.0, results[2][0].Value)
+		// The time series is exhausted, hence the stale marker is sent only once.
+		require.Equal(t, 1, len(results[3]))
+		assert.True(t, results[3][0].StaleMarker)
+		assert.Equal(t, 0, len(results[4]))
+	})
+}
+
+func TestHistogramMetric(t *testing.T) {
+	t.Run("should fail to attach a histogram time series to a gauge", func(t *testing.T) {
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeGauge, []string{"label1"})
+
+		err := metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"label1": "value1"},
+			helperHistogramGenerator(3),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.Error(t, err)
+		assert.Equal(t, "metric type mismatch: only histogram metrics accept histogram time series", err.Error())
+	})
+
+	t.Run("should fail to attach a counter time series to a histogram", func(t *testing.T) {
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeHistogram, []string{"label1"})
+
+		err := metric.AddTimeSeries(discrete.NewMetricTimeSeries(
+			map[string]string{"label1": "value1"},
+			discrete.NewVoidSegmentDataGenerator(5),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.Error(t, err)
+		assert.Equal(t, "metric type mismatch: histogram metrics only accept histogram time series", err.Error())
+	})
+
+	t.Run("should fail to attach a histogram time series which includes an unexpected label name", func(t *testing.T) {
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeHistogram, []string{"label1"})
+
+		err := metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"label_extra": "value"},
+			helperHistogramGenerator(3),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.Error(t, err)
+		assert.Equal(t, "label mismatch: unexpected label in time series", err.Error())
+	})
+
+	t.Run("should return the histograms followed by a stale marker", func(t *testing.T) {
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(5),
+		)
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeHistogram, []string{"label1"})
+
+		err = metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(
+			map[string]string{"label1": "value1"},
+			helperHistogramGenerator(2),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, 1, metric.TimeSeriesCount())
+		assert.False(t, metric.HasInfiniteTimeSeries())
+
+		var results []resultContainer
+		iter := scraper.Iterator()
+		for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+			results = append(results, resultContainer{
+				scrapeInfo:    scrapeInfo,
+				metricResults: metric.Evaluate(scrapeInfo),
+			})
+		}
+
+		require.Equal(t, 5, len(results))
+
+		require.Equal(t, 1, len(results[1].metricResults))
+		histogram := results[1].metricResults[0].Histogram
+		assert.Equal(t, []metrics.HistogramBucketScrape{{LE: 0.5, Value: 2}, {LE: 1, Value: 4}}, histogram.Buckets)
+		assert.InDelta(t, 6, histogram.Count, 0.001)
+		assert.InDelta(t, 6, histogram.Sum, 0.001)
+		assert.False(t, results[1].metricResults[0].StaleMarker)
+
+		require.Equal(t, 1, len(results[2].metricResults))
+		assert.True(t, results[2].metricResults[0].StaleMarker)
+		assert.Equal(t, histogram.Buckets, results[2].metricResults[0].Histogram.Buckets)
+		assert.Equal(t, 0, len(results[3].metricResults))
+	})
+}
+
+func TestSummaryMetric(t *testing.T) {
+	t.Run("should fail to attach a summary time series to a histogram", func(t *testing.T) {
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeHistogram, []string{"label1"})
+
+		err := metric.AddSummaryTimeSeries(discrete.NewSummaryMetricTimeSeries(
+			map[string]string{"label1": "value1"},
+			helperSummaryGenerator(3),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.Error(t, err)
+		assert.Equal(t, "metric type mismatch: only summary metrics accept summary time series", err.Error())
+	})
+
+	t.Run("should fail to attach a summary time series to a metric with a quantile label", func(t *testing.T) {
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeSummary, []string{"quantile"})
+
+		err := metric.AddSummaryTimeSeries(discrete.NewSummaryMetricTimeSeries(
+			map[string]string{"quantile": "value1"},
+			helperSummaryGenerator(3),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.Error(t, err)
+		assert.Equal(t, `label mismatch: "quantile" label is reserved for the quantiles`, err.Error())
+	})
+
+	t.Run("should return the summaries followed by a stale marker carrying the last quantiles", func(t *testing.T) {
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(5),
+		)
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("some-metric", "some-help-description", promadapter.MetricTypeSummary, []string{"label1"})
+
+		err = metric.AddSummaryTimeSeries(discrete.NewSummaryMetricTimeSeries(
+			map[string]string{"label1": "value1"},
+			helperSummaryGenerator(2),
+			metrics.NewEndStrategyRemoveTimeSeries(),
+		))
+		require.NoError(t, err)
+		assert.Equal(t, 1, metric.TimeSeriesCount())
+		assert.False(t, metric.HasInfiniteTimeSeries())
+
+		var results []resultContainer
+		iter := scraper.Iterator()
+		for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+			results = append(results, resultContainer{
+				scrapeInfo:    scrapeInfo,
+				metricResults: metric.Evaluate(scrapeInfo),
+			})
+		}
+
+		require.Equal(t, 5, len(results))
+
+		require.Equal(t, 1, len(results[1].metricResults))
+		summary := results[1].metricResults[0].Summary
+		assert.Equal(t, []metrics.SummaryQuantileScrape{{Quantile: 0.5, Value: 1}, {Quantile: 0.9, Value: 2}}, summary.Quantiles)
+		assert.InDelta(t, 6, summary.Count, 0.001)
+		assert.InDelta(t, 9, summary.Sum, 0.001)
+		assert.False(t, results[1].metricResults[0].StaleMarker)
+
+		require.Equal(t, 1, len(results[2].metricResults))
+		assert.True(t, results[2].metricResults[0].StaleMarker)
+		assert.Equal(t, summary.Quantiles, results[2].metricResults[0].Summary.Quantiles)
+		assert.Equal(t, 0, len(results[3].metricResults))
+	})
+}
+
+// helperSummaryGenerator returns a DataSummaryGenerator that records 3 observations on each scrape (0.5, 1 and 1.5),
+// with a median of 1 and a 0.9 quantile of 2, for the given number of scrapes.
+func helperSummaryGenerator(iterationCountLimit int) discrete.DataSummaryGenerator {
+	return staticDataSummaryGenerator{iterationCountLimit: iterationCountLimit}
+}
+
+type staticDataSummaryGenerator struct {
+	iterationCountLimit int
+}
+
+func (dg staticDataSummaryGenerator) Iterator() metrics.DataSummaryIterator {
+	return metrics.DataSummaryIteratorFunc(func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeSummaryResult {
+		if scrapeInfo.IterationIndex >= dg.iterationCountLimit {
+			return metrics.ScrapeSummaryResult{Exhausted: true}
+		}
+
+		n := float64(scrapeInfo.IterationIndex + 1)
+
+		return metrics.ScrapeSummaryResult{
+			Quantiles: []metrics.SummaryQuantileScrape{{Quantile: 0.5, Value: 1}, {Quantile: 0.9, Value: 2}},
+			Count:     3 * n,
+			Sum:       4.5 * n,
+		}
+	})
+}
+
+// helperHistogramGenerator returns a DataHistogramGenerator that records 3 observations on each scrape (0.25, 0.75 and
+// 2), for the given number of scrapes.
+func helperHistogramGenerator(iterationCountLimit int) discrete.DataHistogramGenerator {
+	return staticDataHistogramGenerator{iterationCountLimit: iterationCountLimit}
+}
+
+type staticDataHistogramGenerator struct {
+	iterationCountLimit int
+}
+
+func (dg staticDataHistogramGenerator) Iterator() metrics.DataHistogramIterator {
+	return metrics.DataHistogramIteratorFunc(func(scrapeInfo metrics.ScrapeInfo) metrics.ScrapeHistogramResult {
+		if scrapeInfo.IterationIndex >= dg.iterationCountLimit {
+			return metrics.ScrapeHistogramResult{Exhausted: true}
+		}
+
+		n := float64(scrapeInfo.IterationIndex + 1)
+
+		return metrics.ScrapeHistogramResult{
+			Buckets: []metrics.HistogramBucketScrape{{LE: 0.5, Value: n}, {LE: 1, Value: 2 * n}},
+			Count:   3 * n,
+			Sum:     3 * n,
+		}
+	})
 }
 
 type resultContainer struct {
@@ -0,0 +1,118 @@
+package promadapter
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+)
+
+// Check at compile time whether constNativeHistogram implements prometheus.Metric interface.
+var _ prometheus.Metric = (*constNativeHistogram)(nil)
+
+// constNativeHistogram is a prometheus.Metric representing a native histogram with fixed values.
+// The version of the prometheus client library in use doesn't provide a constructor for constant native histograms, so
+// the metric is written to the protobuf message directly.
+// Native histograms can only be scraped using the protobuf exposition format. The text format only exposes the count
+// and sum of the histogram.
+type constNativeHistogram struct {
+	desc       *prometheus.Desc
+	labelPairs []*dto.LabelPair
+	histogram  NativeHistogramValue
+}
+
+// newConstNativeHistogram creates a prometheus.Metric from the native histogram result.
+// Just like the constructors of the prometheus client library, it validates the label values, as well as the layout of
+// the buckets.
+func newConstNativeHistogram(metricResult MetricResult, labelValues []string) (prometheus.Metric, error) {
+	if len(labelValues) != len(metricResult.Desc.LabelsNames) {
+		return nil, fmt.Errorf("inconsistent label cardinality: expected %d label values but got %d",
+			len(metricResult.Desc.LabelsNames), len(labelValues))
+	}
+
+	for _, labelValue := range labelValues {
+		if !utf8.ValidString(labelValue) {
+			return nil, fmt.Errorf("label value %q is not valid UTF-8", labelValue)
+		}
+	}
+
+	histogram := metricResult.NativeHistogram
+
+	if histogram.Schema < -4 || histogram.Schema > 8 {
+		return nil, fmt.Errorf("schema %d is out of range [-4,8]", histogram.Schema)
+	}
+
+	if err := validateBucketSpans(histogram.PositiveSpans, histogram.PositiveDeltas); err != nil {
+		return nil, fmt.Errorf("invalid positive buckets: %w", err)
+	}
+
+	if err := validateBucketSpans(histogram.NegativeSpans, histogram.NegativeDeltas); err != nil {
+		return nil, fmt.Errorf("invalid negative buckets: %w", err)
+	}
+
+	return &constNativeHistogram{
+		desc:       metricResult.PromDesc,
+		labelPairs: prometheus.MakeLabelPairs(metricResult.PromDesc, labelValues),
+		histogram:  histogram,
+	}, nil
+}
+
+// validateBucketSpans checks the spans cover exactly as many buckets as there are deltas.
+func validateBucketSpans(spans []metrics.NativeHistogramBucketSpan, deltas []int64) error {
+	bucketCount := 0
+	for _, span := range spans {
+		bucketCount += int(span.Length)
+	}
+
+	if bucketCount != len(deltas) {
+		return fmt.Errorf("spans cover %d buckets, but there are %d deltas", bucketCount, len(deltas))
+	}
+
+	return nil
+}
+
+func (h *constNativeHistogram) Desc() *prometheus.Desc {
+	return h.desc
+}
+
+func (h *constNativeHistogram) Write(out *dto.Metric) error {
+	histogram := &dto.Histogram{
+		SampleCount:   proto.Uint64(h.histogram.Count),
+		SampleSum:     proto.Float64(h.histogram.Sum),
+		Schema:        proto.Int32(h.histogram.Schema),
+		ZeroThreshold: proto.Float64(h.histogram.ZeroThreshold),
+		ZeroCount:     proto.Uint64(h.histogram.ZeroCount),
+		PositiveSpan:  toProtoBucketSpans(h.histogram.PositiveSpans),
+		PositiveDelta: h.histogram.PositiveDeltas,
+		NegativeSpan:  toProtoBucketSpans(h.histogram.NegativeSpans),
+		NegativeDelta: h.histogram.NegativeDeltas,
+	}
+
+	// An empty native histogram would be indistinguishable from a classic histogram without buckets, hence a span of
+	// length zero is added to signal it's a native histogram, just like the prometheus client library does.
+	if h.histogram.ZeroThreshold == 0 && h.histogram.ZeroCount == 0 &&
+		len(histogram.PositiveSpan) == 0 && len(histogram.NegativeSpan) == 0 {
+		histogram.PositiveSpan = []*dto.BucketSpan{{Offset: proto.Int32(0), Length: proto.Uint32(0)}}
+	}
+
+	out.Label = h.labelPairs
+	out.Histogram = histogram
+
+	return nil
+}
+
+func toProtoBucketSpans(spans []metrics.NativeHistogramBucketSpan) []*dto.BucketSpan {
+	protoSpans := make([]*dto.BucketSpan, 0, len(spans))
+	for _, span := range spans {
+		protoSpans = append(protoSpans, &dto.BucketSpan{
+			Offset: proto.Int32(span.Offset),
+			Length: proto.Uint32(span.Length),
+		})
+	}
+
+	return protoSpans
+}
@@ -3,6 +3,8 @@ package promwrite
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
@@ -48,12 +50,10 @@ func GenerateAndImportMetrics(ctx context.Context, prometheusRemoteWriter *Prome
 	// If this variable is set to true we jump out.
 	noMoreSamples := false
 
-	// scrapeCount keeps track of how many scrapes happened.
-	scrapeCount := 0
-
 	iter := scraper.Iterator()
 	for scrapeInfo, ok := iter.Next(); ok && !noMoreSamples; scrapeInfo, ok = iter.Next() {
-		noMoreSamples = true
+		// A skipped scrape produces no samples, which doesn't mean the time series are done generating samples.
+		noMoreSamples = !scrapeInfo.Skipped
 
 		// for each scrape go through all observables
 		for _, observable := range metricsObservables {
@@ -68,25 +68,133 @@ func GenerateAndImportMetrics(ctx context.Context, prometheusRemoteWriter *Prome
 
 			remoteWriterTimeSeries := ConvertToRemoteWriterTimeSeries(observable.Desc().MetricFamily, metricResults)
 
-			fmt.Printf("Time series: %+v\n", remoteWriterTimeSeries)
-
 			err := prometheusRemoteWriter.Send(ctx, remoteWriterTimeSeries)
 			if err != nil {
-				return fmt.Errorf("error sending metric to prometheus")
+				return fmt.Errorf("error sending metrics to prometheus: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backfillBatchMaxSamples is the maximum number of samples sent in a single request while backfilling.
+const backfillBatchMaxSamples = 2000
+
+// RealTimeScraper is an interface for metrics.RealTimeScraper.
+type RealTimeScraper interface {
+	Run(ctx context.Context, tickHandler metrics.TickHandler) error
+	ScrapeInterval() time.Duration
+	Now() time.Time
+}
+
+// BackfillAndPushMetrics takes all the samples generated by the DataIterators and sends them to Prometheus, first
+// backfilling the past and then pushing the samples live.
+// The scrapes whose time has already gone by, i.e., from the start time of the scraper up to now, are generated as
+// fast as possible and sent in batches, where all the samples of a time series are sent together. Once it catches up
+// with the clock of the scraper, every scrape is sent as soon as its time comes. The same observables are evaluated throughout,
+// so the state of their iterators carries over from the backfill to the live samples.
+// Unlike GenerateAndImportMetrics, both the scraper and the time series can be infinite. This function terminates when
+// there are no more scrapes to be generated, or sending the samples fails, or the context is cancelled, in which case
+// the error of the context is returned.
+func BackfillAndPushMetrics(ctx context.Context, prometheusRemoteWriter *PrometheusRemoteWriter, scraper RealTimeScraper, metricsObservables []promadapter.MetricObservable) error {
+	batch := newTimeSeriesBatch()
+
+	err := scraper.Run(ctx, func(scrapeInfo metrics.ScrapeInfo) error {
+		for _, observable := range metricsObservables {
+			metricResults := observable.Evaluate(scrapeInfo)
+			if len(metricResults) == 0 {
+				continue
 			}
+
+			batch.add(ConvertToRemoteWriterTimeSeries(observable.Desc().MetricFamily, metricResults))
+		}
+
+		// While backfilling, the next scrape comes right away, so the samples are batched. Once live, the samples are
+		// sent straight away, as the next scrape is in the future.
+		live := !scrapeInfo.IterationTime.Add(scraper.ScrapeInterval()).Before(scraper.Now())
+		if batch.sampleCount == 0 || (!live && batch.sampleCount < backfillBatchMaxSamples) {
+			return nil
 		}
 
-		// only increment counter if we got at least one sample in this iteration.
-		// If no observable produced a single metric, we don't want to increment the counter.
-		// This might be off by one because the time series might return stale markers on the next scrape after they
-		// are done generating samples.
-		if !noMoreSamples {
-			scrapeCount++
+		if err := prometheusRemoteWriter.Send(ctx, batch.timeSeries); err != nil {
+			return fmt.Errorf("error sending metrics to prometheus: %w", err)
 		}
+		batch = newTimeSeriesBatch()
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	// report back how long the entire scrape window is
-	fmt.Printf("Samples time window: %+v\n", time.Duration(scrapeCount)*scraper.ScrapeInterval())
+	// send whatever is left once the scraper is exhausted
+	if batch.sampleCount > 0 {
+		if err := prometheusRemoteWriter.Send(ctx, batch.timeSeries); err != nil {
+			return fmt.Errorf("error sending metrics to prometheus: %w", err)
+		}
+	}
 
 	return nil
 }
+
+// timeSeriesBatch groups the samples to be sent in a single request by time series, i.e., the samples of all the
+// scrapes of a time series are sent in a single TimeSeries, rather than in a TimeSeries per scrape.
+// The time series keep the order in which they first show up in the batch, and so do their samples.
+type timeSeriesBatch struct {
+	timeSeries []TimeSeries
+
+	// indexes maps the labels of each time series, see labelsKey, to its position in the timeSeries slice.
+	indexes map[string]int
+
+	// sampleCount is the number of samples in the batch, including the native histogram samples.
+	sampleCount int
+}
+
+func newTimeSeriesBatch() *timeSeriesBatch {
+	return &timeSeriesBatch{
+		indexes: make(map[string]int),
+	}
+}
+
+// add adds the samples of the time series provided to the batch.
+func (b *timeSeriesBatch) add(timeSeries []TimeSeries) {
+	for _, ts := range timeSeries {
+		b.sampleCount += len(ts.Samples) + len(ts.Histograms)
+
+		key := labelsKey(ts.Labels)
+		index, ok := b.indexes[key]
+		if !ok {
+			b.indexes[key] = len(b.timeSeries)
+			b.timeSeries = append(b.timeSeries, TimeSeries{
+				Labels:     ts.Labels,
+				Samples:    append([]Sample(nil), ts.Samples...),
+				Histograms: append([]Histogram(nil), ts.Histograms...),
+			})
+			continue
+		}
+
+		b.timeSeries[index].Samples = append(b.timeSeries[index].Samples, ts.Samples...)
+		b.timeSeries[index].Histograms = append(b.timeSeries[index].Histograms, ts.Histograms...)
+	}
+}
+
+// labelsKey returns a key identifying the time series with the labels provided, regardless of the order of the
+// labels.
+func labelsKey(labels []Label) string {
+	sortedLabels := append([]Label(nil), labels...)
+	sort.Slice(sortedLabels, func(i, j int) bool {
+		return sortedLabels[i].Name < sortedLabels[j].Name
+	})
+
+	var builder strings.Builder
+	for _, label := range sortedLabels {
+		// The separator is not valid UTF-8, hence it cannot show up in label names nor label values.
+		builder.WriteString(label.Name)
+		builder.WriteByte(0xff)
+		builder.WriteString(label.Value)
+		builder.WriteByte(0xff)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,294 @@
+package promwrite_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/discrete"
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promwrite"
+)
+
+func TestGenerateAndImportMetrics(t *testing.T) {
+	t.Run("should backfill histograms as classic time series", func(t *testing.T) {
+		server, writeRequests := helperRemoteWriteServer(t)
+		defer server.Close()
+
+		remoteWriter, err := promwrite.NewPrometheusRemoteWriter(promwrite.PrometheusRemoteWriterConfig{Endpoint: server.URL})
+		require.NoError(t, err)
+
+		scraper, err := metrics.NewScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(10),
+		)
+		require.NoError(t, err)
+
+		dataGenerator, err := discrete.NewDistributionHistogramDataGenerator(
+			discrete.HistogramDistributionParameters{
+				Mean:            discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 0.3}, {Value: 0.3}}),
+				ObservationRate: discrete.NewCustomValuesDataGenerator([]discrete.CustomValueSample{{Value: 10}, {Value: 10}}),
+			},
+			discrete.HistogramDataGeneratorOptions{
+				Buckets:          []float64{0.1, 0.5},
+				DistributionType: discrete.DistributionTypeExponential,
+				Seed:             1,
+			},
+		)
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("request_duration_seconds", "Duration of the requests", promadapter.MetricTypeHistogram, nil)
+		err = metric.AddHistogramTimeSeries(discrete.NewHistogramMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyRemoveTimeSeries()))
+		require.NoError(t, err)
+
+		err = promwrite.GenerateAndImportMetrics(context.Background(), remoteWriter, scraper, []promadapter.MetricObservable{metric})
+		require.NoError(t, err)
+
+		// Two scrapes with samples, followed by the stale markers.
+		requests := writeRequests()
+		require.Equal(t, 3, len(requests))
+
+		for _, writeRequest := range requests {
+			var names []string
+			for _, timeSeries := range writeRequest.Timeseries {
+				for _, label := range timeSeries.Labels {
+					if label.Name == "__name__" {
+						names = append(names, label.Value)
+					}
+				}
+			}
+			sort.Strings(names)
+
+			assert.Equal(t, []string{
+				"request_duration_seconds_bucket",
+				"request_duration_seconds_bucket",
+				"request_duration_seconds_bucket",
+				"request_duration_seconds_count",
+				"request_duration_seconds_sum",
+			}, names)
+		}
+	})
+}
+
+func TestBackfillAndPushMetrics(t *testing.T) {
+	t.Run("should backfill the past in batches and then push samples live, carrying the state over", func(t *testing.T) {
+		server, writeRequests := helperRemoteWriteServer(t)
+		defer server.Close()
+
+		remoteWriter, err := promwrite.NewPrometheusRemoteWriter(promwrite.PrometheusRemoteWriterConfig{Endpoint: server.URL})
+		require.NoError(t, err)
+
+		startTime := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+		scrapeInterval := 15 * time.Second
+
+		// The scraper starts 10 minutes in the past, so the first 39 scrapes are backfilled, and the scrape 39 is the
+		// first whose interval ends after now.
+		scraper := helperFakeRealTimeScraper(t, startTime, scrapeInterval, 45, startTime.Add(10*time.Minute))
+
+		dataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      0,
+			AmplitudeEnd:        9999,
+			IterationCountLimit: 10000,
+		})
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("some_gauge", "Some gauge", promadapter.MetricTypeGauge, nil)
+		err = metric.AddTimeSeries(discrete.NewMetricTimeSeries(nil, dataGenerator, metrics.NewEndStrategyRemoveTimeSeries()))
+		require.NoError(t, err)
+
+		err = promwrite.BackfillAndPushMetrics(context.Background(), remoteWriter, scraper, []promadapter.MetricObservable{metric})
+		require.NoError(t, err)
+
+		// The backfill is sent in a single batch, with all the samples of the time series grouped together and the
+		// first live sample, followed by one request per live scrape.
+		requests := writeRequests()
+		require.Equal(t, 6, len(requests))
+
+		var samples []prompb.Sample
+		for i, writeRequest := range requests {
+			require.Equal(t, 1, len(writeRequest.Timeseries), "request %d", i)
+
+			if i == 0 {
+				assert.Equal(t, 40, len(writeRequest.Timeseries[0].Samples))
+			} else {
+				assert.Equal(t, 1, len(writeRequest.Timeseries[0].Samples), "request %d", i)
+			}
+
+			samples = append(samples, writeRequest.Timeseries[0].Samples...)
+		}
+
+		require.Equal(t, 45, len(samples))
+		for i, sample := range samples {
+			assert.Equal(t, float64(i), sample.Value)
+			assert.Equal(t, startTime.Add(time.Duration(i)*scrapeInterval).UnixMilli(), sample.Timestamp)
+		}
+	})
+
+	t.Run("should group the backfilled samples by time series and split the batches by number of samples", func(t *testing.T) {
+		server, writeRequests := helperRemoteWriteServer(t)
+		defer server.Close()
+
+		remoteWriter, err := promwrite.NewPrometheusRemoteWriter(promwrite.PrometheusRemoteWriterConfig{Endpoint: server.URL})
+		require.NoError(t, err)
+
+		// All the scrapes are in the past, so they are all backfilled.
+		scraper, err := metrics.NewRealTimeScraper(
+			metrics.ScraperConfig{
+				StartTime:      time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+				ScrapeInterval: 15 * time.Second,
+			},
+			metrics.WithScraperIterationCountLimit(1500),
+		)
+		require.NoError(t, err)
+
+		dataGenerator, err := discrete.NewLinearSegmentDataGenerator(discrete.LinearSegmentDataGeneratorOptions{
+			AmplitudeStart:      0,
+			AmplitudeEnd:        1499,
+			IterationCountLimit: 1500,
+		})
+		require.NoError(t, err)
+
+		metric := promadapter.NewMetric("some_gauge", "Some gauge", promadapter.MetricTypeGauge, []string{"instance"})
+		for _, instance := range []string{"a", "b"} {
+			err = metric.AddTimeSeries(discrete.NewMetricTimeSeries(map[string]string{"instance": instance}, dataGenerator, metrics.NewEndStrategyRemoveTimeSeries()))
+			require.NoError(t, err)
+		}
+
+		err = promwrite.BackfillAndPushMetrics(context.Background(), remoteWriter, scraper, []promadapter.MetricObservable{metric})
+		require.NoError(t, err)
+
+		// 3000 samples are sent in a batch of 2000 samples, followed by the remaining 1000 samples.
+		requests := writeRequests()
+		require.Equal(t, 2, len(requests))
+
+		samplesByInstance := make(map[string][]prompb.Sample)
+		for i, writeRequest := range requests {
+			seenLabels := make(map[string]struct{})
+
+			require.Equal(t, 2, len(writeRequest.Timeseries), "request %d", i)
+			for _, timeSeries := range writeRequest.Timeseries {
+				labels := fmt.Sprint(timeSeries.Labels)
+				_, ok := seenLabels[labels]
+				assert.False(t, ok, "request %d has the time series %s more than once", i, labels)
+				seenLabels[labels] = struct{}{}
+
+				assert.Equal(t, 1000-500*i, len(timeSeries.Samples), "request %d", i)
+
+				for _, label := range timeSeries.Labels {
+					if label.Name == "instance" {
+						samplesByInstance[label.Value] = append(samplesByInstance[label.Value], timeSeries.Samples...)
+					}
+				}
+			}
+		}
+
+		for instance, samples := range samplesByInstance {
+			require.Equal(t, 1500, len(samples), instance)
+			for i, sample := range samples {
+				assert.Equal(t, float64(i), sample.Value, instance)
+			}
+		}
+	})
+}
+
+// helperRemoteWriteServer returns a server that records the remote write requests it receives, along with a function
+// returning the requests received so far.
+// The handler runs on the goroutine of the server, so failures are reported with assert rather than require, and the
+// request is answered with an error instead.
+func helperRemoteWriteServer(t *testing.T) (*httptest.Server, func() []prompb.WriteRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var writeRequests []prompb.WriteRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var writeRequest prompb.WriteRequest
+
+		compressed, err := io.ReadAll(r.Body)
+		if assert.NoError(t, err) {
+			var body []byte
+			body, err = snappy.Decode(nil, compressed)
+			if assert.NoError(t, err) {
+				err = proto.Unmarshal(body, &writeRequest)
+				assert.NoError(t, err)
+			}
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		writeRequests = append(writeRequests, writeRequest)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	return server, func() []prompb.WriteRequest {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]prompb.WriteRequest(nil), writeRequests...)
+	}
+}
+
+// fakeRealTimeScraper hands out the scrapes against a fake clock, which moves forward to the time of each scrape
+// whose time is yet to come, instead of waiting for the wall clock.
+type fakeRealTimeScraper struct {
+	scraper *metrics.Scraper
+	now     time.Time
+}
+
+// helperFakeRealTimeScraper returns a fakeRealTimeScraper whose clock starts at the time provided as now.
+func helperFakeRealTimeScraper(t *testing.T, startTime time.Time, scrapeInterval time.Duration, iterationCountLimit int, now time.Time) *fakeRealTimeScraper {
+	t.Helper()
+
+	scraper, err := metrics.NewScraper(
+		metrics.ScraperConfig{StartTime: startTime, ScrapeInterval: scrapeInterval},
+		metrics.WithScraperIterationCountLimit(iterationCountLimit),
+	)
+	require.NoError(t, err)
+
+	return &fakeRealTimeScraper{scraper: scraper, now: now}
+}
+
+func (s *fakeRealTimeScraper) Run(_ context.Context, tickHandler metrics.TickHandler) error {
+	iter := s.scraper.Iterator()
+	for scrapeInfo, ok := iter.Next(); ok; scrapeInfo, ok = iter.Next() {
+		if scrapeInfo.IterationTime.After(s.now) {
+			s.now = scrapeInfo.IterationTime
+		}
+
+		if err := tickHandler(scrapeInfo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *fakeRealTimeScraper) ScrapeInterval() time.Duration {
+	return s.scraper.ScrapeInterval()
+}
+
+func (s *fakeRealTimeScraper) Now() time.Time {
+	return s.now
+}
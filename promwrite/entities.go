@@ -3,9 +3,11 @@ package promwrite
 import "time"
 
 // TimeSeries represents a time series that contains labels and a series of samples.
+// Native histograms set the Histograms field instead of the Samples field.
 type TimeSeries struct {
-	Labels  []Label
-	Samples []Sample
+	Labels     []Label
+	Samples    []Sample
+	Histograms []Histogram
 }
 
 // Label represents a label that can be attached to a time series.
@@ -19,3 +21,27 @@ type Sample struct {
 	Time  time.Time
 	Value float64
 }
+
+// Histogram represents a native histogram sample in a time series.
+// The buckets are encoded as spans of consecutive buckets and the deltas between the counts of consecutive buckets.
+type Histogram struct {
+	Time time.Time
+
+	Schema        int32
+	ZeroThreshold float64
+	ZeroCount     uint64
+
+	Count uint64
+	Sum   float64
+
+	PositiveSpans  []BucketSpan
+	PositiveDeltas []int64
+	NegativeSpans  []BucketSpan
+	NegativeDeltas []int64
+}
+
+// BucketSpan represents a span of consecutive buckets of a native histogram.
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
@@ -224,9 +224,15 @@ func toProtoTimeSeries(timeSeries []TimeSeries) ([]prompb.TimeSeries, error) {
 			}
 		}
 
+		histograms := make([]prompb.Histogram, len(singleTimeSeries.Histograms))
+		for histogramIndex, histogram := range singleTimeSeries.Histograms {
+			histograms[histogramIndex] = toProtoHistogram(histogram)
+		}
+
 		protoSingleTimeSeries := prompb.TimeSeries{
-			Labels:  labels,
-			Samples: samples,
+			Labels:     labels,
+			Samples:    samples,
+			Histograms: histograms,
 		}
 
 		protoTimeSeries[i] = protoSingleTimeSeries
@@ -235,6 +241,36 @@ func toProtoTimeSeries(timeSeries []TimeSeries) ([]prompb.TimeSeries, error) {
 	return protoTimeSeries, nil
 }
 
+// toProtoHistogram converts a native histogram into its protobuf struct.
+// Only integer histograms are supported, hence the bucket counts are sent as deltas.
+func toProtoHistogram(histogram Histogram) prompb.Histogram {
+	return prompb.Histogram{
+		Count:          &prompb.Histogram_CountInt{CountInt: histogram.Count},
+		Sum:            histogram.Sum,
+		Schema:         histogram.Schema,
+		ZeroThreshold:  histogram.ZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: histogram.ZeroCount},
+		NegativeSpans:  toProtoBucketSpans(histogram.NegativeSpans),
+		NegativeDeltas: histogram.NegativeDeltas,
+		PositiveSpans:  toProtoBucketSpans(histogram.PositiveSpans),
+		PositiveDeltas: histogram.PositiveDeltas,
+		// Timestamps MUST be int64 counted as milliseconds since the Unix epoch.
+		Timestamp: histogram.Time.UnixMilli(),
+	}
+}
+
+func toProtoBucketSpans(spans []BucketSpan) []*prompb.BucketSpan {
+	protoSpans := make([]*prompb.BucketSpan, len(spans))
+	for i, span := range spans {
+		protoSpans[i] = &prompb.BucketSpan{
+			Offset: span.Offset,
+			Length: span.Length,
+		}
+	}
+
+	return protoSpans
+}
+
 // convertLabels checks whether the labels are valid, formats and converts them according to the spec.
 //
 // Spec Ref:
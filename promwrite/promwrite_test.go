@@ -2,11 +2,21 @@ package promwrite_test
 
 import (
 	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
 	"github.com/gustavooferreira/prometheus-metrics-generator/promwrite"
 )
 
@@ -49,3 +59,74 @@ func TestPrometheusRemoteWriter(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestPrometheusRemoteWriterNativeHistogram(t *testing.T) {
+	t.Run("should send native histograms and their stale markers", func(t *testing.T) {
+		var writeRequest prompb.WriteRequest
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			compressed, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+
+			body, err := snappy.Decode(nil, compressed)
+			require.NoError(t, err)
+
+			err = proto.Unmarshal(body, &writeRequest)
+			require.NoError(t, err)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		remoteWriter, err := promwrite.NewPrometheusRemoteWriter(promwrite.PrometheusRemoteWriterConfig{Endpoint: server.URL})
+		require.NoError(t, err)
+
+		timestamp := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+		desc := promadapter.Desc{MetricFamily: "request_duration_seconds", MetricType: promadapter.MetricTypeNativeHistogram}
+
+		metricResults := []promadapter.MetricResult{
+			{
+				Desc:      desc,
+				LabelsSet: map[string]string{"code": "200"},
+				Timestamp: timestamp,
+				NativeHistogram: promadapter.NativeHistogramValue{
+					Schema:         3,
+					ZeroThreshold:  0.001,
+					ZeroCount:      1,
+					Count:          6,
+					Sum:            2.5,
+					PositiveSpans:  []metrics.NativeHistogramBucketSpan{{Offset: -2, Length: 2}},
+					PositiveDeltas: []int64{2, 1},
+				},
+			},
+			{
+				Desc:        desc,
+				LabelsSet:   map[string]string{"code": "500"},
+				Timestamp:   timestamp,
+				StaleMarker: true,
+			},
+		}
+
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(desc.MetricFamily, metricResults)
+		err = remoteWriter.Send(context.Background(), timeSeries)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, len(writeRequest.Timeseries))
+
+		require.Equal(t, 0, len(writeRequest.Timeseries[0].Samples))
+		require.Equal(t, 1, len(writeRequest.Timeseries[0].Histograms))
+		histogram := writeRequest.Timeseries[0].Histograms[0]
+		assert.Equal(t, timestamp.UnixMilli(), histogram.Timestamp)
+		assert.Equal(t, int32(3), histogram.Schema)
+		assert.Equal(t, 0.001, histogram.ZeroThreshold)
+		assert.Equal(t, uint64(1), histogram.GetZeroCountInt())
+		assert.Equal(t, uint64(6), histogram.GetCountInt())
+		assert.Equal(t, 2.5, histogram.Sum)
+		assert.Equal(t, []*prompb.BucketSpan{{Offset: -2, Length: 2}}, histogram.PositiveSpans)
+		assert.Equal(t, []int64{2, 1}, histogram.PositiveDeltas)
+
+		require.Equal(t, 1, len(writeRequest.Timeseries[1].Histograms))
+		staleHistogram := writeRequest.Timeseries[1].Histograms[0]
+		assert.Equal(t, uint64(0x7ff0000000000002), math.Float64bits(staleHistogram.Sum))
+	})
+}
@@ -1,6 +1,12 @@
 package promwrite
 
-import "github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+import (
+	"math"
+	"strconv"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+)
 
 // ConvertToRemoteWriterTimeSeries takes a slice of metric results and creates the corresponding slice of time series
 // in the format the PrometheusRemoteWriter expects.
@@ -8,36 +14,173 @@ func ConvertToRemoteWriterTimeSeries(metricName string, metricResults []promadap
 	var remoteWriterTimeSeries []TimeSeries
 
 	for _, metricResult := range metricResults {
-		labels := make([]Label, 0, len(metricResult.LabelsSet))
+		if metricResult.Desc.MetricType == promadapter.MetricTypeHistogram {
+			remoteWriterTimeSeries = append(remoteWriterTimeSeries, convertHistogram(metricName, metricResult)...)
+			continue
+		}
 
-		labels = append(labels, Label{
-			Name:  "__name__",
-			Value: metricName,
-		})
+		if metricResult.Desc.MetricType == promadapter.MetricTypeSummary {
+			remoteWriterTimeSeries = append(remoteWriterTimeSeries, convertSummary(metricName, metricResult)...)
+			continue
+		}
 
-		for labelName, labelValue := range metricResult.LabelsSet {
-			labels = append(labels, Label{
-				Name:  labelName,
-				Value: labelValue,
+		if metricResult.Desc.MetricType == promadapter.MetricTypeNativeHistogram {
+			remoteWriterTimeSeries = append(remoteWriterTimeSeries, TimeSeries{
+				Labels:     buildLabels(metricName, metricResult.LabelsSet),
+				Histograms: []Histogram{convertNativeHistogram(metricResult)},
 			})
+			continue
 		}
 
-		sampleValue := metricResult.Value
+		labels := buildLabels(metricName, metricResult.LabelsSet)
 
-		if metricResult.StaleMarker {
-			sampleValue = staleMarker
-		}
+		remoteWriterTimeSeries = append(remoteWriterTimeSeries, newSingleSampleTimeSeries(labels, metricResult, metricResult.Value))
+	}
 
-		remoteWriterSingleTimeSeries := TimeSeries{
-			Labels: labels,
-			Samples: []Sample{{
-				Time:  metricResult.Timestamp,
-				Value: sampleValue,
-			}},
+	return remoteWriterTimeSeries
+}
+
+// convertNativeHistogram converts the native histogram of the metric result into the format the PrometheusRemoteWriter
+// expects.
+// Stale markers are signalled by setting the sum of an empty histogram to the stale marker value.
+func convertNativeHistogram(metricResult promadapter.MetricResult) Histogram {
+	if metricResult.StaleMarker {
+		return Histogram{
+			Time: metricResult.Timestamp,
+			Sum:  staleMarker,
 		}
+	}
+
+	nativeHistogram := metricResult.NativeHistogram
 
-		remoteWriterTimeSeries = append(remoteWriterTimeSeries, remoteWriterSingleTimeSeries)
+	histogram := Histogram{
+		Time:           metricResult.Timestamp,
+		Schema:         nativeHistogram.Schema,
+		ZeroThreshold:  nativeHistogram.ZeroThreshold,
+		ZeroCount:      nativeHistogram.ZeroCount,
+		Count:          nativeHistogram.Count,
+		Sum:            nativeHistogram.Sum,
+		PositiveDeltas: nativeHistogram.PositiveDeltas,
+		NegativeDeltas: nativeHistogram.NegativeDeltas,
 	}
 
+	for _, span := range nativeHistogram.PositiveSpans {
+		histogram.PositiveSpans = append(histogram.PositiveSpans, BucketSpan{Offset: span.Offset, Length: span.Length})
+	}
+
+	for _, span := range nativeHistogram.NegativeSpans {
+		histogram.NegativeSpans = append(histogram.NegativeSpans, BucketSpan{Offset: span.Offset, Length: span.Length})
+	}
+
+	return histogram
+}
+
+// convertHistogram converts the histogram of the metric result into the time series the PrometheusRemoteWriter expects.
+// A histogram is made of a <name>_bucket time series for each bucket, identified by the le label, including the +Inf
+// bucket, as well as the <name>_sum and <name>_count time series.
+// Stale markers are sent for every one of these time series.
+// Inconsistent histograms cannot be sent, so they produce no time series.
+func convertHistogram(metricName string, metricResult promadapter.MetricResult) []TimeSeries {
+	histogram := metricResult.Histogram
+	if histogram.Err != nil {
+		return nil
+	}
+
+	remoteWriterTimeSeries := make([]TimeSeries, 0, len(histogram.Buckets)+3)
+
+	// The +Inf bucket is usually implicit, in which case its value is the count of the histogram.
+	buckets := make([]metrics.HistogramBucketScrape, 0, len(histogram.Buckets)+1)
+	buckets = append(buckets, histogram.Buckets...)
+	if len(buckets) == 0 || !math.IsInf(buckets[len(buckets)-1].LE, 1) {
+		buckets = append(buckets, metrics.HistogramBucketScrape{LE: math.Inf(1), Value: histogram.Count})
+	}
+
+	for _, bucket := range buckets {
+		labels := buildLabels(metricName+"_bucket", metricResult.LabelsSet)
+		labels = append(labels, Label{
+			Name:  "le",
+			Value: formatFloatLabelValue(bucket.LE),
+		})
+
+		remoteWriterTimeSeries = append(remoteWriterTimeSeries, newSingleSampleTimeSeries(labels, metricResult, bucket.Value))
+	}
+
+	remoteWriterTimeSeries = append(remoteWriterTimeSeries,
+		newSingleSampleTimeSeries(buildLabels(metricName+"_sum", metricResult.LabelsSet), metricResult, histogram.Sum),
+		newSingleSampleTimeSeries(buildLabels(metricName+"_count", metricResult.LabelsSet), metricResult, histogram.Count),
+	)
+
 	return remoteWriterTimeSeries
 }
+
+// convertSummary converts the summary of the metric result into the time series the PrometheusRemoteWriter expects.
+// A summary is made of a time series for each quantile, identified by the quantile label, as well as the <name>_sum and
+// <name>_count time series.
+// Stale markers are sent for every one of these time series.
+func convertSummary(metricName string, metricResult promadapter.MetricResult) []TimeSeries {
+	summary := metricResult.Summary
+
+	remoteWriterTimeSeries := make([]TimeSeries, 0, len(summary.Quantiles)+2)
+
+	for _, quantile := range summary.Quantiles {
+		labels := buildLabels(metricName, metricResult.LabelsSet)
+		labels = append(labels, Label{
+			Name:  "quantile",
+			Value: formatFloatLabelValue(quantile.Quantile),
+		})
+
+		remoteWriterTimeSeries = append(remoteWriterTimeSeries, newSingleSampleTimeSeries(labels, metricResult, quantile.Value))
+	}
+
+	remoteWriterTimeSeries = append(remoteWriterTimeSeries,
+		newSingleSampleTimeSeries(buildLabels(metricName+"_sum", metricResult.LabelsSet), metricResult, summary.Sum),
+		newSingleSampleTimeSeries(buildLabels(metricName+"_count", metricResult.LabelsSet), metricResult, summary.Count),
+	)
+
+	return remoteWriterTimeSeries
+}
+
+// buildLabels returns the labels of a time series, including the __name__ label.
+func buildLabels(metricName string, labelsSet map[string]string) []Label {
+	labels := make([]Label, 0, len(labelsSet)+1)
+
+	labels = append(labels, Label{
+		Name:  "__name__",
+		Value: metricName,
+	})
+
+	for labelName, labelValue := range labelsSet {
+		labels = append(labels, Label{
+			Name:  labelName,
+			Value: labelValue,
+		})
+	}
+
+	return labels
+}
+
+// newSingleSampleTimeSeries returns a time series with a single sample, which is replaced by the stale marker if the
+// metric result is flagged as stale.
+func newSingleSampleTimeSeries(labels []Label, metricResult promadapter.MetricResult, sampleValue float64) TimeSeries {
+	if metricResult.StaleMarker {
+		sampleValue = staleMarker
+	}
+
+	return TimeSeries{
+		Labels: labels,
+		Samples: []Sample{{
+			Time:  metricResult.Timestamp,
+			Value: sampleValue,
+		}},
+	}
+}
+
+// formatFloatLabelValue formats the le and quantile label values the same way the prometheus client library does, so
+// the time series match the ones scraped from the /metrics endpoint.
+func formatFloatLabelValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,180 @@
+package promwrite_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gustavooferreira/prometheus-metrics-generator/metrics"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promadapter"
+	"github.com/gustavooferreira/prometheus-metrics-generator/promwrite"
+)
+
+func TestConvertToRemoteWriterTimeSeries(t *testing.T) {
+	timestamp := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	histogramDesc := promadapter.Desc{MetricFamily: "request_duration_seconds", MetricType: promadapter.MetricTypeHistogram}
+	histogram := promadapter.HistogramValue{
+		Buckets: []metrics.HistogramBucketScrape{{LE: 0.005, Value: 1}, {LE: 1, Value: 4}, {LE: 1e6, Value: 5}},
+		Count:   6,
+		Sum:     2.5,
+	}
+
+	t.Run("should convert histograms into bucket, sum and count time series", func(t *testing.T) {
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(histogramDesc.MetricFamily, []promadapter.MetricResult{{
+			Desc:      histogramDesc,
+			LabelsSet: map[string]string{"code": "200"},
+			Timestamp: timestamp,
+			Histogram: histogram,
+		}})
+
+		bucketTimeSeries := func(le string, value float64) promwrite.TimeSeries {
+			return promwrite.TimeSeries{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds_bucket"},
+					{Name: "code", Value: "200"},
+					{Name: "le", Value: le},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: value}},
+			}
+		}
+
+		expected := []promwrite.TimeSeries{
+			bucketTimeSeries("0.005", 1),
+			bucketTimeSeries("1", 4),
+			bucketTimeSeries("1e+06", 5),
+			bucketTimeSeries("+Inf", 6),
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds_sum"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 2.5}},
+			},
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds_count"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 6}},
+			},
+		}
+
+		assert.Equal(t, expected, timeSeries)
+	})
+
+	t.Run("should send stale markers for every time series of a histogram", func(t *testing.T) {
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(histogramDesc.MetricFamily, []promadapter.MetricResult{{
+			Desc:        histogramDesc,
+			LabelsSet:   map[string]string{"code": "200"},
+			Timestamp:   timestamp,
+			Histogram:   histogram,
+			StaleMarker: true,
+		}})
+
+		require.Equal(t, 6, len(timeSeries))
+		for _, singleTimeSeries := range timeSeries {
+			require.Equal(t, 1, len(singleTimeSeries.Samples))
+			assert.Equal(t, uint64(0x7ff0000000000002), math.Float64bits(singleTimeSeries.Samples[0].Value))
+		}
+	})
+
+	t.Run("should not duplicate an explicit +Inf bucket", func(t *testing.T) {
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(histogramDesc.MetricFamily, []promadapter.MetricResult{{
+			Desc:      histogramDesc,
+			Timestamp: timestamp,
+			Histogram: promadapter.HistogramValue{
+				Buckets: []metrics.HistogramBucketScrape{{LE: 1, Value: 4}, {LE: math.Inf(1), Value: 6}},
+				Count:   6,
+				Sum:     2.5,
+			},
+		}})
+
+		// 2 buckets, sum and count.
+		assert.Equal(t, 4, len(timeSeries))
+	})
+
+	t.Run("should not send inconsistent histograms", func(t *testing.T) {
+		inconsistentHistogram := histogram
+		inconsistentHistogram.Err = errors.New("buckets are not cumulative")
+
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(histogramDesc.MetricFamily, []promadapter.MetricResult{{
+			Desc:      histogramDesc,
+			Timestamp: timestamp,
+			Histogram: inconsistentHistogram,
+		}})
+
+		assert.Empty(t, timeSeries)
+	})
+
+	desc := promadapter.Desc{MetricFamily: "request_duration_seconds", MetricType: promadapter.MetricTypeSummary}
+	summary := promadapter.SummaryValue{
+		Quantiles: []metrics.SummaryQuantileScrape{{Quantile: 0.5, Value: 0.2}, {Quantile: 0.99, Value: 1.5}},
+		Count:     10,
+		Sum:       3,
+	}
+
+	t.Run("should convert summaries into quantile, sum and count time series", func(t *testing.T) {
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(desc.MetricFamily, []promadapter.MetricResult{{
+			Desc:      desc,
+			LabelsSet: map[string]string{"code": "200"},
+			Timestamp: timestamp,
+			Summary:   summary,
+		}})
+
+		expected := []promwrite.TimeSeries{
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds"},
+					{Name: "code", Value: "200"},
+					{Name: "quantile", Value: "0.5"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 0.2}},
+			},
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds"},
+					{Name: "code", Value: "200"},
+					{Name: "quantile", Value: "0.99"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 1.5}},
+			},
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds_sum"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 3}},
+			},
+			{
+				Labels: []promwrite.Label{
+					{Name: "__name__", Value: "request_duration_seconds_count"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []promwrite.Sample{{Time: timestamp, Value: 10}},
+			},
+		}
+
+		assert.Equal(t, expected, timeSeries)
+	})
+
+	t.Run("should send stale markers for every time series of a summary", func(t *testing.T) {
+		timeSeries := promwrite.ConvertToRemoteWriterTimeSeries(desc.MetricFamily, []promadapter.MetricResult{{
+			Desc:        desc,
+			LabelsSet:   map[string]string{"code": "200"},
+			Timestamp:   timestamp,
+			Summary:     summary,
+			StaleMarker: true,
+		}})
+
+		require.Equal(t, 4, len(timeSeries))
+		for _, singleTimeSeries := range timeSeries {
+			require.Equal(t, 1, len(singleTimeSeries.Samples))
+			assert.Equal(t, uint64(0x7ff0000000000002), math.Float64bits(singleTimeSeries.Samples[0].Value))
+		}
+	})
+}